@@ -28,7 +28,7 @@ func New(logger *zap.SugaredLogger) Service {
 func (s *service) Intepret(ctx context.Context, req InterpretRequest) (*InterpretResponse, error) {
 	var errMessage string
 	var buf bytes.Buffer
-	err := interpreter.Interpret(req.Script, config.Config{Writer: &buf})
+	err := interpreter.Interpret(req.Script, config.Config{Writer: &buf, Warnings: &buf})
 	if err != nil {
 		errMessage = err.Error()
 		sentry.CaptureException(fmt.Errorf("Script: %s\nError: %s", req.Script, errMessage))
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"io/ioutil"
 	"log"
 	"os"
 
@@ -17,21 +16,25 @@ func main() {
 		HelpName:  "covid",
 		Usage:     "an useless tool for managing an useless language source code",
 		Version:   "0.0.1-alpha.2",
-		UsageText: "covid example.covs",
+		UsageText: "covid [--backend vm] example.covs",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "backend",
+				Value: string(config.BackendTreeWalker),
+				Usage: "execution backend, tree or vm",
+			},
+		},
 		Action: func(c *cli.Context) error {
 			fileName := c.Args().First()
 			if fileName == "" {
 				cli.ShowAppHelp(c)
 				return nil
 			}
-			b, err := ioutil.ReadFile(fileName)
-			if err != nil {
-				return err
-			}
-			err = interpreter.Interpret(string(b), config.Config{
-				Writer: os.Stdout,
+			return interpreter.InterpretFile(fileName, config.Config{
+				Writer:   os.Stdout,
+				Warnings: os.Stderr,
+				Backend:  config.Backend(c.String("backend")),
 			})
-			return err
 		},
 	}
 	err := app.Run(os.Args)
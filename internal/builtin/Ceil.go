@@ -8,21 +8,19 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Ceil() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Ceil() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "num"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("num")
-			lexp, ok := arg.(*core.LiteralExpression)
+			lexp, ok := arg.(*core.LiteralValue)
 			if !ok || lexp.Type != core.LiteralTypeNumber {
-				return nil, fmt.Errorf("Runtime error: unexpected %s as argument type of floor, expected number.", arg.GetType())
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of floor, expected number", arg.GetType())}
 			}
 			f, _ := strconv.ParseFloat(lexp.Value, 64)
-			return &core.LiteralExpression{
-				Type:   core.LiteralTypeNumber,
-				Value:  fmt.Sprintf("%v", math.Ceil(f)),
-				Line:   lexp.Line,
-				CharAt: lexp.CharAt,
+			return &core.LiteralValue{
+				Type:  core.LiteralTypeNumber,
+				Value: fmt.Sprintf("%v", math.Ceil(f)),
 			}, nil
 		},
 	}
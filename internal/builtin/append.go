@@ -6,25 +6,20 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Append() *core.FunctionExpression {
-	return &core.FunctionExpression{
-		Params: []core.Identifier{{Name: "array"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+func Append() *core.FunctionValue {
+	return &core.FunctionValue{
+		Params: []core.Identifier{{Name: "array"}, {Name: "elements", Rest: true}},
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("array")
-			aexp, ok := arg.(*core.ArrayExpression)
+			aexp, ok := arg.(*core.ArrayValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: unexpected %s as argument type of append, expected array.", arg.GetType())
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of append, expected array", arg.GetType())}
 			}
-			result := &core.ArrayExpression{
-				Elements: aexp.Elements,
-			}
-			for i := 1; ; i++ {
-				if arg, ok := ec.Variables[fmt.Sprintf("_args%d_", i)]; ok {
-					result.Elements = append(result.Elements, arg)
-				} else {
-					break
-				}
+			result := &core.ArrayValue{
+				Elements: append([]core.Value{}, aexp.Elements...),
 			}
+			elements, _ := ec.Get("elements")
+			result.Elements = append(result.Elements, elements.(*core.ArrayValue).Elements...)
 			return result, nil
 		},
 	}
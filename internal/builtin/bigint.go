@@ -0,0 +1,39 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+func BigInt() *core.FunctionValue {
+	return &core.FunctionValue{
+		Params: []core.Identifier{{Name: "value"}},
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
+			arg, _ := ec.Get("value")
+			lexp, ok := arg.(*core.LiteralValue)
+			if !ok || (!core.IsNumeric(lexp.Type) && lexp.Type != core.LiteralTypeString) {
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of bigint, expected number or string", arg.GetType())}
+			}
+			switch {
+			case lexp.Type == core.LiteralTypeString:
+				if i, ok := new(big.Int).SetString(lexp.Value, 10); ok {
+					return core.NewBigInt(i), nil
+				}
+			case core.NumberKind(lexp) == core.NumberTypeFloat:
+				f := lexp.Float()
+				if f == math.Trunc(f) && !math.IsInf(f, 0) {
+					i, _ := new(big.Float).SetFloat64(f).Int(nil)
+					return core.NewBigInt(i), nil
+				}
+			default:
+				if r, _ := lexp.Rat(); r.IsInt() {
+					return core.NewBigInt(r.Num()), nil
+				}
+			}
+			return nil, core.RuntimeError{Message: fmt.Sprintf("cannot convert %s %s to bigint", lexp.GetType(), lexp.Value)}
+		},
+	}
+}
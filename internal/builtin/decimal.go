@@ -0,0 +1,67 @@
+package builtin
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+// Decimal converts a value to a decimal, it is rounded to scale digits after the dot when a scale is given.
+// The rounding mode is half_even unless a mode is given.
+func Decimal() *core.FunctionValue {
+	return &core.FunctionValue{
+		Params: []core.Identifier{{Name: "value"}, {Name: "scale"}, {Name: "mode"}},
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
+			arg, _ := ec.Get("value")
+			lexp, ok := arg.(*core.LiteralValue)
+			if !ok || (!core.IsNumeric(lexp.Type) && lexp.Type != core.LiteralTypeString) {
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of decimal, expected number or string", arg.GetType())}
+			}
+			var d core.Decimal
+			switch {
+			case lexp.Type == core.LiteralTypeString:
+				d, ok = core.ParseDecimal(lexp.Value)
+			case core.NumberKind(lexp) == core.NumberTypeFloat:
+				f := lexp.Float()
+				ok = !math.IsInf(f, 0) && !math.IsNaN(f)
+				if ok {
+					d, _ = core.ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+				}
+			default:
+				d = lexp.Decimal()
+			}
+			if !ok {
+				return nil, core.RuntimeError{Message: fmt.Sprintf("cannot convert %s %s to decimal", lexp.GetType(), lexp.Value)}
+			}
+			arg2, _ := ec.Get("scale")
+			scale, ok := arg2.(*core.LiteralValue)
+			if !ok || scale.Type == core.LiteralTypeUndefined {
+				return core.NewDecimalValue(d), nil
+			}
+			s, ok := scale.Int()
+			if scale.Type != core.LiteralTypeNumber || !ok || s < 0 {
+				return nil, core.RuntimeError{Message: "second argument must be a non negative int"}
+			}
+			mode, err := roundingMode(ec)
+			if err != nil {
+				return nil, err
+			}
+			return core.NewDecimalValue(d.Rescale(int(s), mode)), nil
+		},
+	}
+}
+
+// roundingMode returns the rounding mode given as the mode argument, half_even when it is not given
+func roundingMode(ec *core.ExecutionContext) (core.RoundingMode, error) {
+	arg, _ := ec.Get("mode")
+	m, ok := arg.(*core.LiteralValue)
+	if !ok || m.Type == core.LiteralTypeUndefined {
+		return core.RoundHalfEven, nil
+	}
+	if m.Type != core.LiteralTypeString || !core.IsRoundingMode(m.Value) {
+		return "", core.RuntimeError{Message: fmt.Sprintf("unknown rounding mode %s", m.ToString())}
+	}
+	return core.RoundingMode(m.Value), nil
+}
@@ -1,33 +1,32 @@
 package builtin
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Delete() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Delete() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{
 			{Name: "input"},
 			{Name: "key"},
 		},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			inp, _ := ec.Get("input")
 			arg2, _ := ec.Get("key")
 			switch exp := inp.(type) {
-			case (*core.ArrayExpression):
-				result := &core.ArrayExpression{
-					Elements: []core.Expression{},
+			case (*core.ArrayValue):
+				result := &core.ArrayValue{
+					Elements: []core.Value{},
 				}
-				lexp, ok := arg2.(*core.LiteralExpression)
+				lexp, ok := arg2.(*core.LiteralValue)
 				if !ok || lexp.Type != core.LiteralTypeNumber {
-					return nil, fmt.Errorf("Runtime error: second argument must be integer when deleting array element.")
+					return nil, core.RuntimeError{Message: "second argument must be integer when deleting array element"}
 				}
 				idx, err := strconv.Atoi(lexp.Value)
 				if err != nil {
-					return nil, fmt.Errorf("Runtime error: second argument must be integer when deleting array element.")
+					return nil, core.RuntimeError{Message: "second argument must be integer when deleting array element"}
 				}
 				for i, e := range exp.Elements {
 					if i != idx {
@@ -35,33 +34,18 @@ func Delete() *core.FunctionExpression {
 					}
 				}
 				return result, nil
-			case (*core.ObjectExpression):
-				result := &core.ObjectExpression{
-					Properties: []*core.ObjectProperty{},
+			case (*core.ObjectValue):
+				result := &core.ObjectValue{
+					Properties: []*core.PropertyValue{},
 				}
 				for _, prop := range exp.Properties {
-					var key core.Expression
-					if !prop.Computed {
-						key = &core.LiteralExpression{
-							Type:  core.LiteralTypeString,
-							Value: prop.KeyIdentifier.Name,
-						}
-					} else {
-						key = prop.KeyExpression
-					}
-					bexp := &core.BinaryExpression{
-						Left:     arg2,
-						Right:    key,
-						Operator: core.Operator{Symbol: "=="},
-					}
-					rexp, _ := bexp.Evaluate(ec)
-					if !rexp.IsTruthy() {
+					if !core.IsEqual(arg2, prop.Key) {
 						result.Properties = append(result.Properties, prop)
 					}
 				}
 				return result, nil
 			}
-			return nil, fmt.Errorf("Runtime error: first argument of filter must be array or object.")
+			return nil, core.RuntimeError{Message: "first argument of filter must be array or object"}
 		},
 	}
 }
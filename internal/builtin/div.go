@@ -0,0 +1,45 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+// Div divides two ints, bigints or decimals into a decimal. The quotient is like the one of the / operator
+// unless a scale is given, then it has scale digits after the dot and is rounded according to the mode,
+// half_even unless a mode is given.
+func Div() *core.FunctionValue {
+	return &core.FunctionValue{
+		Params: []core.Identifier{{Name: "x"}, {Name: "y"}, {Name: "scale"}, {Name: "mode"}},
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
+			var operands [2]core.Decimal
+			for i, name := range []string{"x", "y"} {
+				arg, _ := ec.Get(name)
+				lexp, ok := arg.(*core.LiteralValue)
+				if !ok || !core.IsNumeric(lexp.Type) || core.NumberKind(lexp) == core.NumberTypeFloat {
+					return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of div, expected int, bigint or decimal", arg.GetType())}
+				}
+				operands[i] = lexp.Decimal()
+			}
+			x, y := operands[0], operands[1]
+			if y.Unscaled.Sign() == 0 {
+				return nil, core.RuntimeError{Message: "cannot divide by zero"}
+			}
+			arg, _ := ec.Get("scale")
+			scale, ok := arg.(*core.LiteralValue)
+			if !ok || scale.Type == core.LiteralTypeUndefined {
+				return core.NewDecimalValue(x.Quo(y)), nil
+			}
+			s, ok := scale.Int()
+			if scale.Type != core.LiteralTypeNumber || !ok || s < 0 {
+				return nil, core.RuntimeError{Message: "third argument must be a non negative int"}
+			}
+			mode, err := roundingMode(ec)
+			if err != nil {
+				return nil, err
+			}
+			return core.NewDecimalValue(x.QuoRound(y, int(s), mode)), nil
+		},
+	}
+}
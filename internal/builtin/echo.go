@@ -7,16 +7,14 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Echo(conf config.Config) *core.FunctionExpression {
-	return &core.FunctionExpression{
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+func Echo(conf config.Config) *core.FunctionValue {
+	return &core.FunctionValue{
+		Params: []core.Identifier{{Name: "values", Rest: true}},
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
+			values, _ := ec.Get("values")
 			s := ""
-			for i := 0; ; i++ {
-				if arg, ok := ec.Variables[fmt.Sprintf("_args%d_", i)]; ok {
-					s = s + fmt.Sprintf("%s ", arg.ToString())
-				} else {
-					break
-				}
+			for _, v := range values.(*core.ArrayValue).Elements {
+				s = s + fmt.Sprintf("%s ", v.ToString())
 			}
 			fmt.Fprintln(conf.Writer, s)
 			return nil, nil
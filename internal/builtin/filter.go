@@ -6,36 +6,32 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Filter() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Filter() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{
 			{Name: "input"},
 			{Name: "callback"},
 		},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			cb, _ := ec.Get("callback")
-			fexp, ok := cb.(*core.FunctionExpression)
+			fv, ok := cb.(*core.FunctionValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: second argument of filter must be function.")
+				return nil, core.RuntimeError{Message: "second argument of filter must be function"}
 			}
 			inp, _ := ec.Get("input")
 			switch exp := inp.(type) {
-			case (*core.ArrayExpression):
-				result := &core.ArrayExpression{
-					Elements: []core.Expression{},
+			case (*core.ArrayValue):
+				result := &core.ArrayValue{
+					Elements: []core.Value{},
 				}
 				for i, elem := range exp.Elements {
-					cexp := core.CallExpression{
-						Callee: fexp,
-						Arguments: []core.Expression{
-							elem,
-							&core.LiteralExpression{
-								Type:  core.LiteralTypeNumber,
-								Value: fmt.Sprintf("%d", i),
-							},
+					test, err := fv.Call([]core.Value{
+						elem,
+						&core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: fmt.Sprintf("%d", i),
 						},
-					}
-					test, err := cexp.Evaluate(ec)
+					})
 					if err != nil {
 						return nil, err
 					}
@@ -44,25 +40,12 @@ func Filter() *core.FunctionExpression {
 					}
 				}
 				return result, nil
-			case (*core.ObjectExpression):
-				result := &core.ObjectExpression{
-					Properties: []*core.ObjectProperty{},
+			case (*core.ObjectValue):
+				result := &core.ObjectValue{
+					Properties: []*core.PropertyValue{},
 				}
 				for _, prop := range exp.Properties {
-					var key core.Expression
-					if !prop.Computed {
-						key = &core.LiteralExpression{
-							Type:  core.LiteralTypeString,
-							Value: prop.KeyIdentifier.Name,
-						}
-					} else {
-						key = prop.KeyExpression
-					}
-					cexp := core.CallExpression{
-						Callee:    fexp,
-						Arguments: []core.Expression{prop.Value, key},
-					}
-					test, err := cexp.Evaluate(ec)
+					test, err := fv.Call([]core.Value{prop.Value, prop.Key})
 					if err != nil {
 						return nil, err
 					}
@@ -72,7 +55,7 @@ func Filter() *core.FunctionExpression {
 				}
 				return result, nil
 			}
-			return nil, fmt.Errorf("Runtime error: first argument of filter must be array or object.")
+			return nil, core.RuntimeError{Message: "first argument of filter must be array or object"}
 		},
 	}
 }
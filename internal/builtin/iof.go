@@ -6,31 +6,25 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func IndexOf() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func IndexOf() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "array"}, {Name: "elem"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg1, _ := ec.Get("array")
-			arexp, ok := arg1.(*core.ArrayExpression)
+			arexp, ok := arg1.(*core.ArrayValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: first argument must be array.")
+				return nil, core.RuntimeError{Message: "first argument must be array"}
 			}
 			arg2, _ := ec.Get("elem")
 			for i, elem := range arexp.Elements {
-				bexp := &core.BinaryExpression{
-					Left:     elem,
-					Right:    arg2,
-					Operator: core.Operator{Symbol: "=="},
-				}
-				rexp, _ := bexp.Evaluate(ec)
-				if rexp.IsTruthy() {
-					return &core.LiteralExpression{
+				if core.IsEqual(elem, arg2) {
+					return &core.LiteralValue{
 						Type:  core.LiteralTypeNumber,
 						Value: fmt.Sprintf("%d", i),
 					}, nil
 				}
 			}
-			return &core.LiteralExpression{
+			return &core.LiteralValue{
 				Type:  core.LiteralTypeNumber,
 				Value: "-1",
 			}, nil
@@ -6,19 +6,19 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Join() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Join() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "array"}, {Name: "separator"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg1, _ := ec.Get("array")
-			arexp, ok := arg1.(*core.ArrayExpression)
+			arexp, ok := arg1.(*core.ArrayValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: first argument must be array.")
+				return nil, core.RuntimeError{Message: "first argument must be array"}
 			}
 			arg2, _ := ec.Get("separator")
-			lexp, ok := arg2.(*core.LiteralExpression)
+			lexp, ok := arg2.(*core.LiteralValue)
 			if !ok || (lexp.Type != core.LiteralTypeString && lexp.Type != core.LiteralTypeUndefined) {
-				return nil, fmt.Errorf("Runtime error: second argument must be string.")
+				return nil, core.RuntimeError{Message: "second argument must be string"}
 			}
 			var sep string
 			if lexp.Type == core.LiteralTypeUndefined {
@@ -33,7 +33,7 @@ func Join() *core.FunctionExpression {
 			if len(arexp.Elements) > 0 {
 				result = result[:len(result)-len(sep)]
 			}
-			return &core.LiteralExpression{
+			return &core.LiteralValue{
 				Type:  core.LiteralTypeString,
 				Value: result,
 			}, nil
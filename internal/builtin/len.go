@@ -2,30 +2,31 @@ package builtin
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Len() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Len() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "inp"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("inp")
 			switch exp := arg.(type) {
-			case (*core.ArrayExpression):
-				return &core.LiteralExpression{
+			case (*core.ArrayValue):
+				return &core.LiteralValue{
 					Type:  core.LiteralTypeNumber,
 					Value: fmt.Sprintf("%d", len(exp.Elements)),
 				}, nil
-			case (*core.LiteralExpression):
+			case (*core.LiteralValue):
 				if exp.Type == core.LiteralTypeString {
-					return &core.LiteralExpression{
+					return &core.LiteralValue{
 						Type:  core.LiteralTypeNumber,
-						Value: fmt.Sprintf("%d", len(exp.Value)),
+						Value: fmt.Sprintf("%d", utf8.RuneCountInString(exp.Value)),
 					}, nil
 				}
 			}
-			return nil, fmt.Errorf("Runtime error: unexpected %s as argument type of len, expected array or string.", arg.GetType())
+			return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of len, expected array or string", arg.GetType())}
 		},
 	}
 }
@@ -6,20 +6,18 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Negative() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Negative() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "num"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("num")
-			lexp, ok := arg.(*core.LiteralExpression)
+			lexp, ok := arg.(*core.LiteralValue)
 			if !ok || lexp.Type != core.LiteralTypeNumber {
-				return nil, fmt.Errorf("Runtime error: unexpected %s as argument type of negative, expected number.", arg.GetType())
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of negative, expected number", arg.GetType())}
 			}
-			return &core.LiteralExpression{
-				Type:   core.LiteralTypeNumber,
-				Value:  fmt.Sprintf("-%s", lexp.Value),
-				Line:   lexp.Line,
-				CharAt: lexp.CharAt,
+			return &core.LiteralValue{
+				Type:  core.LiteralTypeNumber,
+				Value: fmt.Sprintf("-%s", lexp.Value),
 			}, nil
 		},
 	}
@@ -1,41 +1,44 @@
 package builtin
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Sort() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Sort() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{
 			{Name: "array"},
 			{Name: "comparator"},
 		},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("array")
-			aexp, ok := arg.(*core.ArrayExpression)
+			aexp, ok := arg.(*core.ArrayValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: first argument of sort must be array.")
+				return nil, core.RuntimeError{Message: "first argument of sort must be array"}
 			}
 			comp, _ := ec.Get("comparator")
-			fexp, ok := comp.(*core.FunctionExpression)
+			fv, ok := comp.(*core.FunctionValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: second argument of sort must be function.")
+				return nil, core.RuntimeError{Message: "second argument of sort must be function"}
 			}
+			var err error
 			sort.SliceStable(aexp.Elements, func(i, j int) bool {
-				cexp := core.CallExpression{
-					Callee: fexp,
-					Arguments: []core.Expression{
-						aexp.Elements[i],
-						aexp.Elements[j],
-					},
+				if err != nil {
+					return false
 				}
-				test, _ := cexp.Evaluate(ec)
-				return test != nil && test.IsTruthy()
+				var test core.Value
+				test, err = fv.Call([]core.Value{
+					aexp.Elements[i],
+					aexp.Elements[j],
+				})
+				return err == nil && test != nil && test.IsTruthy()
 			})
-			return &core.ArrayExpression{
+			if err != nil {
+				return nil, err
+			}
+			return &core.ArrayValue{
 				Elements: aexp.Elements,
 			}, nil
 		},
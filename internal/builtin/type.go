@@ -4,12 +4,18 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Type() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Type() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "input"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			inp, _ := ec.Get("input")
-			return &core.LiteralExpression{
+			if lv, ok := inp.(*core.LiteralValue); ok && lv.Type == core.LiteralTypeNumber {
+				return &core.LiteralValue{
+					Type:  core.LiteralTypeString,
+					Value: core.NumberType(lv.Value),
+				}, nil
+			}
+			return &core.LiteralValue{
 				Type:  core.LiteralTypeString,
 				Value: inp.GetType(),
 			}, nil
@@ -6,20 +6,20 @@ import (
 	"github.com/dhl1402/covidscript/internal/core"
 )
 
-func Values() *core.FunctionExpression {
-	return &core.FunctionExpression{
+func Values() *core.FunctionValue {
+	return &core.FunctionValue{
 		Params: []core.Identifier{{Name: "obj"}},
-		NativeFunction: func(ec *core.ExecutionContext) (core.Expression, error) {
+		NativeFunction: func(ec *core.ExecutionContext) (core.Value, error) {
 			arg, _ := ec.Get("obj")
-			oexp, ok := arg.(*core.ObjectExpression)
+			oexp, ok := arg.(*core.ObjectValue)
 			if !ok {
-				return nil, fmt.Errorf("Runtime error: unexpected %s as argument type of keys, expected object.", arg.GetType())
+				return nil, core.RuntimeError{Message: fmt.Sprintf("unexpected %s as argument type of keys, expected object", arg.GetType())}
 			}
-			result := []core.Expression{}
+			result := []core.Value{}
 			for _, prop := range oexp.Properties {
 				result = append(result, prop.Value)
 			}
-			return &core.ArrayExpression{
+			return &core.ArrayValue{
 				Elements: result,
 			}, nil
 		},
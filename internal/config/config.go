@@ -2,6 +2,17 @@ package config
 
 import "io"
 
+type Backend string
+
+const (
+	// BackendTreeWalker evaluates the AST directly, it is used when Backend is empty
+	BackendTreeWalker Backend = "tree"
+	// BackendVM compiles the AST to bytecode and runs it on a stack based virtual machine
+	BackendVM Backend = "vm"
+)
+
 type Config struct {
-	Writer io.Writer
+	Writer   io.Writer
+	Warnings io.Writer // receives the warnings of the scripts, one per line, they are dropped when it is nil
+	Backend  Backend
 }
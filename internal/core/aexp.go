@@ -6,23 +6,22 @@ type ArrayExpression struct {
 	CharAt   int
 }
 
-func (e *ArrayExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	elems := []Expression{}
+func (e *ArrayExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	elems := []Value{}
 	for _, ee := range e.Elements {
-		exp, err := ee.Evaluate(ec)
+		v, err := ee.Evaluate(ec)
 		if err != nil {
 			return nil, err
 		}
-		exp.SetLine(ee.GetLine())
-		exp.SetCharAt(ee.GetCharAt())
-		elems = append(elems, exp)
+		elems = append(elems, v)
 	}
-	e.Elements = elems
-	return e, nil
-}
-
-func (e *ArrayExpression) IsTruthy() bool {
-	return len(e.Elements) > 0
+	elems, err := Spread(e.Elements, elems)
+	if err != nil {
+		return nil, err
+	}
+	return &ArrayValue{
+		Elements: elems,
+	}, nil
 }
 
 func (e *ArrayExpression) GetCharAt() int {
@@ -33,14 +32,6 @@ func (e *ArrayExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *ArrayExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *ArrayExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *ArrayExpression) GetType() string {
 	return "array"
 }
@@ -55,15 +46,3 @@ func (e *ArrayExpression) ToString() string {
 	}
 	return s + "]"
 }
-
-func (e *ArrayExpression) Clone() Expression {
-	elems := []Expression{}
-	for _, elem := range e.Elements {
-		elems = append(elems, elem.Clone())
-	}
-	return &ArrayExpression{
-		Elements: elems,
-		Line:     e.Line,
-		CharAt:   e.CharAt,
-	}
-}
@@ -1,85 +1,122 @@
 package core
 
-import (
-	"fmt"
-	"strconv"
-)
-
 type AssignmentStatement struct {
-	Left   Expression
-	Right  Expression
-	Line   int
-	CharAt int
+	Left     Expression
+	Right    Expression
+	Operator *Operator // compound operator like += or ++, nil for =
+	Line     int
+	CharAt   int
 }
 
-func (stmt AssignmentStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt AssignmentStatement) Execute(ec *ExecutionContext) (Value, error) {
 	right, err := stmt.Right.Evaluate(ec)
 	if err != nil {
 		return nil, err
 	}
-	switch left := stmt.Left.(type) {
+	if left, ok := stmt.Left.(*TupleExpression); ok {
+		return nil, stmt.AssignTuple(ec, left, right)
+	}
+	return nil, stmt.assign(ec, stmt.Left, right)
+}
+
+func (stmt AssignmentStatement) assign(ec *ExecutionContext, left Expression, right Value) error {
+	switch left := left.(type) {
 	case (*VariableExpression):
-		if _, ok := ec.Get(left.Name); !ok {
-			return nil, fmt.Errorf("Runtime error: %s is not defined. [%d,%d]", left.Name, stmt.Line, stmt.CharAt)
-		}
-		ec.Assign(left.Name, right)
+		return stmt.AssignVariable(ec, left, right)
+	case (*Pattern):
+		return left.Assign(ec, right)
 	case (*MemberAccessExpression):
-		// do not need to handle error in this case because error have been already handled in the following `left.Evaluate(ec)``
-		var pexp *LiteralExpression
-		_, err := left.Evaluate(ec)
+		obj, err := left.Object.Evaluate(ec)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		obj, _ := left.Object.Evaluate(ec)
+		var prop Value
 		if left.Compute {
-			tmpExp, _ := left.PropertyExpression.Evaluate(ec)
-			lexp, _ := tmpExp.(*LiteralExpression)
-			pexp = lexp
-		}
-		switch o := obj.(type) {
-		case (*ObjectExpression):
-			for _, p := range o.Properties {
-				if p.Computed {
-					kexp, ok := p.KeyExpression.(*LiteralExpression)
-					if ok && (left.PropertyIdentifier.Name == kexp.Value || left.Compute && kexp.Type == pexp.Type && kexp.Value == pexp.Value) {
-						p.Value = right
-						return nil, nil
-					}
-				} else if p.KeyIdentifier.Name == left.PropertyIdentifier.Name || (pexp != nil && p.KeyIdentifier.Name == pexp.Value) {
-					p.Value = right
-					return nil, nil
-				}
-			}
-			newProp := &ObjectProperty{
-				KeyIdentifier: left.PropertyIdentifier,
-				KeyExpression: pexp,
-				Computed:      pexp != nil,
-				Value:         right,
-				Line:          right.GetLine(),
-				CharAt:        right.GetCharAt(),
-			}
-			if pexp == nil {
-				newProp.KeyExpression = nil
-			}
-			o.Properties = append(o.Properties, newProp)
-			return nil, nil
-		case (*ArrayExpression):
-			if i, err := strconv.Atoi(pexp.Value); err == nil {
-				o.Elements[i] = right
-				return nil, nil
+			prop, err = left.PropertyExpression.Evaluate(ec)
+			if err != nil {
+				return err
 			}
 		}
-	default:
-		return nil, fmt.Errorf("Runtime error: cannot identify variable. [%d,%d]", stmt.Left.GetLine(), stmt.Left.GetCharAt())
+		return stmt.AssignMember(left, obj, prop, right)
+	}
+	return RuntimeErrorf(left.GetLine(), left.GetCharAt(), "cannot identify variable")
+}
+
+// AssignTuple stores the values of the evaluated right side into the variables or properties of left, the
+// right side must give as many values
+func (stmt AssignmentStatement) AssignTuple(ec *ExecutionContext, left *TupleExpression, right Value) error {
+	values := []Value{right}
+	if t, ok := right.(*TupleValue); ok {
+		values = t.Elements
+	}
+	if len(values) != len(left.Elements) {
+		return mismatch(len(left.Elements), len(values), stmt.Line, stmt.CharAt)
+	}
+	for i, target := range left.Elements {
+		if err := stmt.assign(ec, target, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssignVariable stores the evaluated right side into the variable left
+func (stmt AssignmentStatement) AssignVariable(ec *ExecutionContext, left *VariableExpression, right Value) error {
+	if left.Binding == nil && ec.Constant(left.Name) {
+		return RuntimeErrorf(left.Line, left.CharAt, "cannot assign to constant %s", left.Name)
+	}
+	current, _ := ec.Lookup(left.Name, left.Binding)
+	v, err := stmt.Assigned(left, current, right)
+	if err != nil {
+		return err
+	}
+	ec.Update(left.Name, left.Binding, v)
+	return nil
+}
+
+// Assigned returns the value stored by stmt into the variable left holding current, current is nil when left
+// is not declared. The VM uses it for the variables it keeps out of execution contexts.
+func (stmt AssignmentStatement) Assigned(left *VariableExpression, current Value, right Value) (Value, error) {
+	if t, ok := right.(*TupleValue); ok {
+		return nil, mismatch(1, len(t.Elements), stmt.Line, stmt.CharAt)
+	}
+	if current == nil {
+		return nil, RuntimeErrorf(stmt.Line, stmt.CharAt, "%s is not defined", left.Name)
+	}
+	if stmt.Operator == nil {
+		return right, nil
+	}
+	return stmt.apply(current, right)
+}
+
+// AssignMember stores the evaluated right side into the property prop of the evaluated object obj
+func (stmt AssignmentStatement) AssignMember(left *MemberAccessExpression, obj Value, prop Value, right Value) error {
+	if t, ok := right.(*TupleValue); ok {
+		return mismatch(1, len(t.Elements), stmt.Line, stmt.CharAt)
+	}
+	if stmt.Operator != nil {
+		current, err := left.Access(obj, prop)
+		if err != nil {
+			return err
+		}
+		if right, err = stmt.apply(current, right); err != nil {
+			return err
+		}
 	}
-	return nil, nil
+	return left.Assign(obj, prop, right)
 }
 
-func (stmt AssignmentStatement) Clone() Statement {
-	return AssignmentStatement{
-		Left:   stmt.Left.Clone(),
-		Right:  stmt.Right.Clone(),
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
+// apply combines the current value of the target with the right side of a compound assignment,
+// += and ++ both use the + operator
+func (stmt AssignmentStatement) apply(current Value, right Value) (Value, error) {
+	op := *stmt.Operator
+	op.Symbol = op.Symbol[:1]
+	bexp := &BinaryExpression{
+		Left:     stmt.Left,
+		Right:    stmt.Right,
+		Operator: op,
+		Line:     stmt.Line,
+		CharAt:   stmt.CharAt,
 	}
+	return bexp.Apply(current, right)
 }
@@ -0,0 +1,24 @@
+package core
+
+type ArrayValue struct {
+	Elements []Value
+}
+
+func (v *ArrayValue) IsTruthy() bool {
+	return len(v.Elements) > 0
+}
+
+func (v *ArrayValue) GetType() string {
+	return "array"
+}
+
+func (v *ArrayValue) ToString() string {
+	s := "["
+	for _, elm := range v.Elements {
+		s = s + elm.ToString() + ", "
+	}
+	if len(s) > 1 {
+		s = s[:len(s)-2]
+	}
+	return s + "]"
+}
@@ -2,7 +2,7 @@ package core
 
 import (
 	"fmt"
-	"strconv"
+	"math"
 
 	"github.com/dhl1402/covidscript/internal/utils"
 )
@@ -16,55 +16,54 @@ type BinaryExpression struct {
 	CharAt   int
 }
 
-func (e *BinaryExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
+func (e *BinaryExpression) Evaluate(ec *ExecutionContext) (Value, error) {
 	if e.Operator.Symbol == "&&" {
-		left, err := e.Left.Evaluate(ec)
+		left, err := truthy(ec, e.Left)
 		if err != nil {
 			return nil, err
 		}
-		if !left.IsTruthy() {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  "#f",
-				Line:   e.Line,
-				CharAt: e.CharAt,
+		if !left {
+			return &LiteralValue{
+				Type:  LiteralTypeBoolean,
+				Value: "#f",
 			}, nil
 		}
-		right, err := e.Right.Evaluate(ec)
+		right, err := truthy(ec, e.Right)
 		if err != nil {
 			return nil, err
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(right.IsTruthy()),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(right),
 		}, nil
 	}
 	if e.Operator.Symbol == "||" {
-		left, err := e.Left.Evaluate(ec)
+		left, err := truthy(ec, e.Left)
 		if err != nil {
 			return nil, err
 		}
-		if left.IsTruthy() {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  "#t",
-				Line:   e.Line,
-				CharAt: e.CharAt,
+		if left {
+			return &LiteralValue{
+				Type:  LiteralTypeBoolean,
+				Value: "#t",
 			}, nil
 		}
-		right, err := e.Right.Evaluate(ec)
+		right, err := truthy(ec, e.Right)
 		if err != nil {
 			return nil, err
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(right.IsTruthy()),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(right),
 		}, nil
 	}
+	if e.Operator.Symbol == "??" {
+		left, err := e.Left.Evaluate(ec)
+		if err != nil || !IsNullish(left) {
+			return left, err
+		}
+		return e.Right.Evaluate(ec)
+	}
 	left, err := e.Left.Evaluate(ec)
 	if err != nil {
 		return nil, err
@@ -73,193 +72,438 @@ func (e *BinaryExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
 	if err != nil {
 		return nil, err
 	}
+	return e.Apply(left, right)
+}
+
+// Apply computes the result of a non short-circuit operator from the evaluated operands
+func (e *BinaryExpression) Apply(left Value, right Value) (Value, error) {
 	if e.Operator.Symbol == "==" {
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(isEqual(left, right)),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(IsEqual(left, right)),
 		}, nil
 	}
 	if e.Operator.Symbol == "!=" {
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(!isEqual(left, right)),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(!IsEqual(left, right)),
+		}, nil
+	}
+	if e.Operator.Symbol == "===" {
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(IsStrictEqual(left, right)),
 		}, nil
 	}
-	lle, ok := left.(*LiteralExpression)
+	if e.Operator.Symbol == "!==" {
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(!IsStrictEqual(left, right)),
+		}, nil
+	}
+	lle, ok := left.(*LiteralValue)
 	if !ok {
-		return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, left.GetType(), e.Operator.Line, e.Operator.CharAt)
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, left.GetType())
 	}
-	rle, ok := right.(*LiteralExpression)
+	rle, ok := right.(*LiteralValue)
 	if !ok {
-		return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, right.GetType(), e.Operator.Line, e.Operator.CharAt)
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, right.GetType())
 	}
-	if e.Operator.Symbol == "-" || e.Operator.Symbol == "*" || e.Operator.Symbol == "/" || e.Operator.Symbol == "%" {
-		if lle.Type != LiteralTypeNumber {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, lle.GetType(), e.Operator.Line, e.Operator.CharAt)
+	if isBitwise(e.Operator.Symbol) {
+		return e.applyBitwise(lle, rle)
+	}
+	if e.Operator.Symbol == "-" || e.Operator.Symbol == "*" || e.Operator.Symbol == "/" || e.Operator.Symbol == "%" || e.Operator.Symbol == "**" {
+		if !IsNumeric(lle.Type) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, lle.GetType())
 		}
-		if rle.Type != LiteralTypeNumber {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, rle.GetType(), e.Operator.Line, e.Operator.CharAt)
+		if !IsNumeric(rle.Type) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, rle.GetType())
 		}
 	}
 	if e.Operator.Symbol == ">" || e.Operator.Symbol == "<" || e.Operator.Symbol == ">=" || e.Operator.Symbol == "<=" {
-		if lle.Type != rle.Type {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with 2 different types. [%d,%d]", e.Operator.Symbol, e.Operator.Line, e.Operator.CharAt)
+		if lle.Type != rle.Type && !(IsNumeric(lle.Type) && IsNumeric(rle.Type)) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with 2 different types", e.Operator.Symbol)
 		}
 	}
-	ln, _ := strconv.ParseFloat(lle.Value, 64)
-	rn, _ := strconv.ParseFloat(rle.Value, 64)
+	if lle.Type == LiteralTypeNumber && rle.Type == LiteralTypeNumber {
+		return e.applyNumbers(lle, rle)
+	}
+	if IsNumeric(lle.Type) && IsNumeric(rle.Type) {
+		return e.applyExactNumbers(lle, rle)
+	}
 	switch e.Operator.Symbol {
 	case "+":
 		if lle.Type == LiteralTypeUndefined || lle.Type == LiteralTypeNull {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, lle.GetType(), e.Operator.Line, e.Operator.CharAt)
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, lle.GetType())
 		}
 		if rle.Type == LiteralTypeUndefined || rle.Type == LiteralTypeNull {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with %s. [%d,%d]", e.Operator.Symbol, rle.GetType(), e.Operator.Line, e.Operator.CharAt)
-		}
-		if lle.Type == LiteralTypeNumber && rle.Type == LiteralTypeNumber {
-			return &LiteralExpression{
-				Type:   LiteralTypeNumber,
-				Value:  fmt.Sprintf("%v", ln+rn),
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, rle.GetType())
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeString,
-			Value:  lle.Value + rle.Value,
-			Line:   e.Line,
-			CharAt: e.CharAt,
+		return &LiteralValue{
+			Type:  LiteralTypeString,
+			Value: lle.Value + rle.Value,
 		}, nil
-	case "-":
-		// handle number
-		return &LiteralExpression{
-			Type:   LiteralTypeNumber,
-			Value:  fmt.Sprintf("%v", ln-rn),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+	case ">":
+		// handle literal, same type
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(lle.Value > rle.Value),
 		}, nil
-	case "*":
-		// handle number
-		return &LiteralExpression{
-			Type:   LiteralTypeNumber,
-			Value:  fmt.Sprintf("%v", ln*rn),
-			Line:   e.Line,
-			CharAt: e.CharAt,
+	case "<":
+		// handle literal, same type
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(lle.Value < rle.Value),
 		}, nil
+	case ">=":
+		// handle literal, same type
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(lle.Value >= rle.Value),
+		}, nil
+	case "<=":
+		// handle literal, same type
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(lle.Value <= rle.Value),
+		}, nil
+	}
+	return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "operator %s is not supported", e.Operator.Symbol)
+}
+
+// applyNumbers computes the result of an operator between 2 numbers. The result is an int when both numbers are
+// ints, except for ** with a negative exponent, otherwise ints are promoted to floats.
+func (e *BinaryExpression) applyNumbers(lle *LiteralValue, rle *LiteralValue) (Value, error) {
+	li, lok := lle.Int()
+	ri, rok := rle.Int()
+	if !lok || !rok || (e.Operator.Symbol == "**" && ri < 0) {
+		return e.applyFloats(lle.Float(), rle.Float())
+	}
+	var n int64
+	ok := true
+	switch e.Operator.Symbol {
+	case "+":
+		n, ok = addInt(li, ri)
+	case "-":
+		n, ok = subInt(li, ri)
+	case "*":
+		n, ok = mulInt(li, ri)
+	case "**":
+		n, ok = powInt(li, ri)
 	case "/":
-		// handle number
-		if rn == 0 {
-			return nil, fmt.Errorf("Runtime error: cannot divide by zero. [%d,%d]", rle.Line, rle.CharAt)
+		// integer division truncates toward zero
+		if ri == 0 {
+			return nil, RuntimeErrorf(e.Right.GetLine(), e.Right.GetCharAt(), "cannot divide by zero")
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeNumber,
-			Value:  fmt.Sprintf("%v", ln/rn),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		n, ok = li/ri, li != math.MinInt64 || ri != -1
 	case "%":
-		// handle number
-		li, err := strconv.Atoi(lle.Value)
-		if err != nil {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with float. [%d,%d]", e.Operator.Symbol, e.Operator.Line, e.Operator.CharAt)
+		// the result has the sign of the dividend
+		if ri == 0 {
+			return nil, RuntimeErrorf(e.Right.GetLine(), e.Right.GetCharAt(), "cannot divide by zero")
 		}
-		ri, err := strconv.Atoi(rle.Value)
-		if err != nil {
-			return nil, fmt.Errorf("Runtime error: cannot use '%s' operator with float. [%d,%d]", e.Operator.Symbol, e.Operator.Line, e.Operator.CharAt)
-		}
-		return &LiteralExpression{
-			Type:   LiteralTypeNumber,
-			Value:  fmt.Sprintf("%v", li%ri),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		n = li % ri
 	case ">":
-		// handle literal, same type
-		if lle.Type == LiteralTypeNumber {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  utils.ToBoolStr(ln > rn),
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
-		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(lle.Value > rle.Value),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(li > ri)}, nil
 	case "<":
-		// handle literal, same type
-		if lle.Type == LiteralTypeNumber {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  utils.ToBoolStr(ln < rn),
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(li < ri)}, nil
+	case ">=":
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(li >= ri)}, nil
+	case "<=":
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(li <= ri)}, nil
+	default:
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "operator %s is not supported", e.Operator.Symbol)
+	}
+	if !ok {
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "integer overflow with '%s' operator", e.Operator.Symbol)
+	}
+	return NewInt(n), nil
+}
+
+func (e *BinaryExpression) applyFloats(ln float64, rn float64) (Value, error) {
+	switch e.Operator.Symbol {
+	case "+":
+		return NewFloat(ln + rn), nil
+	case "-":
+		return NewFloat(ln - rn), nil
+	case "*":
+		return NewFloat(ln * rn), nil
+	case "**":
+		return NewFloat(math.Pow(ln, rn)), nil
+	case "/":
+		if rn == 0 {
+			return nil, RuntimeErrorf(e.Right.GetLine(), e.Right.GetCharAt(), "cannot divide by zero")
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(lle.Value < rle.Value),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		return NewFloat(ln / rn), nil
+	case "%":
+		// the result has the sign of the dividend, like with ints
+		if rn == 0 {
+			return nil, RuntimeErrorf(e.Right.GetLine(), e.Right.GetCharAt(), "cannot divide by zero")
+		}
+		return NewFloat(math.Mod(ln, rn)), nil
+	case ">":
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(ln > rn)}, nil
+	case "<":
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(ln < rn)}, nil
 	case ">=":
-		// handle literal, same type
-		if lle.Type == LiteralTypeNumber {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  utils.ToBoolStr(ln >= rn),
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(ln >= rn)}, nil
+	case "<=":
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(ln <= rn)}, nil
+	}
+	return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "operator %s is not supported", e.Operator.Symbol)
+}
+
+// applyExactNumbers computes the result of an operator when one of the numbers is a bigint or a decimal. Ints
+// are promoted to the kind of the other operand, floats can only be compared to them since the result would
+// not be exact.
+func (e *BinaryExpression) applyExactNumbers(lle *LiteralValue, rle *LiteralValue) (Value, error) {
+	switch e.Operator.Symbol {
+	case ">", "<", ">=", "<=":
+		c, ok := compareNumbers(lle, rle)
+		return &LiteralValue{Type: LiteralTypeBoolean, Value: utils.ToBoolStr(ok && isComparison(e.Operator.Symbol, c))}, nil
+	}
+	lk, rk := NumberKind(lle), NumberKind(rle)
+	if lk == NumberTypeFloat || rk == NumberTypeFloat {
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s and %s", e.Operator.Symbol, lk, rk)
+	}
+	if e.Operator.Symbol == "**" {
+		if rk != NumberTypeInt && rk != string(LiteralTypeBigInt) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "exponent of '**' operator with %s must be an integer", lk)
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(lle.Value >= rle.Value),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		if rle.BigInt().Sign() < 0 && lk != string(LiteralTypeDecimal) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "exponent of '**' operator with %s must not be negative", lk)
+		}
+	}
+	if ((e.Operator.Symbol == "/" || e.Operator.Symbol == "%") && !rle.IsTruthy()) || (e.Operator.Symbol == "**" && rle.BigInt().Sign() < 0 && !lle.IsTruthy()) {
+		return nil, RuntimeErrorf(e.Right.GetLine(), e.Right.GetCharAt(), "cannot divide by zero")
+	}
+	if lle.Type == LiteralTypeDecimal || rle.Type == LiteralTypeDecimal {
+		ld, rd := lle.Decimal(), rle.Decimal()
+		switch e.Operator.Symbol {
+		case "+":
+			return NewDecimalValue(ld.Add(rd)), nil
+		case "-":
+			return NewDecimalValue(ld.Sub(rd)), nil
+		case "*":
+			return NewDecimalValue(ld.Mul(rd)), nil
+		case "/":
+			return NewDecimalValue(ld.Quo(rd)), nil
+		case "%":
+			return NewDecimalValue(ld.Rem(rd)), nil
+		case "**":
+			return NewDecimalValue(ld.Pow(rle.BigInt())), nil
+		}
+	} else {
+		li, ri := lle.BigInt(), rle.BigInt()
+		switch e.Operator.Symbol {
+		case "+":
+			return NewBigInt(li.Add(li, ri)), nil
+		case "-":
+			return NewBigInt(li.Sub(li, ri)), nil
+		case "*":
+			return NewBigInt(li.Mul(li, ri)), nil
+		case "/":
+			// integer division truncates toward zero
+			return NewBigInt(li.Quo(li, ri)), nil
+		case "%":
+			return NewBigInt(li.Rem(li, ri)), nil
+		case "**":
+			return NewBigInt(li.Exp(li, ri, nil)), nil
+		}
+	}
+	return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "operator %s is not supported", e.Operator.Symbol)
+}
+
+func isBitwise(op string) bool {
+	return op == "&" || op == "|" || op == "^" || op == "<<" || op == ">>" || op == ">>>"
+}
+
+// applyBitwise computes the result of a bitwise or shift operator between 2 integers. Ints are 64 bits two's
+// complement, so << drops the bits shifted out, >> keeps the sign and >>> shifts zeros in. An int and a
+// bigint give a bigint, >>> is not defined on bigints since they have no width.
+func (e *BinaryExpression) applyBitwise(lle *LiteralValue, rle *LiteralValue) (Value, error) {
+	for _, v := range []*LiteralValue{lle, rle} {
+		if !isInteger(v) {
+			return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with %s", e.Operator.Symbol, kindOf(v))
+		}
+	}
+	isShift := e.Operator.Symbol == "<<" || e.Operator.Symbol == ">>" || e.Operator.Symbol == ">>>"
+	if isShift && rle.BigInt().Sign() < 0 {
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "shift count of '%s' operator must not be negative", e.Operator.Symbol)
+	}
+	if lle.Type == LiteralTypeBigInt || (rle.Type == LiteralTypeBigInt && !isShift) {
+		li, ri := lle.BigInt(), rle.BigInt()
+		switch e.Operator.Symbol {
+		case "&":
+			return NewBigInt(li.And(li, ri)), nil
+		case "|":
+			return NewBigInt(li.Or(li, ri)), nil
+		case "^":
+			return NewBigInt(li.Xor(li, ri)), nil
+		case "<<", ">>":
+			if !ri.IsInt64() || ri.Int64() > math.MaxInt32 {
+				return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "shift count of '%s' operator is too large", e.Operator.Symbol)
+			}
+			if e.Operator.Symbol == "<<" {
+				return NewBigInt(li.Lsh(li, uint(ri.Int64()))), nil
+			}
+			return NewBigInt(li.Rsh(li, uint(ri.Int64()))), nil
+		}
+		return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "cannot use '%s' operator with bigint", e.Operator.Symbol)
+	}
+	li, _ := lle.Int()
+	ri, _ := rle.Int()
+	n := uint64(ri) // a count of 64 or more shifts every bit out
+	if !rle.BigInt().IsInt64() {
+		n = math.MaxUint64
+	}
+	switch e.Operator.Symbol {
+	case "&":
+		return NewInt(li & ri), nil
+	case "|":
+		return NewInt(li | ri), nil
+	case "^":
+		return NewInt(li ^ ri), nil
+	case "<<":
+		return NewInt(li << n), nil
+	case ">>":
+		return NewInt(li >> n), nil
+	case ">>>":
+		return NewInt(int64(uint64(li) >> n)), nil
+	}
+	return nil, RuntimeErrorf(e.Operator.Line, e.Operator.CharAt, "operator %s is not supported", e.Operator.Symbol)
+}
+
+func isInteger(v *LiteralValue) bool {
+	return v.Type == LiteralTypeBigInt || (v.Type == LiteralTypeNumber && NumberType(v.Value) == NumberTypeInt)
+}
+
+// kindOf returns the type of v, with the kind of number for numbers
+func kindOf(v Value) string {
+	if lv, ok := v.(*LiteralValue); ok && IsNumeric(lv.Type) {
+		return NumberKind(lv)
+	}
+	return v.GetType()
+}
+
+// compareNumbers returns -1, 0 or 1 when v1 is less than, equal to or greater than v2. It is false when a NaN
+// is compared.
+func compareNumbers(v1 *LiteralValue, v2 *LiteralValue) (int, bool) {
+	r1, ok1 := v1.Rat()
+	r2, ok2 := v2.Rat()
+	if ok1 && ok2 {
+		return r1.Cmp(r2), true
+	}
+	f1, f2 := v1.Float(), v2.Float()
+	if math.IsNaN(f1) || math.IsNaN(f2) {
+		return 0, false
+	}
+	if f1 < f2 {
+		return -1, true
+	}
+	if f1 > f2 {
+		return 1, true
+	}
+	return 0, true
+}
+
+func isComparison(op string, c int) bool {
+	switch op {
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	case ">=":
+		return c >= 0
 	case "<=":
-		// handle literal, same type
-		if lle.Type == LiteralTypeNumber {
-			return &LiteralExpression{
-				Type:   LiteralTypeBoolean,
-				Value:  utils.ToBoolStr(ln <= rn),
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+		return c <= 0
+	}
+	return false
+}
+
+// IsEqual compares primitive values by value, numbers of different kinds included, arrays and objects by
+// structure and other values by reference
+func IsEqual(v1 Value, v2 Value) bool {
+	return isEqual(v1, v2, map[[2]Value]bool{})
+}
+
+// isEqual compares v1 and v2 deeply, seen holds the pairs of arrays and objects being compared so that cyclic
+// values are considered equal instead of being compared forever
+func isEqual(v1 Value, v2 Value, seen map[[2]Value]bool) bool {
+	switch lv1 := v1.(type) {
+	case *LiteralValue:
+		lv2, ok := v2.(*LiteralValue)
+		if !ok {
+			return false
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeBoolean,
-			Value:  utils.ToBoolStr(lle.Value <= rle.Value),
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
+		// if both is primitive type
+		if lv1.Type == LiteralTypeNumber && lv2.Type == LiteralTypeNumber {
+			return isEqualNumber(lv1, lv2)
+		}
+		if IsNumeric(lv1.Type) && IsNumeric(lv2.Type) {
+			c, ok := compareNumbers(lv1, lv2)
+			return ok && c == 0
+		}
+		return lv1.Type == lv2.Type && lv1.Value == lv2.Value
+	case *ArrayValue:
+		av2, ok := v2.(*ArrayValue)
+		if !ok || len(lv1.Elements) != len(av2.Elements) {
+			return false
+		}
+		if lv1 == av2 || seen[[2]Value{v1, v2}] {
+			return true
+		}
+		seen[[2]Value{v1, v2}] = true
+		for i, elem := range lv1.Elements {
+			if !isEqual(elem, av2.Elements[i], seen) {
+				return false
+			}
+		}
+		return true
+	case *ObjectValue:
+		ov2, ok := v2.(*ObjectValue)
+		if !ok || len(lv1.Properties) != len(ov2.Properties) {
+			return false
+		}
+		if lv1 == ov2 || seen[[2]Value{v1, v2}] {
+			return true
+		}
+		seen[[2]Value{v1, v2}] = true
+		for _, p := range lv1.Properties {
+			v := ov2.Get(p.Key)
+			if v == nil || !isEqual(p.Value, v, seen) {
+				return false
+			}
+		}
+		return true
 	}
-	return nil, fmt.Errorf("Runtime error: operator %s is not supported. [%d,%d]", e.Operator.Symbol, e.Operator.Line, e.Operator.CharAt)
+	// otherwise compare pointer reference
+	return v1 == v2
 }
 
-func isEqual(e1 Expression, e2 Expression) bool {
-	le1, ok := e1.(*LiteralExpression)
+// IsStrictEqual compares primitive values by type and value, an int and a float are different, and other
+// values by reference
+func IsStrictEqual(v1 Value, v2 Value) bool {
+	lv1, ok := v1.(*LiteralValue)
 	if ok {
-		le2, ok := e2.(*LiteralExpression)
+		lv2, ok := v2.(*LiteralValue)
 		if ok {
-			// if both is primitive type
-			return le1.Type == le2.Type && le1.Value == le2.Value
+			if IsNumeric(lv1.Type) && IsNumeric(lv2.Type) {
+				return NumberKind(lv1) == NumberKind(lv2) && IsEqual(lv1, lv2)
+			}
+			return lv1.Type == lv2.Type && lv1.Value == lv2.Value
 		}
 	}
-	// otherwise compare pointer reference
-	return e1 == e2
+	return v1 == v2
 }
 
-func (e *BinaryExpression) IsTruthy() bool {
-	return true
+// isEqualNumber compares 2 numbers by value, an int and a float are equal when they are the same number
+func isEqualNumber(v1 *LiteralValue, v2 *LiteralValue) bool {
+	i1, ok1 := v1.Int()
+	i2, ok2 := v2.Int()
+	if ok1 && ok2 {
+		return i1 == i2
+	}
+	return v1.Float() == v2.Float()
 }
 
 func (e *BinaryExpression) GetCharAt() int {
@@ -270,14 +514,6 @@ func (e *BinaryExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *BinaryExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *BinaryExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *BinaryExpression) GetType() string {
 	return "expression"
 }
@@ -285,14 +521,3 @@ func (e *BinaryExpression) GetType() string {
 func (e *BinaryExpression) ToString() string {
 	return fmt.Sprintf("%s %s %s", e.Left.ToString(), e.Operator.Symbol, e.Right.ToString())
 }
-
-func (e *BinaryExpression) Clone() Expression {
-	return &BinaryExpression{
-		Left:     e.Left.Clone(),
-		Right:    e.Right.Clone(),
-		Operator: e.Operator,
-		Group:    e.Group,
-		Line:     e.Line,
-		CharAt:   e.CharAt,
-	}
-}
@@ -7,7 +7,7 @@ type BreakStatement struct {
 	CharAt int
 }
 
-func (stmt BreakStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt BreakStatement) Execute(ec *ExecutionContext) (Value, error) {
 	return nil, BreakError{
 		Message: fmt.Sprintf("break is not in a loop. [%d,%d]", stmt.Line, stmt.CharAt),
 	}
@@ -20,10 +20,3 @@ type BreakError struct {
 func (err BreakError) Error() string {
 	return err.Message
 }
-
-func (stmt BreakStatement) Clone() Statement {
-	return BreakStatement{
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
-	}
-}
@@ -6,7 +6,7 @@ type BlockStatement struct {
 	CharAt     int
 }
 
-func (stmt BlockStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt BlockStatement) Execute(ec *ExecutionContext) (Value, error) {
 	for _, s := range stmt.Statements {
 		rexp, err := s.Execute(ec)
 		if rexp != nil || err != nil {
@@ -15,15 +15,3 @@ func (stmt BlockStatement) Execute(ec *ExecutionContext) (Expression, error) {
 	}
 	return nil, nil
 }
-
-func (stmt BlockStatement) Clone() Statement {
-	stmts := []Statement{}
-	for _, s := range stmt.Statements {
-		stmts = append(stmts, s.Clone())
-	}
-	return BlockStatement{
-		Statements: stmts,
-		Line:       stmt.Line,
-		CharAt:     stmt.CharAt,
-	}
-}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"fmt"
+)
+
+// ConditionalExpression is test ? consequent : alternate
+type ConditionalExpression struct {
+	Test       Expression
+	Consequent Expression
+	Alternate  Expression
+	Line       int
+	CharAt     int
+}
+
+func (e *ConditionalExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	t, err := truthy(ec, e.Test)
+	if err != nil {
+		return nil, err
+	}
+	if t {
+		return e.Consequent.Evaluate(ec)
+	}
+	return e.Alternate.Evaluate(ec)
+}
+
+func (e *ConditionalExpression) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *ConditionalExpression) GetLine() int {
+	return e.Line
+}
+
+func (e *ConditionalExpression) GetType() string {
+	return "conditional expression"
+}
+
+func (e *ConditionalExpression) ToString() string {
+	return fmt.Sprintf("%s ? %s : %s", e.Test.ToString(), e.Consequent.ToString(), e.Alternate.ToString())
+}
@@ -0,0 +1,45 @@
+package core
+
+import "errors"
+
+// ChainExpression is a chain of member accesses and calls with at least one optional link like a?.b or f?.().
+// When the base of an optional link is null or undefined the rest of the chain is skipped and the chain is
+// undefined.
+type ChainExpression struct {
+	Expression Expression
+	Line       int
+	CharAt     int
+}
+
+// errShortCircuit is returned by an optional link to its chain, it never escapes a ChainExpression
+var errShortCircuit = errors.New("short-circuit")
+
+func (e *ChainExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	v, err := e.Expression.Evaluate(ec)
+	if err == errShortCircuit {
+		return &LiteralValue{Type: LiteralTypeUndefined}, nil
+	}
+	return v, err
+}
+
+// IsNullish reports whether v is null or undefined
+func IsNullish(v Value) bool {
+	lv, ok := v.(*LiteralValue)
+	return ok && (lv.Type == LiteralTypeNull || lv.Type == LiteralTypeUndefined)
+}
+
+func (e *ChainExpression) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *ChainExpression) GetLine() int {
+	return e.Line
+}
+
+func (e *ChainExpression) GetType() string {
+	return "chain expression"
+}
+
+func (e *ChainExpression) ToString() string {
+	return e.Expression.ToString()
+}
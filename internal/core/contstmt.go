@@ -7,7 +7,7 @@ type ContinueStatement struct {
 	CharAt int
 }
 
-func (stmt ContinueStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt ContinueStatement) Execute(ec *ExecutionContext) (Value, error) {
 	return nil, ContinueError{
 		Message: fmt.Sprintf("continue is not in a loop. [%d,%d]", stmt.Line, stmt.CharAt),
 	}
@@ -20,10 +20,3 @@ type ContinueError struct {
 func (err ContinueError) Error() string {
 	return err.Message
 }
-
-func (stmt ContinueStatement) Clone() Statement {
-	return ContinueStatement{
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
-	}
-}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Decimal is an exact decimal number, its value is Unscaled * 10^-Scale. The scale is kept by the arithmetic
+// so that 1.10 + 2.20 is 3.30.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// RoundingMode tells how a decimal is rounded when digits are dropped
+type RoundingMode string
+
+const (
+	RoundHalfEven RoundingMode = "half_even" // to the nearest neighbor, to the even one when both are as near
+	RoundHalfUp   RoundingMode = "half_up"   // to the nearest neighbor, away from zero when both are as near
+	RoundHalfDown RoundingMode = "half_down" // to the nearest neighbor, toward zero when both are as near
+	RoundUp       RoundingMode = "up"        // away from zero
+	RoundDown     RoundingMode = "down"      // toward zero
+	RoundCeiling  RoundingMode = "ceiling"   // toward positive infinity
+	RoundFloor    RoundingMode = "floor"     // toward negative infinity
+)
+
+var roundingModes = map[RoundingMode]bool{RoundHalfEven: true, RoundHalfUp: true, RoundHalfDown: true, RoundUp: true, RoundDown: true, RoundCeiling: true, RoundFloor: true}
+
+func IsRoundingMode(s string) bool {
+	return roundingModes[RoundingMode(s)]
+}
+
+// DivisionScale is the number of digits added after the scale of the operands when dividing decimals
+const DivisionScale = 16
+
+// ParseDecimal reads a decimal written like -12.30, .5 or 7
+func ParseDecimal(s string) (Decimal, bool) {
+	digits := s
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = len(s) - i - 1
+	}
+	if digits == "" || digits == "-" || digits == "+" || strings.ContainsAny(digits, "_eE") {
+		return Decimal{}, false
+	}
+	u, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, false
+	}
+	return Decimal{Unscaled: u, Scale: scale}, true
+}
+
+// NewDecimal returns the decimal value of the integer i
+func NewDecimal(i *big.Int) Decimal {
+	return Decimal{Unscaled: i, Scale: 0}
+}
+
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.Unscaled).String()
+	if d.Scale > 0 {
+		if len(s) <= d.Scale {
+			s = strings.Repeat("0", d.Scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+	}
+	if d.Unscaled.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale))
+}
+
+// Rescale returns d with scale digits after the dot, digits are dropped according to mode
+func (d Decimal) Rescale(scale int, mode RoundingMode) Decimal {
+	if scale >= d.Scale {
+		return Decimal{Unscaled: new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale)), Scale: scale}
+	}
+	return RoundRat(d.Rat(), scale, mode)
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{Unscaled: new(big.Int).Add(a.Unscaled, b.Unscaled), Scale: a.Scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{Unscaled: new(big.Int).Sub(a.Unscaled, b.Unscaled), Scale: a.Scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{Unscaled: new(big.Int).Mul(d.Unscaled, o.Unscaled), Scale: d.Scale + o.Scale}
+}
+
+// Quo divides d by a non zero o. The quotient is rounded half to even with DivisionScale more digits than the
+// operands, then its trailing zeros are dropped down to the scale of the operands.
+func (d Decimal) Quo(o Decimal) Decimal {
+	scale := max(d.Scale, o.Scale)
+	q := d.QuoRound(o, scale+DivisionScale, RoundHalfEven)
+	ten := big.NewInt(10)
+	for q.Scale > scale {
+		u, r := new(big.Int).QuoRem(q.Unscaled, ten, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		q = Decimal{Unscaled: u, Scale: q.Scale - 1}
+	}
+	return q
+}
+
+// QuoRound divides d by a non zero o, the quotient has scale digits after the dot and is rounded according to mode
+func (d Decimal) QuoRound(o Decimal, scale int, mode RoundingMode) Decimal {
+	return RoundRat(new(big.Rat).Quo(d.Rat(), o.Rat()), scale, mode)
+}
+
+// Rem returns the remainder of d truncated divided by a non zero o, it has the sign of d
+func (d Decimal) Rem(o Decimal) Decimal {
+	a, b := align(d, o)
+	return Decimal{Unscaled: new(big.Int).Rem(a.Unscaled, b.Unscaled), Scale: a.Scale}
+}
+
+// Pow raises d to the power n, a negative power divides 1 like Quo
+func (d Decimal) Pow(n *big.Int) Decimal {
+	e := new(big.Int).Abs(n)
+	p := Decimal{Unscaled: new(big.Int).Exp(d.Unscaled, e, nil), Scale: d.Scale * int(e.Int64())}
+	if n.Sign() < 0 {
+		return NewDecimal(big.NewInt(1)).Quo(p)
+	}
+	return p
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{Unscaled: new(big.Int).Neg(d.Unscaled), Scale: d.Scale}
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	a, b := align(d, o)
+	return a.Unscaled.Cmp(b.Unscaled)
+}
+
+// RoundRat returns the decimal with scale digits after the dot nearest to r according to mode
+func RoundRat(r *big.Rat, scale int, mode RoundingMode) Decimal {
+	n := new(big.Int).Mul(r.Num(), pow10(scale))
+	q, rem := new(big.Int).QuoRem(n, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		sign := n.Sign()
+		dropped := new(big.Int).Abs(rem)
+		half := dropped.Mul(dropped, big.NewInt(2)).Cmp(r.Denom()) // compare the dropped part with a half
+		away := false
+		switch mode {
+		case RoundUp:
+			away = true
+		case RoundCeiling:
+			away = sign > 0
+		case RoundFloor:
+			away = sign < 0
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfDown:
+			away = half > 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(sign)))
+		}
+	}
+	return Decimal{Unscaled: q, Scale: scale}
+}
+
+func align(a Decimal, b Decimal) (Decimal, Decimal) {
+	scale := max(a.Scale, b.Scale)
+	return a.Rescale(scale, RoundDown), b.Rescale(scale, RoundDown)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
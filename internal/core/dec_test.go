@@ -0,0 +1,65 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal_Rescale(t *testing.T) {
+	cases := []struct {
+		in    string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{in: "2.5", scale: 0, mode: RoundHalfEven, want: "2"},
+		{in: "3.5", scale: 0, mode: RoundHalfEven, want: "4"},
+		{in: "-2.5", scale: 0, mode: RoundHalfEven, want: "-2"},
+		{in: "2.51", scale: 0, mode: RoundHalfEven, want: "3"},
+		{in: "2.5", scale: 0, mode: RoundHalfUp, want: "3"},
+		{in: "-2.5", scale: 0, mode: RoundHalfUp, want: "-3"},
+		{in: "2.5", scale: 0, mode: RoundHalfDown, want: "2"},
+		{in: "2.51", scale: 0, mode: RoundHalfDown, want: "3"},
+		{in: "1.21", scale: 1, mode: RoundUp, want: "1.3"},
+		{in: "-1.21", scale: 1, mode: RoundUp, want: "-1.3"},
+		{in: "1.29", scale: 1, mode: RoundDown, want: "1.2"},
+		{in: "-1.29", scale: 1, mode: RoundDown, want: "-1.2"},
+		{in: "1.21", scale: 1, mode: RoundCeiling, want: "1.3"},
+		{in: "-1.29", scale: 1, mode: RoundCeiling, want: "-1.2"},
+		{in: "1.29", scale: 1, mode: RoundFloor, want: "1.2"},
+		{in: "-1.21", scale: 1, mode: RoundFloor, want: "-1.3"},
+		{in: "1.2", scale: 3, mode: RoundDown, want: "1.200"},
+		{in: "0.004", scale: 2, mode: RoundHalfUp, want: "0.00"},
+		{in: "-0.005", scale: 2, mode: RoundHalfUp, want: "-0.01"},
+	}
+	for _, tt := range cases {
+		t.Run(fmt.Sprintf("%s %d %s", tt.in, tt.scale, tt.mode), func(t *testing.T) {
+			d, ok := ParseDecimal(tt.in)
+			require.True(t, ok)
+			require.Equal(t, tt.want, d.Rescale(tt.scale, tt.mode).String())
+		})
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	d := func(s string) Decimal {
+		d, _ := ParseDecimal(s)
+		return d
+	}
+	require.Equal(t, "3.30", d("1.10").Add(d("2.20")).String())
+	require.Equal(t, "-0.05", d("0.1").Sub(d("0.15")).String())
+	require.Equal(t, "0.0200", d("0.10").Mul(d("0.20")).String())
+	require.Equal(t, "2.50", d("10.00").Quo(d("4")).String())
+	require.Equal(t, "0.3333333333333333", d("1").Quo(d("3")).String())
+	require.Equal(t, "0.6666666666666666667", d("2.000").Quo(d("3")).String())
+	require.Equal(t, "0.67", d("2").QuoRound(d("3"), 2, RoundHalfEven).String())
+	require.Equal(t, "0.66", d("2").QuoRound(d("3"), 2, RoundDown).String())
+	require.Equal(t, "-0.67", d("-2").QuoRound(d("3"), 2, RoundUp).String())
+	require.Equal(t, "0.12", d("0.25").QuoRound(d("2"), 2, RoundHalfEven).String())
+	require.Equal(t, "0.13", d("0.25").QuoRound(d("2"), 2, RoundHalfUp).String())
+	require.Equal(t, "-1.5", d("-7.5").Rem(d("2")).String())
+	require.Equal(t, 0, d("1.50").Cmp(d("1.5")))
+	require.Equal(t, -1, d("-1").Cmp(d("0.001")))
+}
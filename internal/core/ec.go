@@ -11,27 +11,41 @@ const (
 type ExecutionContext struct {
 	Type      ecType
 	Outer     *ExecutionContext
-	Variables map[string]Expression
+	Variables map[string]Value // nil until a variable is declared by name
+	Constants map[string]bool  // names of Variables which cannot be assigned, like the builtins
+	Slots     []Value          // local variables bound by the resolver, nil until they are declared
+	Exports   []string
+	Loader    ModuleLoader
 }
 
-func (ec *ExecutionContext) Get(s string) (Expression, bool) {
+// Binding is where the resolver found a local variable: slot Slot of the execution context Depth levels
+// above the one using it. Globals have no binding, they are looked up by name.
+type Binding struct {
+	Depth int
+	Slot  int
+}
+
+func (ec *ExecutionContext) Get(s string) (Value, bool) {
 	for ec != nil {
-		if exp, ok := ec.Variables[s]; ok {
-			return exp, ok
+		if v, ok := ec.Variables[s]; ok {
+			return v, ok
 		}
 		ec = ec.Outer
 	}
 	return nil, false
 }
 
-func (ec *ExecutionContext) Set(s string, exp Expression) {
-	ec.Variables[s] = exp
+func (ec *ExecutionContext) Set(s string, v Value) {
+	if ec.Variables == nil {
+		ec.Variables = map[string]Value{}
+	}
+	ec.Variables[s] = v
 }
 
-func (ec *ExecutionContext) Assign(s string, exp Expression) bool {
+func (ec *ExecutionContext) Assign(s string, v Value) bool {
 	for ec != nil {
 		if _, ok := ec.Variables[s]; ok {
-			ec.Variables[s] = exp
+			ec.Variables[s] = v
 			return true
 		}
 		ec = ec.Outer
@@ -39,14 +53,55 @@ func (ec *ExecutionContext) Assign(s string, exp Expression) bool {
 	return false
 }
 
-func (ec *ExecutionContext) Clone() *ExecutionContext {
-	vars := map[string]Expression{}
-	for k, v := range ec.Variables {
-		vars[k] = v
+// Constant reports whether the variable s found by name is a constant. Constants bound by the resolver are
+// checked when resolving.
+func (ec *ExecutionContext) Constant(s string) bool {
+	for ec != nil {
+		if _, ok := ec.Variables[s]; ok {
+			return ec.Constants[s]
+		}
+		ec = ec.Outer
+	}
+	return false
+}
+
+// Lookup returns the variable s, through its binding when it has one
+func (ec *ExecutionContext) Lookup(s string, b *Binding) (Value, bool) {
+	if b == nil {
+		return ec.Get(s)
+	}
+	for i := 0; i < b.Depth; i++ {
+		ec = ec.Outer
 	}
-	return &ExecutionContext{
-		Type:      ec.Type,
-		Outer:     ec.Outer,
-		Variables: vars,
+	if b.Slot < len(ec.Slots) && ec.Slots[b.Slot] != nil {
+		return ec.Slots[b.Slot], true
 	}
+	return nil, false
+}
+
+// Declare creates the variable id in ec
+func (ec *ExecutionContext) Declare(id Identifier, v Value) {
+	if id.Binding == nil {
+		ec.Set(id.Name, v)
+		return
+	}
+	for len(ec.Slots) <= id.Binding.Slot {
+		ec.Slots = append(ec.Slots, nil)
+	}
+	ec.Slots[id.Binding.Slot] = v
+}
+
+// Update assigns v to the existing variable s, it returns false when s is not declared yet
+func (ec *ExecutionContext) Update(s string, b *Binding, v Value) bool {
+	if b == nil {
+		return ec.Assign(s, v)
+	}
+	for i := 0; i < b.Depth; i++ {
+		ec = ec.Outer
+	}
+	if b.Slot < len(ec.Slots) && ec.Slots[b.Slot] != nil {
+		ec.Slots[b.Slot] = v
+		return true
+	}
+	return false
 }
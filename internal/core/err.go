@@ -0,0 +1,36 @@
+package core
+
+import "fmt"
+
+// RuntimeError is an error raised while running a script. Its position is 0,0 when the code raising it does
+// not know it, like a builtin function, until the statement or the call running that code locates it.
+type RuntimeError struct {
+	Message string // without the final period
+	Line    int
+	CharAt  int
+}
+
+// RuntimeErrorf returns the RuntimeError raised at line, charAt with the message formatted from format and a
+func RuntimeErrorf(line int, charAt int, format string, a ...interface{}) error {
+	return RuntimeError{
+		Message: fmt.Sprintf(format, a...),
+		Line:    line,
+		CharAt:  charAt,
+	}
+}
+
+func (err RuntimeError) Error() string {
+	if err.Line == 0 && err.CharAt == 0 {
+		return fmt.Sprintf("Runtime error: %s.", err.Message)
+	}
+	return fmt.Sprintf("Runtime error: %s. [%d,%d]", err.Message, err.Line, err.CharAt)
+}
+
+// Locate gives the position line, charAt to err when it is a runtime error without a position
+func Locate(err error, line int, charAt int) error {
+	if rerr, ok := err.(RuntimeError); ok && rerr.Line == 0 && rerr.CharAt == 0 {
+		rerr.Line, rerr.CharAt = line, charAt
+		return rerr
+	}
+	return err
+}
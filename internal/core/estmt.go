@@ -6,16 +6,7 @@ type ExpressionStatement struct {
 	CharAt int
 }
 
-func (stmt ExpressionStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt ExpressionStatement) Execute(ec *ExecutionContext) (Value, error) {
 	_, err := stmt.Expression.Evaluate(ec)
 	return nil, err
 }
-
-func (stmt ExpressionStatement) Clone() Statement {
-	exp := stmt.Expression.Clone()
-	return ExpressionStatement{
-		Expression: exp,
-		Line:       stmt.Line,
-		CharAt:     stmt.CharAt,
-	}
-}
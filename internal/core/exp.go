@@ -1,13 +1,10 @@
 package core
 
+// Expression is a node of the syntax tree, evaluating it never modifies the tree
 type Expression interface {
-	Evaluate(*ExecutionContext) (Expression, error)
-	IsTruthy() bool
+	Evaluate(*ExecutionContext) (Value, error)
 	GetLine() int
 	GetCharAt() int
-	SetLine(int)
-	SetCharAt(int)
 	GetType() string
 	ToString() string
-	Clone() Expression
 }
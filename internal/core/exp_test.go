@@ -1,7 +1,6 @@
 package core
 
 import (
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,7 +11,7 @@ func TestEvaluate_LiteralExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
@@ -22,7 +21,7 @@ func TestEvaluate_LiteralExpression(t *testing.T) {
 				Type:  LiteralTypeNumber,
 				Value: "1",
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "1",
 			},
@@ -43,18 +42,16 @@ func TestEvaluate_VariableExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate variable expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
-						Type:   LiteralTypeNumber,
-						Value:  "3",
-						Line:   1,
-						CharAt: 1,
+				Variables: map[string]Value{
+					"a": &LiteralValue{
+						Type:  LiteralTypeNumber,
+						Value: "3",
 					},
 				},
 			},
@@ -63,11 +60,9 @@ func TestEvaluate_VariableExpression(t *testing.T) {
 				Line:   2,
 				CharAt: 1,
 			},
-			want: &LiteralExpression{
-				Type:   LiteralTypeNumber,
-				Value:  "3",
-				Line:   2,
-				CharAt: 1,
+			want: &LiteralValue{
+				Type:  LiteralTypeNumber,
+				Value: "3",
 			},
 			err: nil,
 		},
@@ -80,7 +75,7 @@ func TestEvaluate_VariableExpression(t *testing.T) {
 				CharAt: 1,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: a is not defined. [1,1]"),
+			err:  RuntimeError{Message: "a is not defined", Line: 1, CharAt: 1},
 		},
 	}
 	for _, tt := range cases {
@@ -97,13 +92,13 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate binary expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -118,7 +113,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "+",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "3",
 			},
@@ -127,8 +122,8 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #2",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
+				Variables: map[string]Value{
+					"a": &LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "3",
 					},
@@ -155,7 +150,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "+",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "6",
 			},
@@ -164,7 +159,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #3",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -179,7 +174,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "+",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeString,
 				Value: "12",
 			},
@@ -188,7 +183,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #4",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -203,7 +198,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "+",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeString,
 				Value: "abcxyz",
 			},
@@ -212,7 +207,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #5",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -230,12 +225,12 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 				},
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: cannot use '-' operator with string. [1,2]"),
+			err:  RuntimeError{Message: "cannot use '-' operator with string", Line: 1, CharAt: 2},
 		},
 		{
 			name: "evaluate binary expression #6",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -257,17 +252,15 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 				},
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: cannot use '+' operator with array. [1,2]"),
+			err:  RuntimeError{Message: "cannot use '+' operator with array", Line: 1, CharAt: 2},
 		},
 		{
 			name: "evaluate binary expression #7",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
-						Type:   LiteralTypeNumber,
-						Value:  "0",
-						Line:   1,
-						CharAt: 1,
+				Variables: map[string]Value{
+					"a": &LiteralValue{
+						Type:  LiteralTypeNumber,
+						Value: "0",
 					},
 				},
 			},
@@ -286,12 +279,12 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 				},
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: cannot divide by zero. [1,3]"),
+			err:  RuntimeError{Message: "cannot divide by zero", Line: 1, CharAt: 3},
 		},
 		{
 			name: "evaluate binary expression #8",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -306,7 +299,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "+",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "3.2",
 			},
@@ -315,7 +308,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #9",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -330,7 +323,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "-",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "0.8",
 			},
@@ -339,7 +332,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #10",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -354,7 +347,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "%",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "1",
 			},
@@ -363,12 +356,12 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #11",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
 					Type:  LiteralTypeNumber,
-					Value: "3.3",
+					Value: "3.5",
 				},
 				Right: &LiteralExpression{
 					Type:  LiteralTypeNumber,
@@ -380,13 +373,16 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					CharAt: 4,
 				},
 			},
-			want: nil,
-			err:  fmt.Errorf("Runtime error: cannot use '%s' operator with float. [1,4]", "%"),
+			want: &LiteralValue{
+				Type:  LiteralTypeNumber,
+				Value: "1.5",
+			},
+			err: nil,
 		},
 		{
 			name: "evaluate binary expression #12",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -401,7 +397,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: ">",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -410,7 +406,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #13",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -425,7 +421,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: ">",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -434,7 +430,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #14",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -449,7 +445,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: ">",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -458,7 +454,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #15",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -473,7 +469,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: ">=",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -482,7 +478,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #15",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -497,7 +493,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: ">=",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -506,7 +502,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #16",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -521,7 +517,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "==",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -530,7 +526,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #17",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -545,7 +541,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "==",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -554,7 +550,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #18",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -569,7 +565,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "!=",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -578,7 +574,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #19",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -595,13 +591,12 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 						},
 					},
 					Body: BlockStatement{},
-					EC:   &ExecutionContext{},
 				},
 				Operator: Operator{
 					Symbol: "==",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -610,7 +605,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #20",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -627,13 +622,12 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 						},
 					},
 					Body: BlockStatement{},
-					EC:   &ExecutionContext{},
 				},
 				Operator: Operator{
 					Symbol: "&&",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -642,7 +636,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #21",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &ObjectExpression{
@@ -655,7 +649,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "&&",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -664,7 +658,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #22",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &ObjectExpression{
@@ -682,7 +676,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "||",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -691,7 +685,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #23",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -705,7 +699,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "&&",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -714,7 +708,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 		{
 			name: "evaluate binary expression #24",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &BinaryExpression{
 				Left: &LiteralExpression{
@@ -728,7 +722,7 @@ func TestEvaluate_BinaryExpression(t *testing.T) {
 					Symbol: "&&",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#f",
 			},
@@ -749,19 +743,20 @@ func TestEvaluate_CompareReference(t *testing.T) {
 		name string
 		ec   func() *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "compare reference #1",
 			ec: func() *ExecutionContext {
-				obj := &ObjectExpression{
-					Properties: []*ObjectProperty{
+				obj := &ObjectValue{
+					Properties: []*PropertyValue{
 						{
-							KeyIdentifier: Identifier{
-								Name: "a",
+							Key: &LiteralValue{
+								Type:  LiteralTypeString,
+								Value: "a",
 							},
-							Value: &LiteralExpression{
+							Value: &LiteralValue{
 								Type:  LiteralTypeString,
 								Value: "xxx",
 							},
@@ -769,7 +764,7 @@ func TestEvaluate_CompareReference(t *testing.T) {
 					},
 				}
 				return &ExecutionContext{
-					Variables: map[string]Expression{
+					Variables: map[string]Value{
 						"a": obj,
 						"b": obj,
 					},
@@ -786,7 +781,130 @@ func TestEvaluate_CompareReference(t *testing.T) {
 					Symbol: "==",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
+				Type:  LiteralTypeBoolean,
+				Value: "#t",
+			},
+			err: nil,
+		},
+		{
+			name: "compare reference #2",
+			ec: func() *ExecutionContext {
+				return &ExecutionContext{
+					Variables: map[string]Value{
+						"a": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1"}}},
+								},
+							},
+						},
+						"b": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1.0"}}},
+								},
+							},
+						},
+					},
+				}
+			},
+			exp: &BinaryExpression{
+				Left: &VariableExpression{
+					Name: "a",
+				},
+				Right: &VariableExpression{
+					Name: "b",
+				},
+				Operator: Operator{
+					Symbol: "==",
+				},
+			},
+			want: &LiteralValue{
+				Type:  LiteralTypeBoolean,
+				Value: "#t",
+			},
+			err: nil,
+		},
+		{
+			name: "compare reference #3",
+			ec: func() *ExecutionContext {
+				return &ExecutionContext{
+					Variables: map[string]Value{
+						"a": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1"}}},
+								},
+							},
+						},
+						"b": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1.0"}}},
+								},
+							},
+						},
+					},
+				}
+			},
+			exp: &BinaryExpression{
+				Left: &VariableExpression{
+					Name: "a",
+				},
+				Right: &VariableExpression{
+					Name: "b",
+				},
+				Operator: Operator{
+					Symbol: "===",
+				},
+			},
+			want: &LiteralValue{
+				Type:  LiteralTypeBoolean,
+				Value: "#f",
+			},
+			err: nil,
+		},
+		{
+			name: "compare reference #4",
+			ec: func() *ExecutionContext {
+				return &ExecutionContext{
+					Variables: map[string]Value{
+						"a": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1"}}},
+								},
+							},
+						},
+						"b": &ObjectValue{
+							Properties: []*PropertyValue{
+								{
+									Key:   &LiteralValue{Type: LiteralTypeString, Value: "a"},
+									Value: &ArrayValue{Elements: []Value{&LiteralValue{Type: LiteralTypeNumber, Value: "1.0"}}},
+								},
+							},
+						},
+					},
+				}
+			},
+			exp: &BinaryExpression{
+				Left: &VariableExpression{
+					Name: "a",
+				},
+				Right: &VariableExpression{
+					Name: "b",
+				},
+				Operator: Operator{
+					Symbol: "!==",
+				},
+			},
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -807,13 +925,13 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate array expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &ArrayExpression{
 				Elements: []Expression{
@@ -823,9 +941,9 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &ArrayExpression{
-				Elements: []Expression{
-					&LiteralExpression{
+			want: &ArrayValue{
+				Elements: []Value{
+					&LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "1",
 					},
@@ -836,7 +954,7 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 		{
 			name: "evaluate array expression #2",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &ArrayExpression{
 				Elements: []Expression{
@@ -855,9 +973,9 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &ArrayExpression{
-				Elements: []Expression{
-					&LiteralExpression{
+			want: &ArrayValue{
+				Elements: []Value{
+					&LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "2",
 					},
@@ -868,8 +986,8 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 		{
 			name: "evaluate array expression #3",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
+				Variables: map[string]Value{
+					"a": &LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "3",
 					},
@@ -903,13 +1021,13 @@ func TestEvaluate_ArrayExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &ArrayExpression{
-				Elements: []Expression{
-					&LiteralExpression{
+			want: &ArrayValue{
+				Elements: []Value{
+					&LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "6",
 					},
-					&LiteralExpression{
+					&LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "3",
 					},
@@ -932,13 +1050,13 @@ func TestEvaluate_ObjectExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate object expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{},
+				Variables: map[string]Value{},
 			},
 			exp: &ObjectExpression{
 				Properties: []*ObjectProperty{
@@ -953,13 +1071,11 @@ func TestEvaluate_ObjectExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &ObjectExpression{
-				Properties: []*ObjectProperty{
+			want: &ObjectValue{
+				Properties: []*PropertyValue{
 					{
-						KeyIdentifier: Identifier{
-							Name: "a",
-						},
-						Value: &LiteralExpression{
+						Key: &LiteralValue{Type: LiteralTypeString, Value: "a"},
+						Value: &LiteralValue{
 							Type:  LiteralTypeString,
 							Value: "xxx",
 						},
@@ -971,8 +1087,8 @@ func TestEvaluate_ObjectExpression(t *testing.T) {
 		{
 			name: "evaluate object expression #2",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
+				Variables: map[string]Value{
+					"a": &LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "3",
 					},
@@ -1010,18 +1126,17 @@ func TestEvaluate_ObjectExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &ObjectExpression{
-				Properties: []*ObjectProperty{
+			want: &ObjectValue{
+				Properties: []*PropertyValue{
 					{
-						KeyExpression: &LiteralExpression{
+						Key: &LiteralValue{
 							Type:  LiteralTypeString,
 							Value: "ab",
 						},
-						Value: &LiteralExpression{
+						Value: &LiteralValue{
 							Type:  LiteralTypeNumber,
 							Value: "5",
 						},
-						Computed: true,
 					},
 				},
 			},
@@ -1042,25 +1157,24 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate member access expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ObjectExpression{
-						Properties: []*ObjectProperty{
+				Variables: map[string]Value{
+					"a": &ObjectValue{
+						Properties: []*PropertyValue{
 							{
-								KeyExpression: &LiteralExpression{
+								Key: &LiteralValue{
 									Type:  LiteralTypeString,
 									Value: "b",
 								},
-								Value: &LiteralExpression{
+								Value: &LiteralValue{
 									Type:  LiteralTypeBoolean,
 									Value: "#t",
 								},
-								Computed: true,
 							},
 						},
 					},
@@ -1074,7 +1188,7 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 					Name: "b",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -1083,19 +1197,18 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 		{
 			name: "evaluate member access expression #2",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ObjectExpression{
-						Properties: []*ObjectProperty{
+				Variables: map[string]Value{
+					"a": &ObjectValue{
+						Properties: []*PropertyValue{
 							{
-								KeyExpression: &LiteralExpression{
+								Key: &LiteralValue{
 									Type:  LiteralTypeString,
 									Value: "b",
 								},
-								Value: &LiteralExpression{
+								Value: &LiteralValue{
 									Type:  LiteralTypeBoolean,
 									Value: "#t",
 								},
-								Computed: true,
 							},
 						},
 					},
@@ -1111,7 +1224,7 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				},
 				Compute: true,
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -1120,14 +1233,12 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 		{
 			name: "evaluate member access expression #3",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ObjectExpression{
-						Properties: []*ObjectProperty{
+				Variables: map[string]Value{
+					"a": &ObjectValue{
+						Properties: []*PropertyValue{
 							{
-								KeyIdentifier: Identifier{
-									Name: "b",
-								},
-								Value: &LiteralExpression{
+								Key: &LiteralValue{Type: LiteralTypeString, Value: "b"},
+								Value: &LiteralValue{
 									Type:  LiteralTypeBoolean,
 									Value: "#t",
 								},
@@ -1146,7 +1257,7 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				},
 				Compute: true,
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -1155,8 +1266,8 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 		{
 			name: "evaluate member access expression #4",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ObjectExpression{},
+				Variables: map[string]Value{
+					"a": &ObjectValue{},
 				},
 			},
 			exp: &MemberAccessExpression{
@@ -1167,7 +1278,7 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 					Name: "c",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type: LiteralTypeUndefined,
 			},
 			err: nil,
@@ -1175,8 +1286,8 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 		{
 			name: "evaluate member access expression #5",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ObjectExpression{},
+				Variables: map[string]Value{
+					"a": &ObjectValue{},
 				},
 			},
 			exp: &MemberAccessExpression{
@@ -1192,15 +1303,15 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				Compute: true,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: property key of type boolean is not supported. [1,4]"),
+			err:  RuntimeError{Message: "property key of type boolean is not supported", Line: 1, CharAt: 4},
 		},
 		{
 			name: "evaluate member access expression #6",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ArrayExpression{
-						Elements: []Expression{
-							&LiteralExpression{
+				Variables: map[string]Value{
+					"a": &ArrayValue{
+						Elements: []Value{
+							&LiteralValue{
 								Type:  LiteralTypeNumber,
 								Value: "1",
 							},
@@ -1220,21 +1331,19 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				Line:    1,
 				CharAt:  1,
 			},
-			want: &LiteralExpression{
-				Type:   LiteralTypeNumber,
-				Value:  "1",
-				Line:   1,
-				CharAt: 1,
+			want: &LiteralValue{
+				Type:  LiteralTypeNumber,
+				Value: "1",
 			},
 			err: nil,
 		},
 		{
 			name: "evaluate member access expression #7",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ArrayExpression{
-						Elements: []Expression{
-							&LiteralExpression{
+				Variables: map[string]Value{
+					"a": &ArrayValue{
+						Elements: []Value{
+							&LiteralValue{
 								Type:  LiteralTypeNumber,
 								Value: "1",
 							},
@@ -1252,20 +1361,18 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				Line:   1,
 				CharAt: 1,
 			},
-			want: &LiteralExpression{
-				Type:   LiteralTypeUndefined,
-				Line:   1,
-				CharAt: 1,
+			want: &LiteralValue{
+				Type: LiteralTypeUndefined,
 			},
 			err: nil,
 		},
 		{
 			name: "evaluate member access expression #8",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ArrayExpression{
-						Elements: []Expression{
-							&LiteralExpression{
+				Variables: map[string]Value{
+					"a": &ArrayValue{
+						Elements: []Value{
+							&LiteralValue{
 								Type:  LiteralTypeNumber,
 								Value: "1",
 							},
@@ -1288,15 +1395,15 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				CharAt:  1,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: index is out of range. [1.3]"),
+			err:  RuntimeError{Message: "index is out of range", Line: 1, CharAt: 3},
 		},
 		{
 			name: "evaluate member access expression #9",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ArrayExpression{
-						Elements: []Expression{
-							&LiteralExpression{
+				Variables: map[string]Value{
+					"a": &ArrayValue{
+						Elements: []Value{
+							&LiteralValue{
 								Type:  LiteralTypeNumber,
 								Value: "1",
 							},
@@ -1319,15 +1426,15 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				CharAt:  1,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: index must be number. [1,3]"),
+			err:  RuntimeError{Message: "index must be number", Line: 1, CharAt: 3},
 		},
 		{
 			name: "evaluate member access expression #10",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &ArrayExpression{
-						Elements: []Expression{
-							&LiteralExpression{
+				Variables: map[string]Value{
+					"a": &ArrayValue{
+						Elements: []Value{
+							&LiteralValue{
 								Type:  LiteralTypeNumber,
 								Value: "1",
 							},
@@ -1351,17 +1458,15 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				CharAt:  1,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: can't access property of type number. [1,1]"),
+			err:  RuntimeError{Message: "can't access property of type number", Line: 1, CharAt: 1},
 		},
 		{
 			name: "evaluate member access expression #11",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
-						Type:   LiteralTypeString,
-						Value:  "abc",
-						Line:   1,
-						CharAt: 1,
+				Variables: map[string]Value{
+					"a": &LiteralValue{
+						Type:  LiteralTypeString,
+						Value: "abc",
 					},
 				},
 			},
@@ -1377,11 +1482,9 @@ func TestEvaluate_MemberAccessExpression(t *testing.T) {
 				},
 				Compute: true,
 			},
-			want: &LiteralExpression{
-				Type:   LiteralTypeString,
-				Value:  "b",
-				Line:   1,
-				CharAt: 3,
+			want: &LiteralValue{
+				Type:  LiteralTypeString,
+				Value: "b",
 			},
 			err: nil,
 		},
@@ -1400,7 +1503,7 @@ func TestEvaluate_FunctionExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
@@ -1416,9 +1519,8 @@ func TestEvaluate_FunctionExpression(t *testing.T) {
 					},
 				},
 				Body: BlockStatement{},
-				EC:   nil,
 			},
-			want: &FunctionExpression{
+			want: &FunctionValue{
 				Params: []Identifier{
 					{
 						Name: "a",
@@ -1428,8 +1530,16 @@ func TestEvaluate_FunctionExpression(t *testing.T) {
 					},
 				},
 				Body: BlockStatement{},
-				EC: &ExecutionContext{
-					Variables: map[string]Expression{},
+				Node: &FunctionExpression{
+					Params: []Identifier{
+						{
+							Name: "a",
+						},
+						{
+							Name: "b",
+						},
+					},
+					Body: BlockStatement{},
 				},
 			},
 			err: nil,
@@ -1449,14 +1559,14 @@ func TestEvaluate_CallExpression(t *testing.T) {
 		name string
 		ec   *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate call expression #1",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &FunctionExpression{
+				Variables: map[string]Value{
+					"a": &FunctionValue{
 						Params: []Identifier{
 							{
 								Name: "a",
@@ -1476,7 +1586,7 @@ func TestEvaluate_CallExpression(t *testing.T) {
 				},
 				Arguments: []Expression{},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type: LiteralTypeUndefined,
 			},
 			err: nil,
@@ -1484,8 +1594,8 @@ func TestEvaluate_CallExpression(t *testing.T) {
 		{
 			name: "evaluate call expression #2",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &FunctionExpression{
+				Variables: map[string]Value{
+					"a": &FunctionValue{
 						Params: []Identifier{
 							{
 								Name: "a",
@@ -1529,7 +1639,7 @@ func TestEvaluate_CallExpression(t *testing.T) {
 					},
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeNumber,
 				Value: "3",
 			},
@@ -1538,8 +1648,8 @@ func TestEvaluate_CallExpression(t *testing.T) {
 		{
 			name: "evaluate call expression #3",
 			ec: &ExecutionContext{
-				Variables: map[string]Expression{
-					"a": &LiteralExpression{
+				Variables: map[string]Value{
+					"a": &LiteralValue{
 						Type:  LiteralTypeNumber,
 						Value: "1",
 					},
@@ -1554,7 +1664,7 @@ func TestEvaluate_CallExpression(t *testing.T) {
 				CharAt:    1,
 			},
 			want: nil,
-			err:  fmt.Errorf("Runtime error: a is not a function. [1,1]"),
+			err:  RuntimeError{Message: "a is not a function", Line: 1, CharAt: 1},
 		},
 	}
 	for _, tt := range cases {
@@ -1566,24 +1676,80 @@ func TestEvaluate_CallExpression(t *testing.T) {
 	}
 }
 
+func TestExecute_AssignConstant(t *testing.T) {
+	one := &LiteralExpression{Type: LiteralTypeNumber, Value: "1", Line: 1, CharAt: 5}
+	cases := []struct {
+		name string
+		stmt Statement
+		err  error
+	}{
+		{
+			name: "assign constant variable",
+			stmt: AssignmentStatement{
+				Left:   &VariableExpression{Name: "a", Line: 1, CharAt: 1},
+				Right:  one,
+				Line:   1,
+				CharAt: 1,
+			},
+			err: RuntimeError{Message: "cannot assign to constant a", Line: 1, CharAt: 1},
+		},
+		{
+			name: "assign constant variable of a pattern",
+			stmt: AssignmentStatement{
+				Left: &Pattern{
+					Elements: []Identifier{{Name: "b", Line: 1, CharAt: 2}, {Name: "a", Line: 1, CharAt: 4}},
+					Line:     1,
+					CharAt:   1,
+				},
+				Right:  &ArrayExpression{Elements: []Expression{one, one}, Line: 1, CharAt: 9},
+				Line:   1,
+				CharAt: 1,
+			},
+			err: RuntimeError{Message: "cannot assign to constant a", Line: 1, CharAt: 4},
+		},
+		{
+			name: "assign variable",
+			stmt: AssignmentStatement{
+				Left:   &VariableExpression{Name: "b", Line: 1, CharAt: 1},
+				Right:  one,
+				Line:   1,
+				CharAt: 1,
+			},
+			err: nil,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := &ExecutionContext{
+				Variables: map[string]Value{"a": NewInt(0), "b": NewInt(0)},
+				Constants: map[string]bool{"a": true},
+			}
+			_, err := tt.stmt.Execute(ec)
+			require.Equal(t, tt.err, err)
+			require.Equal(t, NewInt(0), ec.Variables["a"])
+		})
+	}
+}
+
 func TestEvaluate_TMP(t *testing.T) {
 	cases := []struct {
 		name string
 		ec   func() *ExecutionContext
 		exp  Expression
-		want Expression
+		want Value
 		err  error
 	}{
 		{
 			name: "evaluate binary expression #20",
 			ec: func() *ExecutionContext {
-				obj := &ObjectExpression{
-					Properties: []*ObjectProperty{
+				obj := &ObjectValue{
+					Properties: []*PropertyValue{
 						{
-							KeyIdentifier: Identifier{
-								Name: "a",
+							Key: &LiteralValue{
+								Type:  LiteralTypeString,
+								Value: "a",
 							},
-							Value: &LiteralExpression{
+							Value: &LiteralValue{
 								Type:  LiteralTypeString,
 								Value: "xxx",
 							},
@@ -1591,7 +1757,7 @@ func TestEvaluate_TMP(t *testing.T) {
 					},
 				}
 				return &ExecutionContext{
-					Variables: map[string]Expression{
+					Variables: map[string]Value{
 						"a": obj,
 						"b": obj,
 					},
@@ -1608,7 +1774,7 @@ func TestEvaluate_TMP(t *testing.T) {
 					Symbol: "==",
 				},
 			},
-			want: &LiteralExpression{
+			want: &LiteralValue{
 				Type:  LiteralTypeBoolean,
 				Value: "#t",
 			},
@@ -0,0 +1,41 @@
+package core
+
+type ExportStatement struct {
+	Declaration Statement
+	Line        int
+	CharAt      int
+}
+
+func (stmt ExportStatement) Execute(ec *ExecutionContext) (Value, error) {
+	if ec.Type != TypeGlobalEC {
+		return nil, RuntimeErrorf(stmt.Line, stmt.CharAt, "export is only allowed at the top level of a module")
+	}
+	if _, err := stmt.Declaration.Execute(ec); err != nil {
+		return nil, err
+	}
+	stmt.Export(ec)
+	return nil, nil
+}
+
+// Export adds the names declared by the declaration of stmt, once it is executed, to the exports of ec
+func (stmt ExportStatement) Export(ec *ExecutionContext) {
+	switch d := stmt.Declaration.(type) {
+	case VariableDeclaration:
+		for _, vd := range d.Declarations {
+			ec.export(vd.ID)
+		}
+	case FunctionDeclaration:
+		ec.Exports = append(ec.Exports, d.ID.Name)
+	}
+}
+
+// export exports the variables declared by id, a pattern exports each of its identifiers
+func (ec *ExecutionContext) export(id Identifier) {
+	if id.Pattern != nil {
+		for _, e := range id.Pattern.Elements {
+			ec.export(e)
+		}
+		return
+	}
+	ec.Exports = append(ec.Exports, id.Name)
+}
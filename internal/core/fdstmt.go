@@ -4,31 +4,17 @@ type FunctionDeclaration struct {
 	ID     Identifier
 	Params []Identifier
 	Body   BlockStatement
+	Doc    string // /// doc comment above the declaration
 	Line   int
 	CharAt int
 }
 
-func (stmt FunctionDeclaration) Execute(ec *ExecutionContext) (Expression, error) {
-	fexp := &FunctionExpression{
+func (stmt FunctionDeclaration) Execute(ec *ExecutionContext) (Value, error) {
+	ec.Declare(stmt.ID, &FunctionValue{
 		Params: stmt.Params,
 		Body:   stmt.Body,
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
-		EC: &ExecutionContext{
-			Outer:     ec,
-			Variables: map[string]Expression{},
-		},
-	}
-	ec.Set(stmt.ID.Name, fexp)
+		EC:     ec,
+		Node:   &stmt,
+	})
 	return nil, nil
 }
-
-func (stmt FunctionDeclaration) Clone() Statement {
-	return FunctionDeclaration{
-		ID:     stmt.ID,
-		Params: stmt.Params,
-		Body:   stmt.Body,
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
-	}
-}
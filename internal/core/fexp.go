@@ -3,26 +3,19 @@ package core
 import "fmt"
 
 type FunctionExpression struct {
-	Params         []Identifier
-	Body           BlockStatement
-	NativeFunction func(*ExecutionContext) (Expression, error)
-	EC             *ExecutionContext
-	Line           int
-	CharAt         int
+	Params []Identifier
+	Body   BlockStatement
+	Line   int
+	CharAt int
 }
 
-func (e *FunctionExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	if e.EC == nil {
-		e.EC = &ExecutionContext{
-			Outer:     ec,
-			Variables: map[string]Expression{},
-		}
-	}
-	return e, nil
-}
-
-func (e *FunctionExpression) IsTruthy() bool {
-	return true
+func (e *FunctionExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	return &FunctionValue{
+		Params: e.Params,
+		Body:   e.Body,
+		EC:     ec,
+		Node:   e,
+	}, nil
 }
 
 func (e *FunctionExpression) GetCharAt() int {
@@ -33,14 +26,6 @@ func (e *FunctionExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *FunctionExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *FunctionExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *FunctionExpression) GetType() string {
 	return "function"
 }
@@ -48,7 +33,7 @@ func (e *FunctionExpression) GetType() string {
 func (e *FunctionExpression) ToString() string {
 	params := ""
 	for _, p := range e.Params {
-		params = params + p.Name + ", "
+		params = params + p.ToString() + ", "
 	}
 	if len(params) > 1 {
 		params = params[:len(params)-2]
@@ -56,14 +41,3 @@ func (e *FunctionExpression) ToString() string {
 	}
 	return "func()"
 }
-
-func (e *FunctionExpression) Clone() Expression {
-	return &FunctionExpression{
-		EC:             nil,
-		Params:         e.Params,
-		NativeFunction: e.NativeFunction,
-		Body:           e.Body,
-		Line:           e.Line,
-		CharAt:         e.CharAt,
-	}
-}
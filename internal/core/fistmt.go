@@ -0,0 +1,90 @@
+package core
+
+import "strconv"
+
+type ForInStatement struct {
+	Key    Identifier
+	Value  *Identifier
+	Right  Expression
+	Body   BlockStatement
+	Line   int
+	CharAt int
+}
+
+func (stmt ForInStatement) Execute(ec *ExecutionContext) (Value, error) {
+	right, err := stmt.Right.Evaluate(ec)
+	if err != nil {
+		return nil, err
+	}
+	keys, values, err := stmt.Entries(right)
+	if err != nil {
+		return nil, err
+	}
+l:
+	for i := range keys {
+		bec := &ExecutionContext{
+			Type:      TypeBlockEC,
+			Outer:     ec,
+			Variables: map[string]Value{},
+		}
+		if err := bec.Bind(stmt.Key, keys[i]); err != nil {
+			return nil, err
+		}
+		if stmt.Value != nil {
+			if err := bec.Bind(*stmt.Value, values[i]); err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range stmt.Body.Statements {
+			rexp, err := s.Execute(bec)
+			if _, ok := err.(BreakError); ok {
+				break l
+			}
+			if _, ok := err.(ContinueError); ok {
+				break
+			}
+			if rexp != nil || err != nil {
+				return rexp, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Entries lists the keys and values visited when iterating over the evaluated right side
+func (stmt ForInStatement) Entries(right Value) ([]Value, []Value, error) {
+	keys := []Value{}
+	values := []Value{}
+	switch v := right.(type) {
+	case *ArrayValue:
+		for i, elem := range v.Elements {
+			keys = append(keys, &LiteralValue{
+				Type:  LiteralTypeNumber,
+				Value: strconv.Itoa(i),
+			})
+			values = append(values, elem)
+		}
+	case *ObjectValue:
+		for _, p := range v.Properties {
+			keys = append(keys, p.Key)
+			values = append(values, p.Value)
+		}
+	case *LiteralValue:
+		if v.Type != LiteralTypeString {
+			return nil, nil, RuntimeErrorf(stmt.Right.GetLine(), stmt.Right.GetCharAt(), "cannot iterate over %s", right.GetType())
+		}
+		for i, r := range []rune(v.Value) {
+			keys = append(keys, &LiteralValue{
+				Type:  LiteralTypeNumber,
+				Value: strconv.Itoa(i),
+			})
+			values = append(values, &LiteralValue{
+				Type:  LiteralTypeString,
+				Value: string(r),
+			})
+		}
+	default:
+		return nil, nil, RuntimeErrorf(stmt.Right.GetLine(), stmt.Right.GetCharAt(), "cannot iterate over %s", right.GetType())
+	}
+	return keys, values, nil
+}
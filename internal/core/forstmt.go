@@ -9,11 +9,11 @@ type ForStatement struct {
 	CharAt int
 }
 
-func (stmt ForStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt ForStatement) Execute(ec *ExecutionContext) (Value, error) {
 	bec := &ExecutionContext{
 		Type:      TypeBlockEC,
 		Outer:     ec,
-		Variables: map[string]Expression{},
+		Variables: map[string]Value{},
 	}
 	if stmt.Init != nil {
 		_, err := stmt.Init.Execute(bec)
@@ -25,7 +25,7 @@ func (stmt ForStatement) Execute(ec *ExecutionContext) (Expression, error) {
 	l1:
 		for {
 			for _, s := range stmt.Body.Statements {
-				rexp, err := s.Execute(ec)
+				rexp, err := s.Execute(bec)
 				if _, ok := err.(BreakError); ok {
 					break l1
 				}
@@ -44,12 +44,12 @@ func (stmt ForStatement) Execute(ec *ExecutionContext) (Expression, error) {
 			}
 		}
 	} else {
-		t, err := stmt.Test.Evaluate(bec)
+		t, err := truthy(bec, stmt.Test)
 		if err != nil {
 			return nil, err
 		}
 	l2:
-		for t.IsTruthy() {
+		for t {
 			for _, s := range stmt.Body.Statements {
 				rexp, err := s.Execute(bec)
 				if _, ok := err.(BreakError); ok {
@@ -68,7 +68,7 @@ func (stmt ForStatement) Execute(ec *ExecutionContext) (Expression, error) {
 					return nil, err
 				}
 			}
-			t, err = stmt.Test.Evaluate(bec)
+			t, err = truthy(bec, stmt.Test)
 			if err != nil {
 				return nil, err
 			}
@@ -76,28 +76,3 @@ func (stmt ForStatement) Execute(ec *ExecutionContext) (Expression, error) {
 	}
 	return nil, nil
 }
-
-func (stmt ForStatement) Clone() Statement {
-	body := stmt.Body.Clone().(BlockStatement)
-	var update *AssignmentStatement
-	if stmt.Update != nil {
-		u := stmt.Update.Clone().(AssignmentStatement)
-		update = &u
-	}
-	var init Statement
-	if stmt.Init != nil {
-		init = stmt.Init.Clone()
-	}
-	var test Expression
-	if stmt.Test != nil {
-		test = stmt.Test.Clone()
-	}
-	return ForStatement{
-		Init:   init,
-		Test:   test,
-		Update: update,
-		Body:   body,
-		Line:   stmt.Line,
-		CharAt: stmt.CharAt,
-	}
-}
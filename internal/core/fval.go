@@ -0,0 +1,102 @@
+package core
+
+import "fmt"
+
+// FunctionValue is a function created by a declaration or an expression. It keeps a reference to the
+// execution context where it was created, never a copy: closures share the variables they capture with
+// that scope and with every other closure created in it.
+type FunctionValue struct {
+	Params         []Identifier
+	Body           BlockStatement
+	NativeFunction func(*ExecutionContext) (Value, error)
+	EC             *ExecutionContext // where the function was created, nil for native functions
+	Node           interface{}       // the *FunctionDeclaration or *FunctionExpression creating the function
+}
+
+// CallContext creates the execution context of a call to v with the evaluated arguments. A missing argument,
+// past the end of args or nil, is the default value of its parameter or undefined. Defaults are evaluated in
+// order once the given arguments are declared, so they can use them and the parameters before.
+func (v *FunctionValue) CallContext(args []Value) (*ExecutionContext, error) {
+	fEC := &ExecutionContext{
+		Type:      TypeFunctionEC,
+		Outer:     v.EC,
+		Variables: map[string]Value{},
+	}
+	for i, p := range v.Params {
+		if p.Rest {
+			rest := []Value{}
+			if i < len(args) {
+				rest = append(rest, args[i:]...)
+			}
+			fEC.Declare(p, &ArrayValue{Elements: rest})
+		} else if i < len(args) && args[i] != nil {
+			if err := fEC.Bind(p, args[i]); err != nil {
+				return nil, err
+			}
+		} else if p.Default == nil {
+			if err := fEC.Bind(p, &LiteralValue{Type: LiteralTypeUndefined}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, p := range v.Params {
+		if p.Default != nil && (i >= len(args) || args[i] == nil) {
+			dv, err := p.Default.Evaluate(fEC)
+			if err != nil {
+				return nil, err
+			}
+			if err := fEC.Bind(p, dv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fEC, nil
+}
+
+// Call runs v with the evaluated arguments and returns its result
+func (v *FunctionValue) Call(args []Value) (Value, error) {
+	fEC, err := v.CallContext(args)
+	if err != nil {
+		return nil, err
+	}
+	if v.NativeFunction != nil {
+		return v.NativeFunction(fEC)
+	}
+	rv, err := v.Body.Execute(fEC)
+	if IsControlFlowError(err) {
+		return nil, Escaped(err)
+	}
+	if rv != nil || err != nil {
+		return rv, err
+	}
+	return &LiteralValue{Type: LiteralTypeUndefined}, nil
+}
+
+// Escaped returns the error of a call whose body lets the control flow error err escape, break and continue
+// cannot leave a loop of the caller. The call gives it its position.
+func Escaped(err error) error {
+	if _, ok := err.(BreakError); ok {
+		return RuntimeError{Message: "break is not in a loop"}
+	}
+	return RuntimeError{Message: "continue is not in a loop"}
+}
+
+func (v *FunctionValue) IsTruthy() bool {
+	return true
+}
+
+func (v *FunctionValue) GetType() string {
+	return "function"
+}
+
+func (v *FunctionValue) ToString() string {
+	params := ""
+	for _, p := range v.Params {
+		params = params + p.ToString() + ", "
+	}
+	if len(params) > 1 {
+		params = params[:len(params)-2]
+		return fmt.Sprintf("func(%s)", params)
+	}
+	return "func()"
+}
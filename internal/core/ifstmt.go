@@ -9,11 +9,11 @@ type IfStatement struct {
 	CharAt     int
 }
 
-func (stmt IfStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt IfStatement) Execute(ec *ExecutionContext) (Value, error) {
 	bec := &ExecutionContext{
 		Type:      TypeBlockEC,
 		Outer:     ec,
-		Variables: map[string]Expression{},
+		Variables: map[string]Value{},
 	}
 	s := stmt
 	for true {
@@ -26,9 +26,9 @@ func (stmt IfStatement) Execute(ec *ExecutionContext) (Expression, error) {
 		if s.Test == nil {
 			return s.Consequent.Execute(bec)
 		}
-		if t, err := s.Test.Evaluate(bec); err != nil {
+		if t, err := truthy(bec, s.Test); err != nil {
 			return nil, err
-		} else if t.IsTruthy() {
+		} else if t {
 			return s.Consequent.Execute(bec)
 		}
 		if s.Alternate == nil {
@@ -38,28 +38,3 @@ func (stmt IfStatement) Execute(ec *ExecutionContext) (Expression, error) {
 	}
 	return nil, nil
 }
-
-func (stmt IfStatement) Clone() Statement {
-	consequent := stmt.Consequent.Clone().(BlockStatement)
-	var alt *IfStatement
-	if stmt.Alternate != nil {
-		a := stmt.Alternate.Clone().(IfStatement)
-		alt = &a
-	}
-	var init Statement
-	if stmt.Init != nil {
-		init = stmt.Init.Clone()
-	}
-	var test Expression
-	if stmt.Test != nil {
-		test = stmt.Test.Clone()
-	}
-	return IfStatement{
-		Init:       init,
-		Test:       test,
-		Alternate:  alt,
-		Consequent: consequent,
-		Line:       stmt.Line,
-		CharAt:     stmt.CharAt,
-	}
-}
@@ -0,0 +1,29 @@
+package core
+
+// ModuleLoader loads the module at path and returns an object holding its exported bindings
+type ModuleLoader interface {
+	Load(path string) (*ObjectValue, error)
+}
+
+type ImportStatement struct {
+	Path   string
+	Alias  Identifier
+	Line   int
+	CharAt int
+}
+
+func (stmt ImportStatement) Execute(ec *ExecutionContext) (Value, error) {
+	gec := ec
+	for gec.Outer != nil {
+		gec = gec.Outer
+	}
+	if gec.Loader == nil {
+		return nil, RuntimeErrorf(stmt.Line, stmt.CharAt, "cannot import %s, modules are only supported when running a file", stmt.Path)
+	}
+	module, err := gec.Loader.Load(stmt.Path)
+	if err != nil {
+		return nil, Locate(err, stmt.Line, stmt.CharAt)
+	}
+	ec.Declare(stmt.Alias, module)
+	return nil, nil
+}
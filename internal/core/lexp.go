@@ -4,6 +4,8 @@ type PrimitiveType string
 
 const (
 	LiteralTypeNumber    PrimitiveType = "number"
+	LiteralTypeBigInt    PrimitiveType = "bigint"
+	LiteralTypeDecimal   PrimitiveType = "decimal"
 	LiteralTypeString    PrimitiveType = "string"
 	LiteralTypeBoolean   PrimitiveType = "boolean"
 	LiteralTypeNull      PrimitiveType = "null"
@@ -17,24 +19,11 @@ type LiteralExpression struct {
 	CharAt int
 }
 
-func (e *LiteralExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	return e, nil
-}
-
-func (e *LiteralExpression) IsTruthy() bool {
-	switch e.Type {
-	case LiteralTypeNull:
-	case LiteralTypeUndefined:
-		return false
-	case LiteralTypeNumber:
-		return e.Value != "0"
-	case LiteralTypeString:
-		return e.Value != ""
-	case LiteralTypeBoolean:
-		return e.Value == "#t"
-	}
-	// never get here
-	return false
+func (e *LiteralExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	return &LiteralValue{
+		Type:  e.Type,
+		Value: e.Value,
+	}, nil
 }
 
 func (e *LiteralExpression) GetCharAt() int {
@@ -45,14 +34,6 @@ func (e *LiteralExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *LiteralExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *LiteralExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *LiteralExpression) GetType() string {
 	return string(e.Type)
 }
@@ -63,12 +44,3 @@ func (e *LiteralExpression) ToString() string {
 	}
 	return e.Value
 }
-
-func (e *LiteralExpression) Clone() Expression {
-	return &LiteralExpression{
-		Type:   e.Type,
-		Value:  e.Value,
-		Line:   e.Line,
-		CharAt: e.CharAt,
-	}
-}
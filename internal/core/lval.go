@@ -0,0 +1,35 @@
+package core
+
+type LiteralValue struct {
+	Type  PrimitiveType
+	Value string
+}
+
+func (v *LiteralValue) IsTruthy() bool {
+	switch v.Type {
+	case LiteralTypeNull:
+	case LiteralTypeUndefined:
+		return false
+	case LiteralTypeNumber:
+		return v.Float() != 0
+	case LiteralTypeBigInt, LiteralTypeDecimal:
+		return v.Decimal().Unscaled.Sign() != 0
+	case LiteralTypeString:
+		return v.Value != ""
+	case LiteralTypeBoolean:
+		return v.Value == "#t"
+	}
+	// never get here
+	return false
+}
+
+func (v *LiteralValue) GetType() string {
+	return string(v.Type)
+}
+
+func (v *LiteralValue) ToString() string {
+	if v.Type == LiteralTypeUndefined || v.Type == LiteralTypeNull {
+		return string(v.Type)
+	}
+	return v.Value
+}
@@ -1,109 +1,126 @@
 package core
 
-import (
-	"fmt"
-	"strconv"
-)
+import "strconv"
 
 type MemberAccessExpression struct {
 	Object             Expression
 	PropertyExpression Expression
 	PropertyIdentifier Identifier
 	Compute            bool
+	Optional           bool // a?.b, the chain is short-circuited when Object is null or undefined
 	Line               int
 	CharAt             int
 }
 
-func (e *MemberAccessExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	var pexp *LiteralExpression
+func (e *MemberAccessExpression) Evaluate(ec *ExecutionContext) (Value, error) {
 	obj, err := e.Object.Evaluate(ec)
 	if err != nil {
 		return nil, err
 	}
+	if e.Optional && IsNullish(obj) {
+		return nil, errShortCircuit
+	}
+	var prop Value
 	if e.Compute {
-		tmpExp, err := e.PropertyExpression.Evaluate(ec)
+		prop, err = e.PropertyExpression.Evaluate(ec)
 		if err != nil {
 			return nil, err
 		}
-		lexp, ok := tmpExp.(*LiteralExpression)
-		if !ok || (lexp.Type != LiteralTypeString && lexp.Type != LiteralTypeNumber) {
-			return nil, fmt.Errorf("Runtime error: property key of type %s is not supported. [%d,%d]", tmpExp.GetType(), tmpExp.GetLine(), tmpExp.GetCharAt())
-		}
-		pexp = lexp
+	}
+	return e.Access(obj, prop)
+}
+
+// Access reads the property of the evaluated obj, prop is the evaluated property expression when e.Compute
+func (e *MemberAccessExpression) Access(obj Value, prop Value) (Value, error) {
+	key, err := e.propertyKey(prop)
+	if err != nil {
+		return nil, err
 	}
 	switch o := obj.(type) {
-	case (*ObjectExpression):
-		for _, p := range o.Properties {
-			if p.Computed {
-				kexp, ok := p.KeyExpression.(*LiteralExpression)
-				if ok && (e.PropertyIdentifier.Name == kexp.Value || e.Compute && kexp.Type == pexp.Type && kexp.Value == pexp.Value) {
-					p.Value.SetLine(e.Line)
-					p.Value.SetCharAt(e.CharAt)
-					return p.Value, nil
-				}
-			} else if p.KeyIdentifier.Name == e.PropertyIdentifier.Name || (pexp != nil && p.KeyIdentifier.Name == pexp.Value) {
-				p.Value.SetLine(e.Line)
-				p.Value.SetCharAt(e.CharAt)
-				return p.Value, nil
-			}
+	case (*ObjectValue):
+		if v := o.Get(key); v != nil {
+			return v, nil
 		}
-		return &LiteralExpression{
-			Type:   LiteralTypeUndefined,
-			Line:   e.Line,
-			CharAt: e.CharAt,
-		}, nil
-	case (*ArrayExpression):
+		return &LiteralValue{Type: LiteralTypeUndefined}, nil
+	case (*ArrayValue):
 		if !e.Compute {
-			return &LiteralExpression{
-				Type:   LiteralTypeUndefined,
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+			return &LiteralValue{Type: LiteralTypeUndefined}, nil
 		}
-		if pexp.Type != LiteralTypeNumber {
-			return nil, fmt.Errorf("Runtime error: index must be number. [%d,%d]", pexp.Line, pexp.GetCharAt())
-		}
-		if i, err := strconv.Atoi(pexp.Value); err == nil {
-			if i >= len(o.Elements) {
-				return nil, fmt.Errorf("Runtime error: index is out of range. [%d.%d]", pexp.Line, pexp.CharAt)
-			}
-			o.Elements[i].SetLine(e.Line)
-			o.Elements[i].SetCharAt(e.CharAt)
-			return o.Elements[i], nil
+		i, err := e.index(key, len(o.Elements))
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("Runtime error: invalid array index. [%d,%d]", pexp.Line, pexp.CharAt)
-	case (*LiteralExpression):
+		return o.Elements[i], nil
+	case (*LiteralValue):
 		if o.Type != LiteralTypeString {
 			break
 		}
 		if !e.Compute {
-			return &LiteralExpression{
-				Type:   LiteralTypeUndefined,
-				Line:   e.Line,
-				CharAt: e.CharAt,
-			}, nil
+			return &LiteralValue{Type: LiteralTypeUndefined}, nil
 		}
-		if pexp.Type != LiteralTypeNumber {
-			return nil, fmt.Errorf("Runtime error: index must be number. [%d,%d]", pexp.Line, pexp.GetCharAt())
+		runes := []rune(o.Value)
+		i, err := e.index(key, len(runes))
+		if err != nil {
+			return nil, err
+		}
+		return &LiteralValue{
+			Type:  LiteralTypeString,
+			Value: string(runes[i]),
+		}, nil
+	}
+	return nil, RuntimeErrorf(e.Line, e.CharAt, "can't access property of type %s", obj.GetType())
+}
+
+// Assign sets the property of the evaluated obj to value, prop is the evaluated property expression when e.Compute
+func (e *MemberAccessExpression) Assign(obj Value, prop Value, value Value) error {
+	if _, err := e.Access(obj, prop); err != nil {
+		return err
+	}
+	key, _ := e.propertyKey(prop)
+	switch o := obj.(type) {
+	case (*ObjectValue):
+		if o.constant(key) {
+			return RuntimeErrorf(e.Line, e.CharAt, "cannot assign to constant %s", key.ToString())
 		}
-		if i, err := strconv.Atoi(pexp.Value); err == nil {
-			if i >= len(o.Value) {
-				return nil, fmt.Errorf("Runtime error: index is out of range. [%d.%d]", pexp.Line, pexp.CharAt)
-			}
-			return &LiteralExpression{
-				Type:   LiteralTypeString,
-				Value:  string(o.Value[i]),
-				Line:   o.Line,
-				CharAt: o.CharAt + i + 1,
-			}, nil
+		o.Set(key, value)
+	case (*ArrayValue):
+		if !e.Compute {
+			return RuntimeErrorf(e.Line, e.CharAt, "cannot assign property of array")
 		}
-		return nil, fmt.Errorf("Runtime error: invalid index. [%d,%d]", pexp.Line, pexp.CharAt)
+		i, _ := e.index(key, len(o.Elements))
+		o.Elements[i] = value
 	}
-	return nil, fmt.Errorf("Runtime error: can't access property of type %s. [%d,%d]", obj.GetType(), e.Line, e.CharAt)
+	return nil
 }
 
-func (e *MemberAccessExpression) IsTruthy() bool {
-	return true
+// propertyKey returns the key of the accessed property, prop is only used when e.Compute
+func (e *MemberAccessExpression) propertyKey(prop Value) (*LiteralValue, error) {
+	if !e.Compute {
+		return &LiteralValue{
+			Type:  LiteralTypeString,
+			Value: e.PropertyIdentifier.Name,
+		}, nil
+	}
+	key, ok := prop.(*LiteralValue)
+	if !ok || (key.Type != LiteralTypeString && key.Type != LiteralTypeNumber) {
+		return nil, RuntimeErrorf(e.PropertyExpression.GetLine(), e.PropertyExpression.GetCharAt(), "property key of type %s is not supported", prop.GetType())
+	}
+	return key, nil
+}
+
+func (e *MemberAccessExpression) index(key *LiteralValue, length int) (int, error) {
+	line, charAt := e.PropertyExpression.GetLine(), e.PropertyExpression.GetCharAt()
+	if key.Type != LiteralTypeNumber {
+		return 0, RuntimeErrorf(line, charAt, "index must be number")
+	}
+	i, err := strconv.Atoi(key.Value)
+	if err != nil {
+		return 0, RuntimeErrorf(line, charAt, "invalid array index")
+	}
+	if i >= length {
+		return 0, RuntimeErrorf(line, charAt, "index is out of range")
+	}
+	return i, nil
 }
 
 func (e *MemberAccessExpression) GetCharAt() int {
@@ -114,14 +131,6 @@ func (e *MemberAccessExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *MemberAccessExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *MemberAccessExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *MemberAccessExpression) GetType() string {
 	return "member access expression"
 }
@@ -129,18 +138,3 @@ func (e *MemberAccessExpression) GetType() string {
 func (e *MemberAccessExpression) ToString() string {
 	return ""
 }
-
-func (e *MemberAccessExpression) Clone() Expression {
-	var pexp Expression
-	if e.PropertyExpression != nil {
-		pexp = e.PropertyExpression.Clone()
-	}
-	return &MemberAccessExpression{
-		Object:             e.Object.Clone(),
-		PropertyIdentifier: e.PropertyIdentifier,
-		PropertyExpression: pexp,
-		Compute:            e.Compute,
-		Line:               e.Line,
-		CharAt:             e.CharAt,
-	}
-}
@@ -0,0 +1,165 @@
+package core
+
+// MatchStatement runs the body of the first case matching the value of Discriminant, or Default when no case
+// matches it. Each case runs in its own execution context where the names of its pattern are declared.
+type MatchStatement struct {
+	Discriminant Expression
+	Cases        []MatchCase
+	Default      *BlockStatement
+	Line         int
+	CharAt       int
+}
+
+// MatchCase is a case of a match statement, it matches a value matching one of its patterns when its guard,
+// evaluated once the names of the pattern are declared, is truthy
+type MatchCase struct {
+	Patterns []CasePattern
+	Guard    Expression
+	Body     BlockStatement
+	Line     int
+	CharAt   int
+}
+
+// CasePattern is a pattern of a match case: a literal equal to the value, a name declared with the value, _
+// matching any value, or an array or object pattern like [x, ...] or {type: "user", name} whose elements match
+// the parts of the value.
+type CasePattern struct {
+	Literal  Expression  // 1, "user", #t, null
+	ID       *Identifier // the name declared with the matched value, nil for _ and ...
+	Array    bool
+	Object   bool
+	Keys     []string      // the property matched by each element of an object pattern, empty for its rest element
+	Elements []CasePattern // the elements of an array or object pattern
+	Rest     bool          // ... or ...name, the last element of a pattern matching the remaining parts of the value
+	Line     int
+	CharAt   int
+}
+
+func (stmt MatchStatement) Execute(ec *ExecutionContext) (Value, error) {
+	v, err := stmt.Discriminant.Evaluate(ec)
+	if err != nil {
+		return nil, err
+	}
+	if err := SingleValue(v, stmt.Discriminant); err != nil {
+		return nil, err
+	}
+	for _, c := range stmt.Cases {
+		for _, p := range c.Patterns {
+			bec := &ExecutionContext{
+				Type:      TypeBlockEC,
+				Outer:     ec,
+				Variables: map[string]Value{},
+			}
+			ok, err := p.Match(bec, v)
+			if err != nil {
+				return nil, err
+			}
+			if ok && c.Guard != nil {
+				t, err := c.Guard.Evaluate(bec)
+				if err != nil {
+					return nil, err
+				}
+				ok = t.IsTruthy()
+			}
+			if ok {
+				return c.Body.Execute(bec)
+			}
+		}
+	}
+	if stmt.Default != nil {
+		return stmt.Default.Execute(&ExecutionContext{
+			Type:      TypeBlockEC,
+			Outer:     ec,
+			Variables: map[string]Value{},
+		})
+	}
+	return nil, nil
+}
+
+// Match reports whether v matches p, the names of p are declared in ec with the parts of v they match
+func (p CasePattern) Match(ec *ExecutionContext, v Value) (bool, error) {
+	return p.MatchWith(ec, v, ec.Declare)
+}
+
+// MatchWith reports whether v matches p like Match, bind is given the names of p with the parts of v they
+// match. Literals are evaluated in ec.
+func (p CasePattern) MatchWith(ec *ExecutionContext, v Value, bind func(Identifier, Value)) (bool, error) {
+	switch {
+	case p.Literal != nil:
+		l, err := p.Literal.Evaluate(ec)
+		if err != nil {
+			return false, err
+		}
+		if !IsEqual(l, v) {
+			return false, nil
+		}
+	case p.Object:
+		obj, ok := v.(*ObjectValue)
+		if !ok {
+			return false, nil
+		}
+		taken := map[string]bool{}
+		for i, e := range p.Elements {
+			if e.Rest {
+				continue
+			}
+			part := obj.property(p.Keys[i])
+			if part == nil {
+				return false, nil
+			}
+			taken[p.Keys[i]] = true
+			if ok, err := e.MatchWith(ec, part, bind); !ok || err != nil {
+				return false, err
+			}
+		}
+		if p.rest() {
+			rest := &ObjectValue{Properties: []*PropertyValue{}}
+			for _, prop := range obj.Properties {
+				if prop.Key.Type != LiteralTypeString || !taken[prop.Key.Value] {
+					rest.Properties = append(rest.Properties, &PropertyValue{Key: prop.Key, Value: prop.Value})
+				}
+			}
+			p.Elements[len(p.Elements)-1].bind(bind, rest)
+		}
+	case p.Array:
+		arr, ok := v.(*ArrayValue)
+		if !ok {
+			return false, nil
+		}
+		n := len(p.Elements)
+		if p.rest() {
+			n--
+		}
+		if len(arr.Elements) < n || (!p.rest() && len(arr.Elements) > n) {
+			return false, nil
+		}
+		for i, e := range p.Elements[:n] {
+			if ok, err := e.MatchWith(ec, arr.Elements[i], bind); !ok || err != nil {
+				return false, err
+			}
+		}
+		if p.rest() {
+			rest := append([]Value{}, arr.Elements[n:]...)
+			p.Elements[n].bind(bind, &ArrayValue{Elements: rest})
+		}
+	}
+	p.bind(bind, v)
+	return true, nil
+}
+
+// bind gives the name of p with v to bind
+func (p CasePattern) bind(bind func(Identifier, Value), v Value) {
+	if p.ID != nil {
+		bind(*p.ID, v)
+	}
+}
+
+// rest reports whether the last element of p is a rest element
+func (p CasePattern) rest() bool {
+	return len(p.Elements) > 0 && p.Elements[len(p.Elements)-1].Rest
+}
+
+// Irrefutable reports whether p matches any value
+func (p CasePattern) Irrefutable() bool {
+	return p.Literal == nil && !p.Array && !p.Object
+}
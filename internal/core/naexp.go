@@ -0,0 +1,76 @@
+package core
+
+// NamedArgument is name: x in the arguments of a call, it is given to the parameter called name. Named
+// arguments come after the positional ones.
+type NamedArgument struct {
+	Name   string
+	Value  Expression
+	Line   int
+	CharAt int
+}
+
+func (e *NamedArgument) Evaluate(ec *ExecutionContext) (Value, error) {
+	return e.Value.Evaluate(ec)
+}
+
+// Arguments returns the arguments of a call to v from its argument expressions exps and their values: spread
+// elements are expanded and named arguments are put at the position of their parameter. The arguments which
+// are not given before a named one are nil.
+func (v *FunctionValue) Arguments(exps []Expression, values []Value) ([]Value, error) {
+	n := len(exps)
+	for n > 0 {
+		if _, ok := exps[n-1].(*NamedArgument); !ok {
+			break
+		}
+		n--
+	}
+	args, err := Spread(exps[:n], values[:n])
+	if err != nil {
+		return nil, err
+	}
+	for i, exp := range exps[n:] {
+		na := exp.(*NamedArgument)
+		j := v.param(na.Name)
+		if j < 0 {
+			return nil, RuntimeErrorf(na.Line, na.CharAt, "unknown argument '%s'", na.Name)
+		}
+		for len(args) <= j {
+			args = append(args, nil)
+		}
+		if args[j] != nil {
+			return nil, RuntimeErrorf(na.Line, na.CharAt, "duplicated argument '%s'", na.Name)
+		}
+		if err := SingleValue(values[n+i], na.Value); err != nil {
+			return nil, err
+		}
+		args[j] = values[n+i]
+	}
+	return args, nil
+}
+
+// param returns the position of the parameter called name, -1 when there is none. A rest parameter cannot be
+// named.
+func (v *FunctionValue) param(name string) int {
+	for i, p := range v.Params {
+		if p.Name == name && !p.Rest {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e *NamedArgument) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *NamedArgument) GetLine() int {
+	return e.Line
+}
+
+func (e *NamedArgument) GetType() string {
+	return "named argument"
+}
+
+func (e *NamedArgument) ToString() string {
+	return e.Name + ": " + e.Value.ToString()
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// A number is a literal value of type number. It is an int when its value is written without a dot nor an
+// exponent and fits in 64 bits, otherwise it is a float. Floats are always written with a dot, an exponent,
+// Inf or NaN so that the kind of a number is kept by its value.
+
+const (
+	NumberTypeInt   = "int"
+	NumberTypeFloat = "float"
+)
+
+// NewInt returns the number value of i
+func NewInt(i int64) *LiteralValue {
+	return &LiteralValue{
+		Type:  LiteralTypeNumber,
+		Value: strconv.FormatInt(i, 10),
+	}
+}
+
+// NewFloat returns the number value of f
+func NewFloat(f float64) *LiteralValue {
+	return &LiteralValue{
+		Type:  LiteralTypeNumber,
+		Value: FormatFloat(f),
+	}
+}
+
+// FormatFloat writes f in its shortest form, with a trailing .0 when it looks like an int
+func FormatFloat(f float64) string {
+	s := fmt.Sprintf("%v", f)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
+
+// NumberType returns whether the number value s is an int or a float
+func NumberType(s string) string {
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return NumberTypeInt
+	}
+	return NumberTypeFloat
+}
+
+// Int returns the value of the number v if it is an int
+func (v *LiteralValue) Int() (int64, bool) {
+	i, err := strconv.ParseInt(v.Value, 10, 64)
+	return i, err == nil
+}
+
+// Float returns the value of the number v as a float, ints are converted
+func (v *LiteralValue) Float() float64 {
+	f, _ := strconv.ParseFloat(v.Value, 64)
+	return f
+}
+
+// IsNumeric reports whether t is a number, a bigint or a decimal
+func IsNumeric(t PrimitiveType) bool {
+	return t == LiteralTypeNumber || t == LiteralTypeBigInt || t == LiteralTypeDecimal
+}
+
+// NumberKind returns int, float, bigint or decimal for the numeric value v
+func NumberKind(v *LiteralValue) string {
+	if v.Type == LiteralTypeNumber {
+		return NumberType(v.Value)
+	}
+	return string(v.Type)
+}
+
+func NewBigInt(i *big.Int) *LiteralValue {
+	return &LiteralValue{
+		Type:  LiteralTypeBigInt,
+		Value: i.String(),
+	}
+}
+
+func NewDecimalValue(d Decimal) *LiteralValue {
+	return &LiteralValue{
+		Type:  LiteralTypeDecimal,
+		Value: d.String(),
+	}
+}
+
+// BigInt returns the value of the int or bigint v
+func (v *LiteralValue) BigInt() *big.Int {
+	i, _ := new(big.Int).SetString(v.Value, 10)
+	return i
+}
+
+// Decimal returns the value of the int, bigint or decimal v
+func (v *LiteralValue) Decimal() Decimal {
+	if v.Type == LiteralTypeDecimal {
+		d, _ := ParseDecimal(v.Value)
+		return d
+	}
+	return NewDecimal(v.BigInt())
+}
+
+// Rat returns the exact value of the numeric v, it is false for infinite and NaN floats
+func (v *LiteralValue) Rat() (*big.Rat, bool) {
+	if v.Type == LiteralTypeNumber && NumberType(v.Value) == NumberTypeFloat {
+		f := v.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return v.Decimal().Rat(), true
+}
+
+// addInt, subInt, mulInt and powInt return false when the result overflows 64 bits
+
+func addInt(a int64, b int64) (int64, bool) {
+	s := a + b
+	return s, (s > a) == (b > 0)
+}
+
+func subInt(a int64, b int64) (int64, bool) {
+	s := a - b
+	return s, (s < a) == (b > 0)
+}
+
+func mulInt(a int64, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	return p, p/b == a && !(a == -1 && b == math.MinInt64) && !(b == -1 && a == math.MinInt64)
+}
+
+func powInt(a int64, b int64) (int64, bool) {
+	p := int64(1)
+	var ok bool
+	for b > 0 {
+		if b&1 == 1 {
+			if p, ok = mulInt(p, a); !ok {
+				return 0, false
+			}
+		}
+		b >>= 1
+		if b > 0 {
+			if a, ok = mulInt(a, a); !ok {
+				return 0, false
+			}
+		}
+	}
+	return p, true
+}
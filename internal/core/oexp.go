@@ -10,6 +10,7 @@ type (
 		Computed      bool
 		Shorthand     bool
 		Method        bool
+		Spread        bool // ...Value
 		Line          int
 		CharAt        int
 	}
@@ -20,49 +21,101 @@ type (
 	}
 )
 
-func (e *ObjectExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	props := []*ObjectProperty{}
-	for _, p := range e.Properties {
+func (e *ObjectExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	keys := make([]Value, len(e.Properties))
+	values := make([]Value, len(e.Properties))
+	for i, p := range e.Properties {
 		if p.Computed {
-			kexp, err := p.KeyExpression.Evaluate(ec)
+			k, err := p.KeyExpression.Evaluate(ec)
 			if err != nil {
 				return nil, err
 			}
-			kexp.SetLine(p.KeyExpression.GetLine())
-			kexp.SetCharAt(p.KeyExpression.GetCharAt())
-			p.KeyExpression = kexp
+			keys[i] = k
 		}
 		v, err := p.Value.Evaluate(ec)
-		v.SetLine(p.Value.GetLine())
-		v.SetCharAt(p.Value.GetCharAt())
 		if err != nil {
 			return nil, err
 		}
-		p.Value = v
-		props = append(props, p)
+		values[i] = v
 	}
-	e.Properties = props
-	return e, nil
+	return e.Build(keys, values)
 }
 
-func (e *ObjectExpression) IsTruthy() bool {
-	return len(e.Properties) > 0
+// Build creates the object from the evaluated keys of the computed properties, nil for the others, and the
+// evaluated values of the properties. A spread property copies the properties of an object, the elements of
+// an array or the characters of a string, a later property with the same key replaces an earlier one.
+func (e *ObjectExpression) Build(keys []Value, values []Value) (*ObjectValue, error) {
+	obj := &ObjectValue{
+		Properties: []*PropertyValue{},
+	}
+	for i, p := range e.Properties {
+		if err := SingleValue(values[i], p.Value); err != nil {
+			return nil, err
+		}
+		if p.Spread {
+			if err := p.spread(obj, values[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		key := &LiteralValue{
+			Type:  LiteralTypeString,
+			Value: p.KeyIdentifier.Name,
+		}
+		if p.Computed {
+			var err error
+			if key, err = p.Key(keys[i]); err != nil {
+				return nil, err
+			}
+		}
+		obj.Set(key, values[i])
+	}
+	return obj, nil
 }
 
-func (e *ObjectExpression) GetCharAt() int {
-	return e.CharAt
+func (p *ObjectProperty) spread(obj *ObjectValue, v Value) error {
+	switch sv := v.(type) {
+	case *ObjectValue:
+		for _, prop := range sv.Properties {
+			obj.Set(prop.Key, prop.Value)
+		}
+		return nil
+	case *ArrayValue:
+		for i, elem := range sv.Elements {
+			obj.Set(NewInt(int64(i)), elem)
+		}
+		return nil
+	case *LiteralValue:
+		if IsNullish(sv) {
+			return nil
+		}
+		if sv.Type == LiteralTypeString {
+			i := 0
+			for _, r := range sv.Value {
+				obj.Set(NewInt(int64(i)), &LiteralValue{Type: LiteralTypeString, Value: string(r)})
+				i++
+			}
+			return nil
+		}
+	}
+	return RuntimeErrorf(p.Line, p.CharAt, "cannot spread %s", v.GetType())
 }
 
-func (e *ObjectExpression) GetLine() int {
-	return e.Line
+// Key checks the evaluated key expression of a computed property
+func (p *ObjectProperty) Key(k Value) (*LiteralValue, error) {
+	key, ok := k.(*LiteralValue)
+	if !ok {
+		return nil, RuntimeErrorf(p.KeyExpression.GetLine(), p.KeyExpression.GetCharAt(), "property key of type %s is not supported", k.GetType())
+	}
+	return key, nil
 }
 
-func (e *ObjectExpression) SetLine(i int) {
-	e.Line = i
+func (e *ObjectExpression) GetCharAt() int {
+	return e.CharAt
 }
 
-func (e *ObjectExpression) SetCharAt(i int) {
-	e.CharAt = i
+func (e *ObjectExpression) GetLine() int {
+	return e.Line
 }
 
 func (e *ObjectExpression) GetType() string {
@@ -72,7 +125,9 @@ func (e *ObjectExpression) GetType() string {
 func (e *ObjectExpression) ToString() string {
 	s := "{"
 	for _, p := range e.Properties {
-		if p.Computed {
+		if p.Spread {
+			s = s + fmt.Sprintf("...%s, ", p.Value.ToString())
+		} else if p.Computed {
 			s = s + fmt.Sprintf("%s: %s, ", p.KeyExpression.ToString(), p.Value.ToString())
 		} else {
 			s = s + fmt.Sprintf("%s: %s, ", p.KeyIdentifier.Name, p.Value.ToString())
@@ -83,28 +138,3 @@ func (e *ObjectExpression) ToString() string {
 	}
 	return s + "}"
 }
-
-func (e *ObjectExpression) Clone() Expression {
-	props := []*ObjectProperty{}
-	for _, p := range e.Properties {
-		var kexp Expression
-		if p.KeyExpression != nil {
-			kexp = p.KeyExpression.Clone()
-		}
-		props = append(props, &ObjectProperty{
-			KeyIdentifier: p.KeyIdentifier,
-			KeyExpression: kexp,
-			Value:         p.Value.Clone(),
-			Shorthand:     p.Shorthand,
-			Method:        p.Method,
-			Computed:      p.Computed,
-			Line:          p.Line,
-			CharAt:        p.CharAt,
-		})
-	}
-	return &ObjectExpression{
-		Properties: props,
-		Line:       e.Line,
-		CharAt:     e.CharAt,
-	}
-}
@@ -7,22 +7,29 @@ type Operator struct {
 }
 
 var precedenceLevels = map[string]int{
-	// ".":   1,
-	"*":  2,
-	"/":  2,
-	"%":  2,
-	"+":  3,
-	"-":  3,
-	"<":  4,
-	"<=": 4,
-	">":  4,
-	">=": 4,
-	"==": 5,
-	// "===": 5,
-	"!=": 5,
-	// "!==": 5,
-	"&&": 6,
-	"||": 7,
+	"**":  1, // right-associative, binds tighter than a unary operator on its left
+	"*":   2,
+	"/":   2,
+	"%":   2,
+	"+":   3,
+	"-":   3,
+	"<<":  4,
+	">>":  4,
+	">>>": 4,
+	"<":   5,
+	"<=":  5,
+	">":   5,
+	">=":  5,
+	"==":  6,
+	"===": 6,
+	"!=":  6,
+	"!==": 6,
+	"&":   7,
+	"^":   8,
+	"|":   9,
+	"&&":  10,
+	"||":  11,
+	"??":  12,
 }
 
 func IsOperatorSymbol(s string) bool {
@@ -0,0 +1,72 @@
+package core
+
+import "fmt"
+
+type (
+	// PropertyValue is a property of an object, Key is a string or a number
+	PropertyValue struct {
+		Key      *LiteralValue
+		Value    Value
+		Constant bool // cannot be assigned, like an exported constant of a module
+	}
+	ObjectValue struct {
+		Properties []*PropertyValue
+	}
+)
+
+// Get returns the value of the property key, or nil when the object does not have it
+func (v *ObjectValue) Get(key *LiteralValue) Value {
+	for _, p := range v.Properties {
+		if p.Key.Type == key.Type && p.Key.Value == key.Value {
+			return p.Value
+		}
+	}
+	return nil
+}
+
+// constant reports whether the property key is a constant
+func (v *ObjectValue) constant(key *LiteralValue) bool {
+	for _, p := range v.Properties {
+		if p.Key.Type == key.Type && p.Key.Value == key.Value {
+			return p.Constant
+		}
+	}
+	return false
+}
+
+// Set changes the value of the property key, the property is added when the object does not have it
+func (v *ObjectValue) Set(key *LiteralValue, value Value) {
+	for _, p := range v.Properties {
+		if p.Key.Type == key.Type && p.Key.Value == key.Value {
+			p.Value = value
+			return
+		}
+	}
+	v.Properties = append(v.Properties, &PropertyValue{
+		Key:   key,
+		Value: value,
+	})
+}
+
+func (v *ObjectValue) IsTruthy() bool {
+	return len(v.Properties) > 0
+}
+
+func (v *ObjectValue) GetType() string {
+	return "object"
+}
+
+func (v *ObjectValue) ToString() string {
+	s := "{"
+	for _, p := range v.Properties {
+		s = s + fmt.Sprintf("%s: %s, ", p.Key.ToString(), p.Value.ToString())
+	}
+	if len(s) > 1 {
+		s = s[:len(s)-2]
+	}
+	return s + "}"
+}
+
+func (v *ObjectValue) property(name string) Value {
+	return v.Get(&LiteralValue{Type: LiteralTypeString, Value: name})
+}
@@ -0,0 +1,144 @@
+package core
+
+// Pattern is a destructuring pattern like [a, b = 1, ...rest] or {name, age: years, ...others}. Its elements
+// are identifiers which can have a default value for a missing part, collect the rest of the value or be
+// nested patterns. A pattern is declared like an identifier and can be the left side of an assignment.
+type Pattern struct {
+	Object   bool     // {...} instead of [...]
+	Keys     []string // the property given to each element of an object pattern, empty for its rest element
+	Elements []Identifier
+	Line     int
+	CharAt   int
+}
+
+// Destructure gives the parts of v to the identifiers of p with bind, the default values of the missing parts
+// are evaluated in ec once the identifiers before them are bound
+func (p *Pattern) Destructure(ec *ExecutionContext, v Value, bind func(Identifier, Value) error) error {
+	parts, err := p.parts(v)
+	if err != nil {
+		return err
+	}
+	for i, id := range p.Elements {
+		part := parts[i]
+		if part == nil && id.Default != nil {
+			if part, err = id.Default.Evaluate(ec); err != nil {
+				return err
+			}
+		} else if part == nil {
+			part = &LiteralValue{Type: LiteralTypeUndefined}
+		}
+		if id.Pattern != nil {
+			err = id.Pattern.Destructure(ec, part, bind)
+		} else {
+			err = bind(id, part)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Assign assigns the parts of v to the existing variables of p
+func (p *Pattern) Assign(ec *ExecutionContext, v Value) error {
+	return p.Destructure(ec, v, func(id Identifier, v Value) error {
+		if id.Binding == nil && ec.Constant(id.Name) {
+			return RuntimeErrorf(id.Line, id.CharAt, "cannot assign to constant %s", id.Name)
+		}
+		if !ec.Update(id.Name, id.Binding, v) {
+			return RuntimeErrorf(id.Line, id.CharAt, "%s is not defined", id.Name)
+		}
+		return nil
+	})
+}
+
+// parts returns the part of v given to each element of p, nil when v does not have it
+func (p *Pattern) parts(v Value) ([]Value, error) {
+	parts := make([]Value, len(p.Elements))
+	if p.Object {
+		obj, ok := v.(*ObjectValue)
+		if !ok {
+			return nil, RuntimeErrorf(p.Line, p.CharAt, "cannot destructure %s as an object", v.GetType())
+		}
+		taken := map[string]bool{}
+		for i, id := range p.Elements {
+			if !id.Rest {
+				taken[p.Keys[i]] = true
+				parts[i] = obj.property(p.Keys[i])
+			}
+		}
+		for i, id := range p.Elements {
+			if id.Rest {
+				rest := &ObjectValue{Properties: []*PropertyValue{}}
+				for _, prop := range obj.Properties {
+					if prop.Key.Type != LiteralTypeString || !taken[prop.Key.Value] {
+						rest.Properties = append(rest.Properties, &PropertyValue{Key: prop.Key, Value: prop.Value})
+					}
+				}
+				parts[i] = rest
+			}
+		}
+		return parts, nil
+	}
+	arr, ok := v.(*ArrayValue)
+	if !ok {
+		return nil, RuntimeErrorf(p.Line, p.CharAt, "cannot destructure %s as an array", v.GetType())
+	}
+	for i, id := range p.Elements {
+		if id.Rest {
+			rest := []Value{}
+			if i < len(arr.Elements) {
+				rest = append(rest, arr.Elements[i:]...)
+			}
+			parts[i] = &ArrayValue{Elements: rest}
+		} else if i < len(arr.Elements) {
+			parts[i] = arr.Elements[i]
+		}
+	}
+	return parts, nil
+}
+
+// Bind declares id with v, the identifiers of a pattern are declared with the parts of v
+func (ec *ExecutionContext) Bind(id Identifier, v Value) error {
+	if id.Pattern == nil {
+		ec.Declare(id, v)
+		return nil
+	}
+	return id.Pattern.Destructure(ec, v, func(id Identifier, v Value) error {
+		ec.Declare(id, v)
+		return nil
+	})
+}
+
+func (p *Pattern) Evaluate(ec *ExecutionContext) (Value, error) {
+	return nil, RuntimeErrorf(p.Line, p.CharAt, "cannot evaluate a destructuring pattern")
+}
+
+func (p *Pattern) GetCharAt() int {
+	return p.CharAt
+}
+
+func (p *Pattern) GetLine() int {
+	return p.Line
+}
+
+func (p *Pattern) GetType() string {
+	return "destructuring pattern"
+}
+
+func (p *Pattern) ToString() string {
+	s := ""
+	for i, id := range p.Elements {
+		if p.Object && !id.Rest && id.Name != p.Keys[i] {
+			s = s + p.Keys[i] + ": "
+		}
+		s = s + id.ToString() + ", "
+	}
+	if len(s) > 0 {
+		s = s[:len(s)-2]
+	}
+	if p.Object {
+		return "{" + s + "}"
+	}
+	return "[" + s + "]"
+}
@@ -6,25 +6,9 @@ type ReturnStatement struct {
 	CharAt   int
 }
 
-func (stmt ReturnStatement) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt ReturnStatement) Execute(ec *ExecutionContext) (Value, error) {
 	if stmt.Argument == nil {
-		return &LiteralExpression{
-			Type:   LiteralTypeUndefined,
-			Line:   stmt.Line,
-			CharAt: stmt.CharAt,
-		}, nil
+		return &LiteralValue{Type: LiteralTypeUndefined}, nil
 	}
 	return stmt.Argument.Evaluate(ec)
 }
-
-func (stmt ReturnStatement) Clone() Statement {
-	var arg Expression
-	if stmt.Argument != nil {
-		arg = stmt.Argument.Clone()
-	}
-	return ReturnStatement{
-		Argument: arg,
-		Line:     stmt.Line,
-		CharAt:   stmt.CharAt,
-	}
-}
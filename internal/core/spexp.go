@@ -0,0 +1,59 @@
+package core
+
+// SpreadElement is ...x in the elements of an array, the arguments of a call or the properties of an object.
+// It evaluates to x, the array, call or object it is in expands it.
+type SpreadElement struct {
+	Expression Expression
+	Line       int
+	CharAt     int
+}
+
+func (e *SpreadElement) Evaluate(ec *ExecutionContext) (Value, error) {
+	return e.Expression.Evaluate(ec)
+}
+
+// Spread expands the evaluated values of exps: the elements of an array or the characters of a string are
+// put in place of a spread element.
+func Spread(exps []Expression, values []Value) ([]Value, error) {
+	result := make([]Value, 0, len(values))
+	for i, v := range values {
+		if err := SingleValue(v, exps[i]); err != nil {
+			return nil, err
+		}
+		se, ok := exps[i].(*SpreadElement)
+		if !ok {
+			result = append(result, v)
+			continue
+		}
+		switch sv := v.(type) {
+		case *ArrayValue:
+			result = append(result, sv.Elements...)
+		case *LiteralValue:
+			if sv.Type != LiteralTypeString {
+				return nil, RuntimeErrorf(se.Line, se.CharAt, "cannot spread %s", v.GetType())
+			}
+			for _, r := range sv.Value {
+				result = append(result, &LiteralValue{Type: LiteralTypeString, Value: string(r)})
+			}
+		default:
+			return nil, RuntimeErrorf(se.Line, se.CharAt, "cannot spread %s", v.GetType())
+		}
+	}
+	return result, nil
+}
+
+func (e *SpreadElement) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *SpreadElement) GetLine() int {
+	return e.Line
+}
+
+func (e *SpreadElement) GetType() string {
+	return "spread element"
+}
+
+func (e *SpreadElement) ToString() string {
+	return "..." + e.Expression.ToString()
+}
@@ -1,12 +1,25 @@
 package core
 
 type Statement interface {
-	Execute(*ExecutionContext) (Expression, error)
-	Clone() Statement
+	Execute(*ExecutionContext) (Value, error)
 }
 
 type Identifier struct {
-	Name   string
-	Line   int
-	CharAt int
+	Name    string
+	Binding *Binding   // set by the resolver for local variables
+	Rest    bool       // ...name, the last parameter of a function collects the remaining arguments in an array
+	Default Expression // name = exp, the value of a missing argument, evaluated in the scope of the call
+	Pattern *Pattern   // [a, b] or {a, b}, the identifier has no name and declares the identifiers of the pattern
+	Line    int
+	CharAt  int
+}
+
+func (id Identifier) ToString() string {
+	if id.Pattern != nil {
+		return id.Pattern.ToString()
+	}
+	if id.Rest {
+		return "..." + id.Name
+	}
+	return id.Name
 }
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"strings"
+)
+
+// TemplateExpression is a string quoted by ` interpolating expressions, Quasis are the raw strings around
+// them so there is one more quasi than expressions
+type TemplateExpression struct {
+	Quasis      []string
+	Expressions []Expression
+	Line        int
+	CharAt      int
+}
+
+func (e *TemplateExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	values := []Value{}
+	for _, exp := range e.Expressions {
+		v, err := exp.Evaluate(ec)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return e.Apply(values), nil
+}
+
+// Apply joins the quasis of e with the evaluated expressions values
+func (e *TemplateExpression) Apply(values []Value) Value {
+	var b strings.Builder
+	for i, q := range e.Quasis {
+		b.WriteString(q)
+		if i < len(values) {
+			b.WriteString(values[i].ToString())
+		}
+	}
+	return &LiteralValue{
+		Type:  LiteralTypeString,
+		Value: b.String(),
+	}
+}
+
+func (e *TemplateExpression) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *TemplateExpression) GetLine() int {
+	return e.Line
+}
+
+func (e *TemplateExpression) GetType() string {
+	return "template expression"
+}
+
+func (e *TemplateExpression) ToString() string {
+	var b strings.Builder
+	b.WriteString("`")
+	for i, q := range e.Quasis {
+		b.WriteString(q)
+		if i < len(e.Expressions) {
+			b.WriteString("${" + e.Expressions[i].ToString() + "}")
+		}
+	}
+	b.WriteString("`")
+	return b.String()
+}
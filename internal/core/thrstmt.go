@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type ThrowStatement struct {
+	Argument Expression
+	Line     int
+	CharAt   int
+}
+
+func (stmt ThrowStatement) Execute(ec *ExecutionContext) (Value, error) {
+	v, err := stmt.Argument.Evaluate(ec)
+	if err != nil {
+		return nil, err
+	}
+	return nil, stmt.Throw(v)
+}
+
+// Throw returns the error throwing v, a value which is not an object is the message of an error object
+func (stmt ThrowStatement) Throw(v Value) error {
+	if _, ok := v.(*ObjectValue); !ok {
+		v = newErrorObject(v, stmt.Line, stmt.CharAt)
+	}
+	return ThrowError{
+		Value:  v,
+		Line:   stmt.Line,
+		CharAt: stmt.CharAt,
+	}
+}
+
+// ThrowError carries a value thrown by a throw statement until it is caught
+type ThrowError struct {
+	Value  Value
+	Line   int
+	CharAt int
+}
+
+func (err ThrowError) Error() string {
+	msg := err.Value.ToString()
+	if obj, ok := err.Value.(*ObjectValue); ok {
+		if m := obj.property("message"); m != nil {
+			msg = m.ToString()
+		}
+	}
+	return fmt.Sprintf("Runtime error: uncaught exception: %s. [%d,%d]", msg, err.Line, err.CharAt)
+}
+
+// ErrorValue converts an error raised while executing a statement into the value bound by catch, the value
+// thrown by a throw statement or an error object holding the message and the position of a runtime error
+func ErrorValue(err error) Value {
+	var terr ThrowError
+	if errors.As(err, &terr) {
+		return terr.Value
+	}
+	msg, line, charAt := err.Error(), 0, 0
+	var rerr RuntimeError
+	if errors.As(err, &rerr) {
+		msg, line, charAt = rerr.Message, rerr.Line, rerr.CharAt
+	}
+	return newErrorObject(&LiteralValue{
+		Type:  LiteralTypeString,
+		Value: msg,
+	}, line, charAt)
+}
+
+// IsControlFlowError reports whether err is used to unwind break, continue... and must never be caught
+func IsControlFlowError(err error) bool {
+	switch err.(type) {
+	case BreakError, ContinueError:
+		return true
+	}
+	return false
+}
+
+func newErrorObject(message Value, line int, charAt int) *ObjectValue {
+	return &ObjectValue{
+		Properties: []*PropertyValue{
+			{
+				Key:   &LiteralValue{Type: LiteralTypeString, Value: "message"},
+				Value: message,
+			},
+			{
+				Key:   &LiteralValue{Type: LiteralTypeString, Value: "line"},
+				Value: &LiteralValue{Type: LiteralTypeNumber, Value: strconv.Itoa(line)},
+			},
+			{
+				Key:   &LiteralValue{Type: LiteralTypeString, Value: "charAt"},
+				Value: &LiteralValue{Type: LiteralTypeNumber, Value: strconv.Itoa(charAt)},
+			},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package core
+
+type TryStatement struct {
+	Block     BlockStatement
+	Param     *Identifier
+	Handler   *BlockStatement
+	Finalizer *BlockStatement
+	Line      int
+	CharAt    int
+}
+
+func (stmt TryStatement) Execute(ec *ExecutionContext) (Value, error) {
+	rexp, err := stmt.Block.Execute(&ExecutionContext{
+		Type:      TypeBlockEC,
+		Outer:     ec,
+		Variables: map[string]Value{},
+	})
+	if err != nil && stmt.Handler != nil && !IsControlFlowError(err) {
+		bec := &ExecutionContext{
+			Type:      TypeBlockEC,
+			Outer:     ec,
+			Variables: map[string]Value{},
+		}
+		if stmt.Param != nil {
+			bec.Declare(*stmt.Param, ErrorValue(err))
+		}
+		rexp, err = stmt.Handler.Execute(bec)
+	}
+	if stmt.Finalizer != nil {
+		frexp, ferr := stmt.Finalizer.Execute(&ExecutionContext{
+			Type:      TypeBlockEC,
+			Outer:     ec,
+			Variables: map[string]Value{},
+		})
+		if frexp != nil || ferr != nil {
+			// return, throw, break... in finally overrides the result of try and catch
+			return frexp, ferr
+		}
+	}
+	return rexp, err
+}
@@ -0,0 +1,49 @@
+package core
+
+// TupleExpression is a list of expressions separated by commas: the values of return a, b or the variables
+// of a, b = f()
+type TupleExpression struct {
+	Elements []Expression
+	Line     int
+	CharAt   int
+}
+
+func (e *TupleExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	elems := []Value{}
+	for _, ee := range e.Elements {
+		v, err := ee.Evaluate(ec)
+		if err != nil {
+			return nil, err
+		}
+		if err := SingleValue(v, ee); err != nil {
+			return nil, err
+		}
+		elems = append(elems, v)
+	}
+	return &TupleValue{
+		Elements: elems,
+	}, nil
+}
+
+func (e *TupleExpression) GetCharAt() int {
+	return e.CharAt
+}
+
+func (e *TupleExpression) GetLine() int {
+	return e.Line
+}
+
+func (e *TupleExpression) GetType() string {
+	return "tuple"
+}
+
+func (e *TupleExpression) ToString() string {
+	s := ""
+	for _, ee := range e.Elements {
+		s = s + ee.ToString() + ", "
+	}
+	if len(s) > 0 {
+		s = s[:len(s)-2]
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package core
+
+import "fmt"
+
+// TupleValue holds the values returned together by return a, b. It is meant to be unpacked by a, b := f()
+// or a, b = f().
+type TupleValue struct {
+	Elements []Value
+}
+
+// Unpack returns the values given to n variables by the right side exps of a declaration or an assignment
+// evaluated to values. A single right side which is a tuple or a call must give exactly n values, a tuple
+// cannot be one of several right sides.
+func Unpack(n int, exps []Expression, values []Value, line int, charAt int) ([]Value, error) {
+	if len(values) == 1 {
+		t, ok := values[0].(*TupleValue)
+		if _, call := exps[0].(*CallExpression); !ok && (!call || n == 1) {
+			return values, nil
+		}
+		got := 1
+		if ok {
+			got = len(t.Elements)
+		}
+		if got != n {
+			return nil, mismatch(n, got, line, charAt)
+		}
+		if ok {
+			return t.Elements, nil
+		}
+		return values, nil
+	}
+	for i, v := range values {
+		if err := SingleValue(v, exps[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// SingleValue returns an error when the value v of exp is a tuple
+func SingleValue(v Value, exp Expression) error {
+	if _, ok := v.(*TupleValue); ok {
+		return RuntimeErrorf(exp.GetLine(), exp.GetCharAt(), "multiple values in single-value context")
+	}
+	return nil
+}
+
+// truthy evaluates the condition exp in ec, it must be a single value
+func truthy(ec *ExecutionContext, exp Expression) (bool, error) {
+	v, err := exp.Evaluate(ec)
+	if err != nil {
+		return false, err
+	}
+	if err := SingleValue(v, exp); err != nil {
+		return false, err
+	}
+	return v.IsTruthy(), nil
+}
+
+// mismatch returns the error of n variables given got values
+func mismatch(n int, got int, line int, charAt int) error {
+	return RuntimeErrorf(line, charAt, "assignment mismatch: %s but %s", plural(n, "variable"), plural(got, "value"))
+}
+
+func plural(n int, s string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, s)
+	}
+	return fmt.Sprintf("%d %ss", n, s)
+}
+
+func (v *TupleValue) IsTruthy() bool {
+	return len(v.Elements) > 0
+}
+
+func (v *TupleValue) GetType() string {
+	return "tuple"
+}
+
+func (v *TupleValue) ToString() string {
+	s := "("
+	for _, elm := range v.Elements {
+		s = s + elm.ToString() + ", "
+	}
+	if len(s) > 1 {
+		s = s[:len(s)-2]
+	}
+	return s + ")"
+}
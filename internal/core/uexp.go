@@ -2,31 +2,71 @@ package core
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/dhl1402/covidscript/internal/utils"
 )
 
 type UnaryExpression struct {
 	Expression
-	Line   int
-	CharAt int
+	Operator string // !, -, + or ~
+	Line     int
+	CharAt   int
 }
 
-func (e *UnaryExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	exp, err := e.Expression.Evaluate(ec)
+func (e *UnaryExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	v, err := e.Expression.Evaluate(ec)
 	if err != nil {
 		return nil, err
 	}
-	return &LiteralExpression{
-		Type:   LiteralTypeBoolean,
-		Value:  utils.ToBoolStr(!exp.IsTruthy()),
-		Line:   e.Line,
-		CharAt: e.CharAt,
-	}, nil
+	if err := SingleValue(v, e.Expression); err != nil {
+		return nil, err
+	}
+	return e.Apply(v)
 }
 
-func (e *UnaryExpression) IsTruthy() bool {
-	return true
+// Apply applies the operator of e to the evaluated operand v
+func (e *UnaryExpression) Apply(v Value) (Value, error) {
+	if e.Operator == "!" {
+		return &LiteralValue{
+			Type:  LiteralTypeBoolean,
+			Value: utils.ToBoolStr(!v.IsTruthy()),
+		}, nil
+	}
+	lv, ok := v.(*LiteralValue)
+	if e.Operator == "~" {
+		if !ok || !isInteger(lv) {
+			return nil, RuntimeErrorf(e.Line, e.CharAt, "cannot use '%s' operator with %s", e.Operator, kindOf(v))
+		}
+		if lv.Type == LiteralTypeBigInt {
+			return NewBigInt(new(big.Int).Not(lv.BigInt())), nil
+		}
+		i, _ := lv.Int()
+		return NewInt(^i), nil
+	}
+	if !ok || !IsNumeric(lv.Type) {
+		return nil, RuntimeErrorf(e.Line, e.CharAt, "cannot use '%s' operator with %s", e.Operator, v.GetType())
+	}
+	switch e.Operator {
+	case "-":
+		if lv.Type == LiteralTypeBigInt {
+			return NewBigInt(new(big.Int).Neg(lv.BigInt())), nil
+		}
+		if lv.Type == LiteralTypeDecimal {
+			return NewDecimalValue(lv.Decimal().Neg()), nil
+		}
+		if i, ok := lv.Int(); ok {
+			n, ok := subInt(0, i)
+			if !ok {
+				return nil, RuntimeErrorf(e.Line, e.CharAt, "integer overflow with '%s' operator", e.Operator)
+			}
+			return NewInt(n), nil
+		}
+		return NewFloat(-lv.Float()), nil
+	case "+":
+		return lv, nil
+	}
+	return nil, RuntimeErrorf(e.Line, e.CharAt, "operator %s is not supported", e.Operator)
 }
 
 func (e *UnaryExpression) GetCharAt() int {
@@ -37,26 +77,10 @@ func (e *UnaryExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *UnaryExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *UnaryExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *UnaryExpression) GetType() string {
 	return "unary expression"
 }
 
 func (e *UnaryExpression) ToString() string {
-	return fmt.Sprintf("!%s", e.Expression.ToString())
-}
-
-func (e *UnaryExpression) Clone() Expression {
-	return &UnaryExpression{
-		Expression: e.Expression.Clone(),
-		Line:       e.Line,
-		CharAt:     e.CharAt,
-	}
+	return fmt.Sprintf("%s%s", e.Operator, e.Expression.ToString())
 }
@@ -0,0 +1,9 @@
+package core
+
+// Value is the result of evaluating an expression. Values only exist while a program is running,
+// they never share memory with the syntax tree so the same tree can be executed many times.
+type Value interface {
+	IsTruthy() bool
+	GetType() string
+	ToString() string
+}
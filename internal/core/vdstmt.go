@@ -9,47 +9,67 @@ type (
 	}
 	VariableDeclaration struct {
 		Declarations []VariableDeclarator
+		Const        bool   // const a = 1, the variables cannot be assigned
+		Doc          string // /// doc comment above the declaration
 		Line         int
 		CharAt       int
 	}
 )
 
-func (stmt VariableDeclaration) Execute(ec *ExecutionContext) (Expression, error) {
+func (stmt VariableDeclaration) Execute(ec *ExecutionContext) (Value, error) {
+	values := []Value{}
 	for _, d := range stmt.Declarations {
 		if d.Init != nil {
 			value, err := d.Init.Evaluate(ec)
 			if err != nil {
 				return nil, err
 			}
-			ec.Set(d.ID.Name, value)
-		} else {
-			ec.Set(d.ID.Name, &LiteralExpression{
-				Type:   LiteralTypeUndefined,
-				Line:   d.ID.Line,
-				CharAt: d.ID.CharAt,
-			})
+			values = append(values, value)
 		}
 	}
-	return nil, nil
+	return nil, stmt.Declare(ec, values)
 }
 
-func (stmt VariableDeclaration) Clone() Statement {
-	ds := []VariableDeclarator{}
-	for _, vd := range stmt.Declarations {
-		var init Expression
-		if vd.Init != nil {
-			init = vd.Init.Clone()
+// Declare declares the variables of stmt with the evaluated initializations, a single call can give the values
+// of several variables. The variables without a value are undefined.
+func (stmt VariableDeclaration) Declare(ec *ExecutionContext, values []Value) error {
+	exps := []Expression{}
+	for _, d := range stmt.Declarations {
+		if d.Init != nil {
+			exps = append(exps, d.Init)
+		}
+	}
+	values, err := Unpack(len(stmt.Declarations), exps, values, stmt.Line, stmt.CharAt)
+	if err != nil {
+		return err
+	}
+	for i, d := range stmt.Declarations {
+		var v Value = &LiteralValue{Type: LiteralTypeUndefined}
+		if i < len(values) {
+			v = values[i]
+		}
+		if err := ec.Bind(d.ID, v); err != nil {
+			return err
+		}
+		if stmt.Const {
+			ec.constant(d.ID)
+		}
+	}
+	return nil
+}
+
+// constant marks the variables of id found by name as constants
+func (ec *ExecutionContext) constant(id Identifier) {
+	if id.Pattern != nil {
+		for _, e := range id.Pattern.Elements {
+			ec.constant(e)
+		}
+		return
+	}
+	if id.Binding == nil {
+		if ec.Constants == nil {
+			ec.Constants = map[string]bool{}
 		}
-		ds = append(ds, VariableDeclarator{
-			ID:     vd.ID,
-			Init:   init,
-			Line:   vd.Line,
-			CharAt: vd.CharAt,
-		})
-	}
-	return VariableDeclaration{
-		Declarations: ds,
-		Line:         stmt.Line,
-		CharAt:       stmt.CharAt,
+		ec.Constants[id.Name] = true
 	}
 }
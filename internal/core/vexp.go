@@ -1,30 +1,17 @@
 package core
 
-import (
-	"fmt"
-)
-
 type VariableExpression struct {
-	Name   string
-	Line   int
-	CharAt int
+	Name    string
+	Binding *Binding // set by the resolver for local variables
+	Line    int
+	CharAt  int
 }
 
-func (e *VariableExpression) Evaluate(ec *ExecutionContext) (Expression, error) {
-	if exp, ok := ec.Get(e.Name); ok {
-		result, err := exp.Evaluate(ec)
-		if err != nil {
-			return nil, err
-		}
-		result.SetLine(e.Line)
-		result.SetCharAt(e.CharAt)
-		return result, nil
+func (e *VariableExpression) Evaluate(ec *ExecutionContext) (Value, error) {
+	if v, ok := ec.Lookup(e.Name, e.Binding); ok {
+		return v, nil
 	}
-	return nil, fmt.Errorf("Runtime error: %s is not defined. [%d,%d]", e.Name, e.Line, e.CharAt)
-}
-
-func (e *VariableExpression) IsTruthy() bool {
-	return true
+	return nil, RuntimeErrorf(e.Line, e.CharAt, "%s is not defined", e.Name)
 }
 
 func (e *VariableExpression) GetCharAt() int {
@@ -35,14 +22,6 @@ func (e *VariableExpression) GetLine() int {
 	return e.Line
 }
 
-func (e *VariableExpression) SetLine(i int) {
-	e.Line = i
-}
-
-func (e *VariableExpression) SetCharAt(i int) {
-	e.CharAt = i
-}
-
 func (e *VariableExpression) GetType() string {
 	return "variable"
 }
@@ -50,11 +29,3 @@ func (e *VariableExpression) GetType() string {
 func (e *VariableExpression) ToString() string {
 	return e.Name
 }
-
-func (e *VariableExpression) Clone() Expression {
-	return &VariableExpression{
-		Name:   e.Name,
-		Line:   e.Line,
-		CharAt: e.CharAt,
-	}
-}
@@ -1,47 +1,121 @@
 package interpreter
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
 	"github.com/dhl1402/covidscript/internal/builtin"
 	"github.com/dhl1402/covidscript/internal/config"
 	"github.com/dhl1402/covidscript/internal/core"
 	"github.com/dhl1402/covidscript/internal/lexer"
 	"github.com/dhl1402/covidscript/internal/parser"
+	"github.com/dhl1402/covidscript/internal/resolver"
+	"github.com/dhl1402/covidscript/internal/vm"
 )
 
-func Interpret(script string, conf config.Config) error {
+// Program is a parsed script. Running it never modifies it, so the same Program can be run many times,
+// including from several goroutines at the same time.
+type Program struct {
+	statements []core.Statement
+	warnings   []string
+	compile    sync.Once
+	code       *vm.Program // nil until p is run by the VM
+}
+
+// Compile parses script and resolves its variables into a Program
+func Compile(script string) (*Program, error) {
 	tokens, err := lexer.Lex(script)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	ast, err := parser.ToAST(tokens)
 	if err != nil {
+		return nil, err
+	}
+	return newProgram(ast)
+}
+
+// newProgram resolves the variables of stmts, builtins are the only globals that are not declared by stmts
+// and they are constants
+func newProgram(stmts []core.Statement) (*Program, error) {
+	builtins := []string{}
+	for name := range createGlobalEC(config.Config{}).Variables {
+		builtins = append(builtins, name)
+	}
+	warnings, err := resolver.Resolve(stmts, builtins, builtins)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		statements: stmts,
+		warnings:   warnings,
+	}, nil
+}
+
+// Warnings returns the warnings found while compiling p
+func (p *Program) Warnings() []string {
+	return p.warnings
+}
+
+// warn writes the warnings of p to w
+func (p *Program) warn(w io.Writer) {
+	if w == nil {
+		return
+	}
+	for _, msg := range p.warnings {
+		fmt.Fprintln(w, msg)
+	}
+}
+
+// Run executes p in a new global execution context
+func (p *Program) Run(conf config.Config) error {
+	return p.execute(createGlobalEC(conf), conf.Backend)
+}
+
+func (p *Program) execute(gec *core.ExecutionContext, backend config.Backend) error {
+	switch backend {
+	case config.BackendVM:
+		p.compile.Do(func() {
+			p.code = vm.Compile(p.statements)
+		})
+		return vm.Run(gec, p.code)
+	case "", config.BackendTreeWalker:
+		_, err := core.BlockStatement{Statements: p.statements}.Execute(gec)
 		return err
 	}
-	gec := createGlobalEC(conf)
-	if err = execute(gec, ast); err != nil {
+	return fmt.Errorf("unknown backend %s", backend)
+}
+
+func Interpret(script string, conf config.Config) error {
+	p, err := Compile(script)
+	if err != nil {
 		return err
 	}
-	return nil
+	p.warn(conf.Warnings)
+	return p.Run(conf)
 }
 
-func execute(gec *core.ExecutionContext, stmts []core.Statement) error {
-	cexp := core.CallExpression{
-		Callee: &core.FunctionExpression{
-			Body: core.BlockStatement{
-				Statements: stmts,
-			},
-			Params: []core.Identifier{},
-			EC:     gec,
-		},
+// InterpretFile runs the script at path, imports are resolved relative to the importing file
+func InterpretFile(path string, conf config.Config) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
 	}
-	_, err := cexp.Evaluate(gec)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = newModuleLoader(conf, filepath.Dir(path)).run(string(b), path)
 	return err
 }
 
 func createGlobalEC(conf config.Config) *core.ExecutionContext {
-	return &core.ExecutionContext{
+	gec := &core.ExecutionContext{
 		Type: core.TypeGlobalEC,
-		Variables: map[string]core.Expression{
+		Variables: map[string]core.Value{
 			"echo":    builtin.Echo(conf),
 			"len":     builtin.Len(),
 			"filter":  builtin.Filter(),
@@ -58,6 +132,14 @@ func createGlobalEC(conf config.Config) *core.ExecutionContext {
 			"neg":     builtin.Negative(),
 			"floor":   builtin.Floor(),
 			"ceil":    builtin.Ceil(),
+			"bigint":  builtin.BigInt(),
+			"decimal": builtin.Decimal(),
+			"div":     builtin.Div(),
 		},
+		Constants: map[string]bool{},
+	}
+	for name := range gec.Variables {
+		gec.Constants[name] = true
 	}
+	return gec
 }
@@ -1,15 +1,32 @@
 package interpreter
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dhl1402/covidscript/internal/config"
 	"github.com/dhl1402/covidscript/internal/core"
 	"github.com/dhl1402/covidscript/internal/lexer"
 	"github.com/dhl1402/covidscript/internal/parser"
 )
 
+var backends = []config.Backend{config.BackendTreeWalker, config.BackendVM}
+
+// newGlobalEC returns an empty copy of ec so each backend runs a test case from the same state
+func newGlobalEC(ec *core.ExecutionContext) *core.ExecutionContext {
+	return &core.ExecutionContext{
+		Type:      ec.Type,
+		Variables: map[string]core.Value{},
+	}
+}
+
 func TestExecute(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -23,17 +40,15 @@ func TestExecute(t *testing.T) {
 			in:   "var a = 1",
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   1,
-							CharAt: 9,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
 					},
 				}
@@ -46,17 +61,15 @@ func TestExecute(t *testing.T) {
 				 var a = 2`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "2",
-							Line:   2,
-							CharAt: 9,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "2",
 						},
 					},
 				}
@@ -69,23 +82,19 @@ func TestExecute(t *testing.T) {
 				 var b = 2`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   1,
-							CharAt: 9,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
-						"b": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "2",
-							Line:   2,
-							CharAt: 9,
+						"b": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "2",
 						},
 					},
 				}
@@ -98,29 +107,23 @@ func TestExecute(t *testing.T) {
 				 var c = #f`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   1,
-							CharAt: 11,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
-						"b": &core.LiteralExpression{
-							Type:   core.LiteralTypeString,
-							Value:  "2",
-							Line:   1,
-							CharAt: 13,
+						"b": &core.LiteralValue{
+							Type:  core.LiteralTypeString,
+							Value: "2",
 						},
-						"c": &core.LiteralExpression{
-							Type:   core.LiteralTypeBoolean,
-							Value:  "#f",
-							Line:   2,
-							CharAt: 9,
+						"c": &core.LiteralValue{
+							Type:  core.LiteralTypeBoolean,
+							Value: "#f",
 						},
 					},
 				}
@@ -132,22 +135,18 @@ func TestExecute(t *testing.T) {
 			in:   `var a,b = 1`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   1,
-							CharAt: 11,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
-						"b": &core.LiteralExpression{
-							Type:   core.LiteralTypeUndefined,
-							Line:   1,
-							CharAt: 7,
+						"b": &core.LiteralValue{
+							Type: core.LiteralTypeUndefined,
 						},
 					},
 				}
@@ -164,53 +163,33 @@ func TestExecute(t *testing.T) {
 				 var d = a.b.c`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ObjectExpression{
-							Properties: []*core.ObjectProperty{
+					Variables: map[string]core.Value{
+						"a": &core.ObjectValue{
+							Properties: []*core.PropertyValue{
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   2,
-										CharAt: 1,
-									},
-									Value: &core.ObjectExpression{
-										Properties: []*core.ObjectProperty{
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "b"},
+									Value: &core.ObjectValue{
+										Properties: []*core.PropertyValue{
 											{
-												KeyIdentifier: core.Identifier{
-													Name:   "c",
-													Line:   3,
-													CharAt: 1,
-												},
-												Value: &core.LiteralExpression{
-													Type:   core.LiteralTypeNumber,
-													Value:  "1",
-													Line:   6,
-													CharAt: 9,
+												Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "c"},
+												Value: &core.LiteralValue{
+													Type:  core.LiteralTypeNumber,
+													Value: "1",
 												},
-												Line:   3,
-												CharAt: 1,
 											},
 										},
-										Line:   6,
-										CharAt: 9,
 									},
-									Line:   2,
-									CharAt: 1,
 								},
 							},
-							Line:   6,
-							CharAt: 9,
 						},
-						"d": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   6,
-							CharAt: 9,
+						"d": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
 					},
 				}
@@ -226,35 +205,27 @@ func TestExecute(t *testing.T) {
 				 ]`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ArrayExpression{
-							Elements: []core.Expression{
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeString,
-									Value:  "12",
-									Line:   2,
-									CharAt: 1,
+					Variables: map[string]core.Value{
+						"a": &core.ArrayValue{
+							Elements: []core.Value{
+								&core.LiteralValue{
+									Type:  core.LiteralTypeString,
+									Value: "12",
 								},
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "3",
-									Line:   3,
-									CharAt: 1,
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "3",
 								},
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeString,
-									Value:  "1#f",
-									Line:   4,
-									CharAt: 1,
+								&core.LiteralValue{
+									Type:  core.LiteralTypeString,
+									Value: "1#f",
 								},
 							},
-							Line:   1,
-							CharAt: 9,
 						},
 					},
 				}
@@ -266,27 +237,29 @@ func TestExecute(t *testing.T) {
 			in:   `var a = func(){}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.FunctionExpression{
+				fexp := &core.FunctionExpression{
 					Params: []core.Identifier{},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
 						CharAt:     15,
 					},
-					EC: &core.ExecutionContext{
-						Outer:     gec,
-						Variables: map[string]core.Expression{},
-					},
 					Line:   1,
 					CharAt: 9,
 				}
+				gec.Variables["a"] = &core.FunctionValue{
+					Params: fexp.Params,
+					Body:   fexp.Body,
+					EC:     gec,
+					Node:   fexp,
+				}
 				return gec
 			},
 			err: nil,
@@ -300,31 +273,33 @@ func TestExecute(t *testing.T) {
 			var d = a(1,2)`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.FunctionExpression{
+				fexp := &core.FunctionExpression{
 					Params: []core.Identifier{
-						{Name: "b", Line: 2, CharAt: 14},
-						{Name: "c", Line: 2, CharAt: 16},
+						{Name: "b", Binding: &core.Binding{Slot: 0}, Line: 2, CharAt: 14},
+						{Name: "c", Binding: &core.Binding{Slot: 1}, Line: 2, CharAt: 16},
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{
 							core.ReturnStatement{
 								Argument: &core.BinaryExpression{
 									Left: &core.VariableExpression{
-										Name:   "b",
-										Line:   3,
-										CharAt: 8,
+										Name:    "b",
+										Binding: &core.Binding{Slot: 0},
+										Line:    3,
+										CharAt:  8,
 									},
 									Right: &core.VariableExpression{
-										Name:   "c",
-										Line:   3,
-										CharAt: 10,
+										Name:    "c",
+										Binding: &core.Binding{Slot: 1},
+										Line:    3,
+										CharAt:  10,
 									},
 									Operator: core.Operator{
 										Symbol: "+",
@@ -341,18 +316,18 @@ func TestExecute(t *testing.T) {
 						Line:   2,
 						CharAt: 18,
 					},
-					EC: &core.ExecutionContext{
-						Outer:     gec,
-						Variables: map[string]core.Expression{},
-					},
-					Line:   5,
+					Line:   2,
 					CharAt: 9,
 				}
-				gec.Variables["d"] = &core.LiteralExpression{
-					Type:   core.LiteralTypeNumber,
-					Value:  "3",
-					Line:   3,
-					CharAt: 8,
+				gec.Variables["a"] = &core.FunctionValue{
+					Params: fexp.Params,
+					Body:   fexp.Body,
+					EC:     gec,
+					Node:   fexp,
+				}
+				gec.Variables["d"] = &core.LiteralValue{
+					Type:  core.LiteralTypeNumber,
+					Value: "3",
 				}
 				return gec
 			},
@@ -363,17 +338,15 @@ func TestExecute(t *testing.T) {
 			in:   `a:=1`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   1,
-							CharAt: 4,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "1",
 						},
 					},
 				}
@@ -385,27 +358,30 @@ func TestExecute(t *testing.T) {
 			in:   `func a(){}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.FunctionExpression{
+				fd := &core.FunctionDeclaration{
+					ID:     core.Identifier{Name: "a", Line: 1, CharAt: 6},
 					Params: []core.Identifier{},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
 						CharAt:     9,
 					},
-					EC: &core.ExecutionContext{
-						Outer:     gec,
-						Variables: map[string]core.Expression{},
-					},
 					Line:   1,
 					CharAt: 1,
 				}
+				gec.Variables["a"] = &core.FunctionValue{
+					Params: fd.Params,
+					Body:   fd.Body,
+					EC:     gec,
+					Node:   fd,
+				}
 				return gec
 			},
 			err: nil,
@@ -419,31 +395,34 @@ func TestExecute(t *testing.T) {
 			var d = a(1,2)`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.FunctionExpression{
+				fd := &core.FunctionDeclaration{
+					ID: core.Identifier{Name: "a", Line: 2, CharAt: 6},
 					Params: []core.Identifier{
-						{Name: "b", Line: 2, CharAt: 8},
-						{Name: "c", Line: 2, CharAt: 10},
+						{Name: "b", Binding: &core.Binding{Slot: 0}, Line: 2, CharAt: 8},
+						{Name: "c", Binding: &core.Binding{Slot: 1}, Line: 2, CharAt: 10},
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{
 							core.ReturnStatement{
 								Argument: &core.BinaryExpression{
 									Left: &core.VariableExpression{
-										Name:   "b",
-										Line:   3,
-										CharAt: 8,
+										Name:    "b",
+										Binding: &core.Binding{Slot: 0},
+										Line:    3,
+										CharAt:  8,
 									},
 									Right: &core.VariableExpression{
-										Name:   "c",
-										Line:   3,
-										CharAt: 10,
+										Name:    "c",
+										Binding: &core.Binding{Slot: 1},
+										Line:    3,
+										CharAt:  10,
 									},
 									Operator: core.Operator{
 										Symbol: "+",
@@ -460,18 +439,18 @@ func TestExecute(t *testing.T) {
 						Line:   2,
 						CharAt: 12,
 					},
-					EC: &core.ExecutionContext{
-						Outer:     gec,
-						Variables: map[string]core.Expression{},
-					},
-					Line:   5,
-					CharAt: 9,
+					Line:   2,
+					CharAt: 1,
 				}
-				gec.Variables["d"] = &core.LiteralExpression{
-					Type:   core.LiteralTypeNumber,
-					Value:  "3",
-					Line:   3,
-					CharAt: 8,
+				gec.Variables["a"] = &core.FunctionValue{
+					Params: fd.Params,
+					Body:   fd.Body,
+					EC:     gec,
+					Node:   fd,
+				}
+				gec.Variables["d"] = &core.LiteralValue{
+					Type:  core.LiteralTypeNumber,
+					Value: "3",
 				}
 				return gec
 			},
@@ -486,20 +465,19 @@ func TestExecute(t *testing.T) {
 				 b()`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "2",
-					Line:   3,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "2",
 				}
-				gec.Variables["b"] = &core.FunctionExpression{
+				fd := &core.FunctionDeclaration{
+					ID:     core.Identifier{Name: "b", Line: 2, CharAt: 6},
 					Params: []core.Identifier{},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{
@@ -522,13 +500,15 @@ func TestExecute(t *testing.T) {
 						Line:   2,
 						CharAt: 10,
 					},
-					EC: &core.ExecutionContext{
-						Outer:     gec,
-						Variables: map[string]core.Expression{},
-					},
-					Line:   5,
+					Line:   2,
 					CharAt: 1,
 				}
+				gec.Variables["b"] = &core.FunctionValue{
+					Params: fd.Params,
+					Body:   fd.Body,
+					EC:     gec,
+					Node:   fd,
+				}
 				return gec
 			},
 			err: nil,
@@ -542,10 +522,10 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: nil,
-			err:    core.BreakError{Message: "break is not in a loop. [2,1]"},
+			err:    core.RuntimeError{Message: "break is not in a loop", Line: 4, CharAt: 1},
 		},
 		{
 			name: "execute function declaration #5",
@@ -556,10 +536,10 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: nil,
-			err:    core.ContinueError{Message: "continue is not in a loop. [2,1]"},
+			err:    core.RuntimeError{Message: "continue is not in a loop", Line: 4, CharAt: 1},
 		},
 		{
 			name: "execute assignment statement #1",
@@ -569,17 +549,15 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   3,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
 					},
 				}
@@ -595,23 +573,19 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "1",
-							Line:   4,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "1",
 						},
-						"b": &core.LiteralExpression{
-							Type:   core.LiteralTypeNumber,
-							Value:  "2",
-							Line:   4,
-							CharAt: 3,
+						"b": &core.LiteralValue{
+							Type:  core.LiteralTypeNumber,
+							Value: "2",
 						},
 					},
 				}
@@ -626,29 +600,23 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ArrayExpression{
-							Elements: []core.Expression{
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeString,
-									Value:  "xxx",
-									Line:   3,
-									CharAt: 6,
+					Variables: map[string]core.Value{
+						"a": &core.ArrayValue{
+							Elements: []core.Value{
+								&core.LiteralValue{
+									Type:  core.LiteralTypeString,
+									Value: "xxx",
 								},
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "2",
-									Line:   2,
-									CharAt: 10,
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "2",
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -663,41 +631,31 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ArrayExpression{
-							Elements: []core.Expression{
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "1",
-									Line:   2,
-									CharAt: 8,
+					Variables: map[string]core.Value{
+						"a": &core.ArrayValue{
+							Elements: []core.Value{
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "1",
 								},
-								&core.ArrayExpression{
-									Elements: []core.Expression{
-										&core.LiteralExpression{
-											Type:   core.LiteralTypeNumber,
-											Value:  "2",
-											Line:   2,
-											CharAt: 11,
+								&core.ArrayValue{
+									Elements: []core.Value{
+										&core.LiteralValue{
+											Type:  core.LiteralTypeNumber,
+											Value: "2",
 										},
-										&core.LiteralExpression{
-											Type:   core.LiteralTypeString,
-											Value:  "xxx",
-											Line:   3,
-											CharAt: 9,
+										&core.LiteralValue{
+											Type:  core.LiteralTypeString,
+											Value: "xxx",
 										},
 									},
-									Line:   3,
-									CharAt: 1,
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -713,59 +671,43 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ArrayExpression{
-							Elements: []core.Expression{
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "1",
-									Line:   2,
-									CharAt: 8,
+					Variables: map[string]core.Value{
+						"a": &core.ArrayValue{
+							Elements: []core.Value{
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "1",
 								},
-								&core.ArrayExpression{
-									Elements: []core.Expression{
-										&core.LiteralExpression{
-											Type:   core.LiteralTypeNumber,
-											Value:  "2",
-											Line:   2,
-											CharAt: 11,
+								&core.ArrayValue{
+									Elements: []core.Value{
+										&core.LiteralValue{
+											Type:  core.LiteralTypeNumber,
+											Value: "2",
 										},
-										&core.LiteralExpression{
-											Type:   core.LiteralTypeString,
-											Value:  "xxx",
-											Line:   4,
-											CharAt: 12,
+										&core.LiteralValue{
+											Type:  core.LiteralTypeString,
+											Value: "xxx",
 										},
 									},
-									Line:   4,
-									CharAt: 1,
 								},
 							},
-							Line:   4,
-							CharAt: 1,
 						},
-						"b": &core.ArrayExpression{
-							Elements: []core.Expression{
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "0",
-									Line:   3,
-									CharAt: 8,
+						"b": &core.ArrayValue{
+							Elements: []core.Value{
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "0",
 								},
-								&core.LiteralExpression{
-									Type:   core.LiteralTypeNumber,
-									Value:  "1",
-									Line:   4,
-									CharAt: 3,
+								&core.LiteralValue{
+									Type:  core.LiteralTypeNumber,
+									Value: "1",
 								},
 							},
-							Line:   4,
-							CharAt: 3,
 						},
 					},
 				}
@@ -780,32 +722,22 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ObjectExpression{
-							Properties: []*core.ObjectProperty{
+					Variables: map[string]core.Value{
+						"a": &core.ObjectValue{
+							Properties: []*core.PropertyValue{
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   2,
-										CharAt: 8,
-									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "2",
-										Line:   3,
-										CharAt: 5,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "b"},
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "2",
 									},
-									Line:   2,
-									CharAt: 8,
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -820,47 +752,29 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ObjectExpression{
-							Properties: []*core.ObjectProperty{
+					Variables: map[string]core.Value{
+						"a": &core.ObjectValue{
+							Properties: []*core.PropertyValue{
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   2,
-										CharAt: 8,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "b"},
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "1",
 									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "1",
-										Line:   2,
-										CharAt: 10,
-									},
-									Line:   2,
-									CharAt: 8,
 								},
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "c",
-										Line:   3,
-										CharAt: 3,
-									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "2",
-										Line:   3,
-										CharAt: 5,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "c"},
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "2",
 									},
-									Line:   3,
-									CharAt: 5,
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -875,49 +789,32 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ObjectExpression{
-							Properties: []*core.ObjectProperty{
+					Variables: map[string]core.Value{
+						"a": &core.ObjectValue{
+							Properties: []*core.PropertyValue{
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   2,
-										CharAt: 8,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "b"},
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "1",
 									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "1",
-										Line:   2,
-										CharAt: 10,
-									},
-									Line:   2,
-									CharAt: 8,
 								},
 								{
-									KeyExpression: &core.LiteralExpression{
-										Type:   core.LiteralTypeString,
-										Value:  "c",
-										Line:   3,
-										CharAt: 3,
+									Key: &core.LiteralValue{
+										Type:  core.LiteralTypeString,
+										Value: "c",
 									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "2",
-										Line:   3,
-										CharAt: 8,
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "2",
 									},
-									Computed: true,
-									Line:     3,
-									CharAt:   8,
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -932,59 +829,37 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.ObjectExpression{
-							Properties: []*core.ObjectProperty{
+					Variables: map[string]core.Value{
+						"a": &core.ObjectValue{
+							Properties: []*core.PropertyValue{
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   2,
-										CharAt: 8,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "b"},
+									Value: &core.LiteralValue{
+										Type:  core.LiteralTypeNumber,
+										Value: "1",
 									},
-									Value: &core.LiteralExpression{
-										Type:   core.LiteralTypeNumber,
-										Value:  "1",
-										Line:   2,
-										CharAt: 10,
-									},
-									Line:   2,
-									CharAt: 8,
 								},
 								{
-									KeyIdentifier: core.Identifier{
-										Name:   "c",
-										Line:   2,
-										CharAt: 12,
-									},
-									Value: &core.ArrayExpression{
-										Elements: []core.Expression{
-											&core.LiteralExpression{
-												Type:   core.LiteralTypeNumber,
-												Value:  "2",
-												Line:   2,
-												CharAt: 15,
+									Key: &core.LiteralValue{Type: core.LiteralTypeString, Value: "c"},
+									Value: &core.ArrayValue{
+										Elements: []core.Value{
+											&core.LiteralValue{
+												Type:  core.LiteralTypeNumber,
+												Value: "2",
 											},
-											&core.LiteralExpression{
-												Type:   core.LiteralTypeString,
-												Value:  "xxx",
-												Line:   3,
-												CharAt: 8,
+											&core.LiteralValue{
+												Type:  core.LiteralTypeString,
+												Value: "xxx",
 											},
 										},
-										Line:   3,
-										CharAt: 1,
 									},
-									Line:   2,
-									CharAt: 12,
 								},
 							},
-							Line:   3,
-							CharAt: 1,
 						},
 					},
 				}
@@ -1003,23 +878,21 @@ func TestExecute(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				aec := &core.ExecutionContext{
-					Outer:     gec,
-					Variables: map[string]core.Expression{},
-				}
-				gec.Variables["a"] = &core.FunctionExpression{
+				fd := &core.FunctionDeclaration{
+					ID: core.Identifier{Name: "a", Line: 2, CharAt: 6},
 					Params: []core.Identifier{
 						{
-							Name:   "b",
-							Line:   2,
-							CharAt: 8,
+							Name:    "b",
+							Binding: &core.Binding{Slot: 0},
+							Line:    2,
+							CharAt:  8,
 						},
 					},
 					Body: core.BlockStatement{
@@ -1028,9 +901,10 @@ func TestExecute(t *testing.T) {
 								Argument: &core.FunctionExpression{
 									Params: []core.Identifier{
 										{
-											Name:   "c",
-											Line:   3,
-											CharAt: 13,
+											Name:    "c",
+											Binding: &core.Binding{Slot: 0},
+											Line:    3,
+											CharAt:  13,
 										},
 									},
 									Body: core.BlockStatement{
@@ -1038,14 +912,16 @@ func TestExecute(t *testing.T) {
 											core.ReturnStatement{
 												Argument: &core.BinaryExpression{
 													Left: &core.VariableExpression{
-														Name:   "b",
-														Line:   4,
-														CharAt: 8,
+														Name:    "b",
+														Binding: &core.Binding{Depth: 1, Slot: 0},
+														Line:    4,
+														CharAt:  8,
 													},
 													Right: &core.VariableExpression{
-														Name:   "c",
-														Line:   4,
-														CharAt: 10,
+														Name:    "c",
+														Binding: &core.Binding{Slot: 0},
+														Line:    4,
+														CharAt:  10,
 													},
 													Operator: core.Operator{
 														Symbol: "+",
@@ -1062,7 +938,6 @@ func TestExecute(t *testing.T) {
 										Line:   3,
 										CharAt: 15,
 									},
-									EC:     nil,
 									Line:   3,
 									CharAt: 8,
 								},
@@ -1073,15 +948,18 @@ func TestExecute(t *testing.T) {
 						Line:   2,
 						CharAt: 10,
 					},
-					EC:     aec,
-					Line:   7,
-					CharAt: 7,
+					Line:   2,
+					CharAt: 1,
 				}
-				gec.Variables["d"] = &core.LiteralExpression{
-					Type:   core.LiteralTypeNumber,
-					Value:  "3",
-					Line:   4,
-					CharAt: 8,
+				gec.Variables["a"] = &core.FunctionValue{
+					Params: fd.Params,
+					Body:   fd.Body,
+					EC:     gec,
+					Node:   fd,
+				}
+				gec.Variables["d"] = &core.LiteralValue{
+					Type:  core.LiteralTypeNumber,
+					Value: "3",
 				}
 				return gec
 			},
@@ -1096,18 +974,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "1",
-					Line:   4,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "1",
 				}
 				return gec
 			},
@@ -1122,18 +998,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "2",
-					Line:   4,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "2",
 				}
 				return gec
 			},
@@ -1148,18 +1022,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "1",
-					Line:   3,
-					CharAt: 5,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "1",
 				}
 				return gec
 			},
@@ -1176,18 +1048,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "3",
-					Line:   6,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "3",
 				}
 				return gec
 			},
@@ -1206,18 +1076,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "4",
-					Line:   8,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "4",
 				}
 				return gec
 			},
@@ -1238,18 +1106,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "5",
-					Line:   10,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "5",
 				}
 				return gec
 			},
@@ -1264,18 +1130,16 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				gec := &core.ExecutionContext{
 					Type:      core.TypeGlobalEC,
-					Variables: map[string]core.Expression{},
+					Variables: map[string]core.Value{},
 				}
-				gec.Variables["a"] = &core.LiteralExpression{
-					Type:   "number",
-					Value:  "2",
-					Line:   4,
-					CharAt: 3,
+				gec.Variables["a"] = &core.LiteralValue{
+					Type:  "number",
+					Value: "2",
 				}
 				return gec
 			},
@@ -1291,23 +1155,19 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   2,
-							CharAt: 7,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "1",
 						},
-						"b": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   5,
-							CharAt: 3,
+						"b": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
 					},
 				}
@@ -1325,17 +1185,15 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"b": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "4",
-							Line:   6,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"b": &core.LiteralValue{
+							Type:  "number",
+							Value: "4",
 						},
 					},
 				}
@@ -1353,17 +1211,15 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"c": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "4",
-							Line:   6,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"c": &core.LiteralValue{
+							Type:  "number",
+							Value: "4",
 						},
 					},
 				}
@@ -1378,7 +1234,7 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: nil,
 			err:    core.BreakError{Message: "break is not in a loop. [3,1]"},
@@ -1391,7 +1247,7 @@ func TestExecute(t *testing.T) {
 				}`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: nil,
 			err:    core.ContinueError{Message: "continue is not in a loop. [3,1]"},
@@ -1406,17 +1262,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   4,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
 					},
 				}
@@ -1434,23 +1288,19 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   5,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
-						"i": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   4,
-							CharAt: 5,
+						"i": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
 					},
 				}
@@ -1468,17 +1318,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   4,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
 					},
 				}
@@ -1495,17 +1343,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "3",
-							Line:   3,
-							CharAt: 5,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "3",
 						},
 					},
 				}
@@ -1525,17 +1371,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "2",
-							Line:   5,
-							CharAt: 4,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "2",
 						},
 					},
 				}
@@ -1555,17 +1399,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "10",
-							Line:   5,
-							CharAt: 4,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "10",
 						},
 					},
 				}
@@ -1583,17 +1425,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   4,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "1",
 						},
 					},
 				}
@@ -1613,17 +1453,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "2",
-							Line:   5,
-							CharAt: 4,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "2",
 						},
 					},
 				}
@@ -1643,17 +1481,15 @@ func TestExecute(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			wantEC: func() *core.ExecutionContext {
 				return &core.ExecutionContext{
 					Type: core.TypeGlobalEC,
-					Variables: map[string]core.Expression{
-						"a": &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   7,
-							CharAt: 3,
+					Variables: map[string]core.Value{
+						"a": &core.LiteralValue{
+							Type:  "number",
+							Value: "1",
 						},
 					},
 				}
@@ -1662,15 +1498,20 @@ func TestExecute(t *testing.T) {
 		},
 	}
 	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			tokens, err := lexer.Lex(tt.in)
-			require.Equal(t, err, nil)
-			stmts, _ := parser.ToAST(tokens)
-			require.Equal(t, tt.err, execute(tt.inEC, stmts))
-			if tt.err == nil {
-				require.Equal(t, tt.wantEC(), tt.inEC)
-			}
-		})
+		for _, backend := range backends {
+			t.Run(fmt.Sprintf("%s (%s)", tt.name, backend), func(t *testing.T) {
+				tokens, err := lexer.Lex(tt.in)
+				require.Equal(t, err, nil)
+				stmts, _ := parser.ToAST(tokens)
+				inEC := newGlobalEC(tt.inEC)
+				p, err := newProgram(stmts)
+				require.Equal(t, nil, err)
+				require.Equal(t, tt.err, p.execute(inEC, backend))
+				if tt.err == nil {
+					require.Equal(t, tt.wantEC(), inEC)
+				}
+			})
+		}
 	}
 }
 
@@ -1695,7 +1536,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "c",
 			var2:         "d",
@@ -1714,7 +1555,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 			`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "c",
 			var2:         "d",
@@ -1732,7 +1573,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "c",
 			var2:         "d",
@@ -1750,7 +1591,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1768,7 +1609,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1787,7 +1628,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1807,7 +1648,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1830,7 +1671,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1852,7 +1693,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1875,7 +1716,7 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 				`,
 			inEC: &core.ExecutionContext{
 				Type:      core.TypeGlobalEC,
-				Variables: map[string]core.Expression{},
+				Variables: map[string]core.Value{},
 			},
 			var1:         "b",
 			var2:         "c",
@@ -1884,40 +1725,1858 @@ func TestExecute_ExpressionPointer(t *testing.T) {
 		},
 	}
 	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			tokens, err := lexer.Lex(tt.in)
-			require.Equal(t, err, nil)
-			stmts, _ := parser.ToAST(tokens)
-			err = execute(tt.inEC, stmts)
-			require.Equal(t, err, nil)
-			if tt.pointerEqual {
-				require.Same(t, tt.inEC.Variables[tt.var1], tt.inEC.Variables[tt.var2])
-			} else {
-				require.NotSame(t, tt.inEC.Variables[tt.var1], tt.inEC.Variables[tt.var2])
-			}
-			if tt.valueEqual {
-				require.Equal(t, tt.inEC.Variables[tt.var1], tt.inEC.Variables[tt.var2])
-			} else {
-				require.NotEqual(t, tt.inEC.Variables[tt.var1], tt.inEC.Variables[tt.var2])
-			}
-		})
+		for _, backend := range backends {
+			t.Run(fmt.Sprintf("%s (%s)", tt.name, backend), func(t *testing.T) {
+				tokens, err := lexer.Lex(tt.in)
+				require.Equal(t, err, nil)
+				stmts, _ := parser.ToAST(tokens)
+				inEC := newGlobalEC(tt.inEC)
+				p, err := newProgram(stmts)
+				require.Equal(t, err, nil)
+				err = p.execute(inEC, backend)
+				require.Equal(t, err, nil)
+				if tt.pointerEqual {
+					require.Same(t, inEC.Variables[tt.var1], inEC.Variables[tt.var2])
+				} else {
+					require.NotSame(t, inEC.Variables[tt.var1], inEC.Variables[tt.var2])
+				}
+				if tt.valueEqual {
+					require.Equal(t, inEC.Variables[tt.var1], inEC.Variables[tt.var2])
+				} else {
+					require.NotEqual(t, inEC.Variables[tt.var1], inEC.Variables[tt.var2])
+				}
+			})
+		}
 	}
 }
 
-func TestTMP(t *testing.T) {
+func TestInterpret(t *testing.T) {
 	cases := []struct {
-		name   string
-		in     string
-		inEC   *core.ExecutionContext
-		wantEC func() *core.ExecutionContext
-		err    error
-	}{}
-	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			tokens, err := lexer.Lex(tt.in)
-			require.Equal(t, err, nil)
-			stmts, _ := parser.ToAST(tokens)
-			err = execute(tt.inEC, stmts)
+		name string
+		in   string
+		want string
+		err  error
+	}{
+		{
+			name: "interpret try statement #1",
+			in: `
+				try {
+					a := 1/0
+				} catch (e) {
+					echo(e.message, e.line, e.charAt)
+				}
+				`,
+			want: "cannot divide by zero 3 8 \n",
+		},
+		{
+			name: "interpret try statement #2",
+			in: `
+				try {
+					echo("try")
+				} catch (e) {
+					echo("catch")
+				} finally {
+					echo("finally")
+				}
+				`,
+			want: "try \nfinally \n",
+		},
+		{
+			name: "interpret try statement #3",
+			in: `
+				func f() {
+					try {
+						throw "boom"
+					} catch (e) {
+						return e.message
+					} finally {
+						echo("finally")
+					}
+				}
+				echo(f())
+				`,
+			want: "finally \nboom \n",
+		},
+		{
+			name: "interpret try statement #4",
+			in: `
+				try {
+					throw {code: 1}
+				} catch (e) {
+					echo(e.code)
+				}
+				`,
+			want: "1 \n",
+		},
+		{
+			name: "interpret try statement #5",
+			in: `
+				for i:=0;i<3;i=i+1 {
+					try {
+						if i == 1 {
+							break
+						}
+						echo(i)
+					} catch {
+						echo("catch")
+					}
+				}
+				`,
+			want: "0 \n",
+		},
+		{
+			name: "interpret try statement #6",
+			in: `
+				notFunc := 1
+				try {
+					try {
+						notFunc()
+					} finally {
+						echo("inner finally")
+					}
+				} catch (e) {
+					echo(e.message)
+				}
+				`,
+			want: "inner finally \nnotFunc is not a function \n",
+		},
+		{
+			name: "interpret try statement #7",
+			in: `
+				try {
+					[1][5]
+				} catch (e) {
+					echo(e.message, e.line, e.charAt)
+				}
+				try {
+					len(1)
+				} catch (e) {
+					echo(e.message, e.line, e.charAt)
+				}
+				`,
+			want: "index is out of range 3 5 \nunexpected number as argument type of len, expected array or string 8 1 \n",
+		},
+		{
+			name: "interpret try statement #8",
+			in: `
+				try {
+					sort([2, 1], func(a, b) {
+						throw {code: 7}
+					})
+				} catch (e) {
+					echo(e)
+				}
+				`,
+			want: "{code: 7} \n",
+		},
+		{
+			name: "interpret throw statement",
+			in: `
+				echo(1)
+				throw "boom"
+				echo(2)
+				`,
+			want: "1 \n",
+			err:  fmt.Errorf("Runtime error: uncaught exception: boom. [3,1]"),
+		},
+		{
+			name: "interpret for in statement #1",
+			in: `
+				for i, v in [10, 20, 30] {
+					if i == 1 {
+						continue
+					}
+					echo(i, v)
+				}
+				`,
+			want: "0 10 \n2 30 \n",
+		},
+		{
+			name: "interpret for in statement #2",
+			in: `
+				for k, v in {a: 1, ["b"]: 2} {
+					echo(k, v)
+				}
+				for k in {c: 3} {
+					echo(k)
+				}
+				`,
+			want: "a 1 \nb 2 \nc \n",
+		},
+		{
+			name: "interpret for in statement #3",
+			in: `
+				for i, c in "abc" {
+					if i == 2 {
+						break
+					}
+					echo(i, c)
+				}
+				`,
+			want: "0 a \n1 b \n",
+		},
+		{
+			name: "interpret for in statement #4",
+			in: `
+				func find(arr, x) {
+					for i, v in arr {
+						if v == x {
+							return i
+						}
+					}
+					return neg(1)
+				}
+				echo(find([1, 2, 3], 3))
+				`,
+			want: "2 \n",
+		},
+		{
+			name: "interpret for in statement #5",
+			in: `
+				fs := []
+				for i in [1, 2] {
+					fs = append(fs, func() {
+						return i
+					})
+				}
+				echo(i)
+				`,
+			err: fmt.Errorf("Resolving error: i is not defined. [8,6]"),
+		},
+		{
+			name: "interpret for in statement with number",
+			in:   `for i in 1 {}`,
+			err:  fmt.Errorf("Runtime error: cannot iterate over number. [1,10]"),
+		},
+		{
+			name: "interpret nested loops",
+			in: `
+				for i:=0;i<3;i=i+1 {
+					for j, v in ["a", "b", "c"] {
+						if j == 1 {
+							continue
+						}
+						if i == j {
+							break
+						}
+						echo(i, v)
+					}
+					if i == 1 {
+						continue
+					}
+					echo("end", i)
+				}
+				`,
+			want: "end 0 \n1 a \n1 c \n2 a \nend 2 \n",
+		},
+		{
+			name: "interpret recursive function",
+			in: `
+				func fib(n) {
+					if n < 2 {
+						return n
+					}
+					return fib(n-1) + fib(n-2)
+				}
+				echo(fib(15))
+				`,
+			want: "610 \n",
+		},
+		{
+			name: "interpret loop without test",
+			in: `
+				for i:=0;;i=i+1 {
+					if i == 2 {
+						break
+					}
+					echo(i)
+				}
+				`,
+			want: "0 \n1 \n",
+		},
+		{
+			name: "interpret import statement without file",
+			in:   `import "a.covs" as a`,
+			err:  fmt.Errorf("Runtime error: cannot import a.covs, modules are only supported when running a file. [1,1]"),
+		},
+		{
+			name: "interpret undeclared variable",
+			in: `
+				echo(1)
+				func f() {
+					return a
+				}
+				`,
+			err: fmt.Errorf("Resolving error: a is not defined. [4,8]"),
+		},
+		{
+			name: "interpret assignment to undeclared variable",
+			in: `
+				a := 1
+				b = a`,
+			err: fmt.Errorf("Resolving error: b is not defined. [3,1]"),
+		},
+		{
+			name: "interpret duplicate parameters",
+			in:   `func f(a, b, a) {}`,
+			err:  fmt.Errorf("Resolving error: a is already declared. [1,14]"),
+		},
+		{
+			name: "interpret duplicate declarations",
+			in:   `if #t { var a, a = 1, 2 }`,
+			err:  fmt.Errorf("Resolving error: a is already declared. [1,16]"),
+		},
+		{
+			name: "interpret variable used before its declaration",
+			in: `
+				a := "global"
+				func f() {
+					echo(a)
+					a := "local"
+					echo(a)
+				}
+				f()
+				echo(b)
+				b := 1
+				`,
+			err: fmt.Errorf("Resolving error: b is not defined. [9,6]"),
+		},
+		{
+			name: "interpret local variable declared after its use",
+			in: `
+				a := "global"
+				func f() {
+					echo(a)
+					a := "local"
+					echo(a)
+				}
+				f()
+				echo(a)
+				`,
+			want: "global \nlocal \nglobal \n",
+		},
+		{
+			name: "interpret closure called before the variable it uses is declared",
+			in: `
+				func f() {
+					g := func() {
+						return a
+					}
+					echo(g())
+					a := 1
+				}
+				f()
+				`,
+			err: fmt.Errorf("Runtime error: a is not defined. [4,8]"),
+		},
+		{
+			name: "interpret functions declared later",
+			in: `
+				func f() {
+					func isEven(n) {
+						if n == 0 {
+							return #t
+						}
+						return isOdd(n - 1)
+					}
+					func isOdd(n) {
+						if n == 0 {
+							return #f
+						}
+						return isEven(n - 1)
+					}
+					return isEven(10)
+				}
+				func g() {
+					return h()
+				}
+				func h() {
+					return "h"
+				}
+				echo(f(), g())
+				`,
+			want: "#t h \n",
+		},
+		{
+			name: "interpret shadowed variables",
+			in: `
+				a := 1
+				func f(a) {
+					for i := 0; i < 2; i = i + 1 {
+						a := a + i
+						echo(a)
+					}
+					return a
+				}
+				echo(f(10), a)
+				`,
+			want: "10 \n11 \n10 1 \n",
+		},
+		{
+			name: "interpret closure #1",
+			in: `
+				func makeCounter() {
+					count := 0
+					return func() {
+						count = count + 1
+						return count
+					}
+				}
+				c1 := makeCounter()
+				c2 := makeCounter()
+				echo(c1(), c1(), c1(), c2(), c1())
+				`,
+			want: "1 2 3 1 4 \n",
+		},
+		{
+			name: "interpret closure #2",
+			in: `
+				func makeAccount(balance) {
+					return {
+						deposit: func(n) {
+							balance = balance + n
+						},
+						balance: func() {
+							return balance
+						},
+					}
+				}
+				acc := makeAccount(10)
+				acc.deposit(5)
+				acc.deposit(1)
+				echo(acc.balance())
+				`,
+			want: "16 \n",
+		},
+		{
+			name: "interpret closure #3",
+			in: `
+				a := 1
+				get := func() {
+					return a
+				}
+				set := func(v) {
+					a = v
+				}
+				echo(get())
+				a = 2
+				echo(get())
+				set(3)
+				echo(a, get())
+				`,
+			want: "1 \n2 \n3 3 \n",
+		},
+		{
+			name: "interpret closure in loops",
+			in: `
+				fs := []
+				for i := 0; i < 3; i = i + 1 {
+					fs = append(fs, func() {
+						return i
+					})
+				}
+				echo(fs[0](), fs[1](), fs[2]())
+				gs := []
+				for i in [0, 1, 2] {
+					gs = append(gs, func() {
+						return i
+					})
+				}
+				echo(gs[0](), gs[1](), gs[2]())
+				hs := []
+				for i := 0; i < 3; i = i + 1 {
+					if #t {
+						j := i
+						hs = append(hs, func() {
+							j = j + 10
+							return j
+						})
+					}
+				}
+				echo(hs[0](), hs[0](), hs[1](), hs[2]())
+				`,
+			want: "3 3 3 \n0 1 2 \n10 20 11 12 \n",
+		},
+		{
+			name: "interpret closure in try statement",
+			in: `
+				func f() {
+					fs := []
+					try {
+						n := 0
+						fs = append(fs, func() {
+							n = n + 1
+							return n
+						})
+						throw "boom"
+					} catch (e) {
+						fs = append(fs, func() {
+							return e.message
+						})
+					}
+					return fs
+				}
+				fs := f()
+				echo(fs[0](), fs[0](), fs[1]())
+				`,
+			want: "1 2 boom \n",
+		},
+		{
+			name: "interpret recursive inner function",
+			in: `
+				func sum(arr) {
+					total := 0
+					func walk(i) {
+						if i < len(arr) {
+							total = total + arr[i]
+							walk(i + 1)
+						}
+					}
+					walk(0)
+					return total
+				}
+				fib := func(n) {
+					if n < 2 {
+						return n
+					}
+					return fib(n - 1) + fib(n - 2)
+				}
+				echo(sum([1, 2, 3, 4]), fib(10))
+				`,
+			want: "10 55 \n",
+		},
+		{
+			name: "interpret compound assignment",
+			in: `
+				a := 10
+				a += 5
+				a -= 3
+				a *= 4
+				a /= 6
+				a %= 5
+				s := "a"
+				s += "b"
+				echo(a, s)
+				`,
+			want: "3 ab \n",
+		},
+		{
+			name: "interpret compound assignment to member",
+			in: `
+				obj := {count: 1, items: [1, 2]}
+				obj.count += 1
+				obj["count"] *= 10
+				obj.items[1] -= 5
+				echo(obj.count, obj.items)
+				`,
+			want: "20 [1, -3] \n",
+		},
+		{
+			name: "interpret increment and decrement",
+			in: `
+				arr := [0, 0, 0]
+				n := 0
+				for i := 0; i < 3; i++ {
+					arr[i]++
+					n--
+				}
+				arr[2]++
+				obj := {a: {b: 1}}
+				obj.a.b++
+				echo(arr, n, obj.a.b)
+				`,
+			want: "[1, 1, 2] -3 2 \n",
+		},
+		{
+			name: "interpret compound assignment evaluates the target once",
+			in: `
+				arr := [1, 2, 3]
+				i := 0
+				func next() {
+					i++
+					return i
+				}
+				arr[next()] += 10
+				echo(arr, i)
+				`,
+			want: "[1, 12, 3] 1 \n",
+		},
+		{
+			name: "interpret compound assignment with wrong type",
+			in: `
+				a := "a"
+				a -= 1
+				`,
+			err: fmt.Errorf("Runtime error: cannot use '-' operator with string. [3,3]"),
+		},
+		{
+			name: "interpret compound assignment divided by zero",
+			in: `
+				a := 1
+				a /= 0
+				`,
+			err: fmt.Errorf("Runtime error: cannot divide by zero. [3,6]"),
+		},
+		{
+			name: "interpret increment undeclared variable",
+			in:   `i++`,
+			err:  fmt.Errorf("Resolving error: i is not defined. [1,1]"),
+		},
+		{
+			name: "interpret unary minus and plus",
+			in: `
+				a := 3
+				echo(-a, +a, - -a, 1 - -a, -(a - 5))
+				`,
+			want: "-3 3 3 4 2 \n",
+		},
+		{
+			name: "interpret unary minus with wrong type",
+			in: `
+				a := "a"
+				echo(-a)
+				`,
+			err: fmt.Errorf("Runtime error: cannot use '-' operator with string. [3,6]"),
+		},
+		{
+			name: "interpret power",
+			in:   `echo(2**10, 2**3**2, -2**2, (-2)**2, 2**-1, 2*3**2)`,
+			want: "1024 512 -4 4 0.5 18 \n",
+		},
+		{
+			name: "interpret power with wrong type",
+			in:   `echo(#t**2)`,
+			err:  fmt.Errorf("Runtime error: cannot use '**' operator with boolean. [1,8]"),
+		},
+		{
+			name: "interpret conditional expression",
+			in: `
+				func sign(n) {
+					return n > 0 ? 1 : n < 0 ? -1 : 0
+				}
+				echo(sign(5), sign(-5), sign(0), (#t ? 1 : 2) + 10)
+				`,
+			want: "1 -1 0 11 \n",
+		},
+		{
+			name: "interpret conditional expression evaluates one branch",
+			in: `
+				n := 0
+				func inc() {
+					n++
+					return n
+				}
+				a := #f ? inc() : 10
+				echo(a, n)
+				`,
+			want: "10 0 \n",
+		},
+		{
+			name: "interpret precedence of a long chain",
+			in:   `echo(#f || 2 == 1 + 1 * 1, 1 + 2 * 3 - 4 / 2, !#f && 1 < 2)`,
+			want: "#t 5 #t \n",
+		},
+		{
+			name: "interpret string escape sequences",
+			in:   `echo("a\tb\n\"c\"", 'd\'e', "\\", "\u{48}\u{49}")`,
+			want: "a\tb\n\"c\" d'e \\ HI \n",
+		},
+		{
+			name: "interpret raw string",
+			in:   "echo(`a\\n\nb`)",
+			want: "a\\n\nb \n",
+		},
+		{
+			name: "interpret template string",
+			in: `
+				name := "world"
+				arr := [1, 2]
+				func greet(s) {
+					return "hello " + s
+				}
+				echo(` + "`${greet(name)}! ${arr} ${arr[0] + arr[1]} ${`${name}`}`" + `)
+				`,
+			want: "hello world! [1, 2] 3 world \n",
+		},
+		{
+			name: "interpret template string with error",
+			in: `
+				echo(` + "`a\n${b}`" + `)
+				`,
+			err: fmt.Errorf("Resolving error: b is not defined. [3,3]"),
+		},
+		{
+			name: "interpret unicode strings",
+			in: `
+				café := "héllo 世界"
+				echo(len(café), café[1], café[7])
+				for i, c in "añb" {
+					echo(i, c)
+				}
+				`,
+			want: "8 é 界 \n0 a \n1 ñ \n2 b \n",
+		},
+		{
+			name: "interpret unicode string index out of range",
+			in: `
+				s := "日本"
+				echo(s[2])
+				`,
+			err: fmt.Errorf("Runtime error: index is out of range. [3,8]"),
+		},
+		{
+			name: "interpret error column after unicode characters",
+			in:   `echo("ü", ü)`,
+			err:  fmt.Errorf("Resolving error: ü is not defined. [1,11]"),
+		},
+		{
+			name: "interpret block and doc comments",
+			in: `
+				/// double returns n * 2
+				func double(n) {
+					/* a block comment
+					   over lines */
+					return n /* inline */ * 2
+				}
+				echo(double(2)) // 4
+				`,
+			want: "4 \n",
+		},
+		{
+			name: "interpret unterminated block comment",
+			in: `
+				echo(1)
+				/* echo(2)
+				`,
+			err: fmt.Errorf("Lexing error: unterminated comment. [3,1]"),
+		},
+		{
+			name: "interpret extended number literals",
+			in: `
+				echo(0xFF, 0b1010, 0o755, 1_000_000, 1e-9, .5, 2.5e3)
+				echo(0xFF + 0b1 == 256, 0o10 % 3)
+				`,
+			want: "255 10 493 1000000 1e-09 0.5 2500.0 \n#t 2 \n",
+		},
+		{
+			name: "interpret int and float numbers",
+			in: `
+				echo(7 / 2, -7 / 2, 7 % 3, -7 % 3, 7.0 / 2, 7.5 % 2, -7.5 % 2)
+				echo(1 + 2, 1 + 2.0, 3 * 1.5, 2 ** 10, 2 ** -1, 2.0 ** 2)
+				echo(0.1 + 0.2, 1.5 - 0.5, 1 == 1.0, 2 > 1.5, 1.0 < 1)
+				echo(type(1), type(1.0), type(1e3), type(7 / 2), type(-1), type("1"))
+				echo(9007199254740993, 9007199254740993 + 1, 9223372036854775807)
+				`,
+			want: "3 -3 1 -1 3.5 1.5 -1.5 \n3 3.0 4.5 1024 0.5 4.0 \n0.30000000000000004 1.0 #t #t #f \nint float float int int string \n9007199254740993 9007199254740994 9223372036854775807 \n",
+		},
+		{
+			name: "interpret integer overflow on addition",
+			in:   `echo(9223372036854775807 + 1)`,
+			err:  fmt.Errorf("Runtime error: integer overflow with '+' operator. [1,26]"),
+		},
+		{
+			name: "interpret integer overflow on multiplication",
+			in: `
+				a := 4294967296
+				echo(a * a)
+				`,
+			err: fmt.Errorf("Runtime error: integer overflow with '*' operator. [3,8]"),
+		},
+		{
+			name: "interpret integer overflow on power",
+			in:   `echo(2 ** 63)`,
+			err:  fmt.Errorf("Runtime error: integer overflow with '**' operator. [1,8]"),
+		},
+		{
+			name: "interpret integer overflow on negation",
+			in:   `echo(-(-9223372036854775807 - 1))`,
+			err:  fmt.Errorf("Runtime error: integer overflow with '-' operator. [1,6]"),
+		},
+		{
+			name: "interpret integer modulo by zero",
+			in:   `echo(1 % 0)`,
+			err:  fmt.Errorf("Runtime error: cannot divide by zero. [1,10]"),
+		},
+		{
+			name: "interpret float modulo by zero",
+			in:   `echo(1.5 % 0.0)`,
+			err:  fmt.Errorf("Runtime error: cannot divide by zero. [1,12]"),
+		},
+		{
+			name: "interpret bigint",
+			in: `
+				a := 9223372036854775807n
+				echo(a + 1, a * a, 7n / 2, -7n % 2n, 2n ** 100, -a, 1n == 1, 2n > 1.5)
+				echo(bigint(12), bigint("123456789012345678901234567890"), bigint(2.0), bigint(10.00d), type(1n))
+				`,
+			want: "9223372036854775808 85070591730234615847396907784232501249 3 -1 1267650600228229401496703205376 -9223372036854775807 #t #t \n12 123456789012345678901234567890 2 10 bigint \n",
+		},
+		{
+			name: "interpret decimal",
+			in: `
+				echo(0.1d + 0.2d, 0.1 + 0.2, 1.10d * 3, 10.00d / 4, 1d / 3, 7.5d % 2, 1.5d ** 2, -12.30d, 2d ** -2)
+				echo(1.50d == 1.5d, 0.1d == 0.1, 1.5d < 2n, type(1d), "$" + 12.30d)
+				echo(decimal(1), decimal(0.1), decimal("12.345"), decimal(2.675, 2), decimal(2.665, 2, "half_up"), decimal(-2.5, 0, "floor"))
+				`,
+			want: "0.3 0.30000000000000004 3.30 2.50 0.3333333333333333 1.5 2.25 -12.30 0.25 \n#t #f #t decimal $12.30 \n1 0.1 12.345 2.68 2.67 -3 \n",
+		},
+		{
+			name: "interpret decimal with float",
+			in:   `echo(1.5d + 1.5)`,
+			err:  fmt.Errorf("Runtime error: cannot use '+' operator with decimal and float. [1,11]"),
+		},
+		{
+			name: "interpret bigint with negative exponent",
+			in:   `echo(2n ** -1)`,
+			err:  fmt.Errorf("Runtime error: exponent of '**' operator with bigint must not be negative. [1,9]"),
+		},
+		{
+			name: "interpret decimal division by zero",
+			in:   `echo(1.5d / 0)`,
+			err:  fmt.Errorf("Runtime error: cannot divide by zero. [1,13]"),
+		},
+		{
+			name: "interpret bigint of fraction",
+			in:   `echo(bigint(1.5))`,
+			err:  fmt.Errorf("Runtime error: cannot convert number 1.5 to bigint. [1,6]"),
+		},
+		{
+			name: "interpret decimal with unknown rounding mode",
+			in:   `echo(decimal(1.5, 0, "nearest"))`,
+			err:  fmt.Errorf("Runtime error: unknown rounding mode nearest. [1,6]"),
+		},
+		{
+			name: "interpret decimal division with scale and rounding mode",
+			in: `
+				echo(div(1, 3), div(2d, 3, 2), div(2d, 3, 2, "down"), div(0.25d, 2, 2), div(0.25d, 2, 2, "half_up"), div(-10n, 4, 0, "floor"))
+				`,
+			want: "0.3333333333333333 0.67 0.66 0.12 0.13 -3 \n",
+		},
+		{
+			name: "interpret decimal division of a float",
+			in:   `echo(div(1.5, 2, 1))`,
+			err:  fmt.Errorf("Runtime error: unexpected number as argument type of div, expected int, bigint or decimal. [1,6]"),
+		},
+		{
+			name: "interpret decimal division with unknown rounding mode",
+			in:   `echo(div(1d, 3, 2, "nearest"))`,
+			err:  fmt.Errorf("Runtime error: unknown rounding mode nearest. [1,6]"),
+		},
+		{
+			name: "interpret strict and deep equality",
+			in: `
+				a := {x: [1, 2], y: "s"}
+				b := {y: "s", x: [1, 2.0]}
+				echo(a == b, a === b, a === a, a != b, a !== b, [a] == [b], [1, 2] == [2, 1])
+				echo(1 == 1.0, 1 === 1.0, 1 !== 1.0, 1n === 1, 1.50d === 1.5d, "1" == 1, null === null, undefined !== null)
+				f := func() {}
+				echo(f == f, f === f, f == func() {}, indexOf([[1], [2]], [2]))
+				c := [1]
+				append(c, c)
+				d := [1]
+				append(d, d)
+				echo(c == d, c === d)
+				`,
+			want: "#t #f #t #f #t #t #f \n#t #f #t #f #t #f #t #t \n#t #t #f 1 \n#t #f \n",
+		},
+		{
+			name: "interpret bitwise and shift operators",
+			in: `
+				flags := 0b0101
+				echo(flags & 0b0100, flags | 0b1000, flags ^ 0b1111, ~flags, 1 << 10, -16 >> 2, -16 >>> 60, 1 << 64)
+				echo(1 + 2 << 1, (6 & 3) == 2, 1 | 2 ^ 3 & 4, 0xFF00 >> 8 & 0xF)
+				echo(1n << 100, (1n << 100) >> 99, 0xFFn & 15, ~0n, -1 >>> 0)
+				echo(#f && 1 / 0, #t || 1 / 0, 1 && 2, 0 || 3)
+				`,
+			want: "4 13 10 -6 1024 -4 15 0 \n6 #t 3 15 \n1267650600228229401496703205376 2 15 -1 -1 \n#f #t #t #t \n",
+		},
+		{
+			name: "interpret bitwise operator with float",
+			in:   `echo(1.5 & 1)`,
+			err:  fmt.Errorf("Runtime error: cannot use '&' operator with float. [1,10]"),
+		},
+		{
+			name: "interpret bitwise not with string",
+			in:   `echo(~"a")`,
+			err:  fmt.Errorf("Runtime error: cannot use '~' operator with string. [1,6]"),
+		},
+		{
+			name: "interpret negative shift count",
+			in:   `echo(1 << -1)`,
+			err:  fmt.Errorf("Runtime error: shift count of '<<' operator must not be negative. [1,8]"),
+		},
+		{
+			name: "interpret unsigned shift of bigint",
+			in:   `echo(8n >>> 1)`,
+			err:  fmt.Errorf("Runtime error: cannot use '>>>' operator with bigint. [1,9]"),
+		},
+		{
+			name: "interpret null-coalescing",
+			in: `
+				func fail() {
+					throw "evaluated"
+				}
+				echo(null ?? 1, undefined ?? "a", 0 ?? 1, "" ?? 1, #f ?? 1, 1 ?? fail())
+				echo(null ?? undefined ?? 3, null || 2 ?? 4)
+				`,
+			want: "1 a 0  #f 1 \n3 #t \n",
+		},
+		{
+			name: "interpret optional chaining",
+			in: `
+				calls := 0
+				func key() {
+					calls += 1
+					return "b"
+				}
+				config := {db: {host: "h", ports: [1, 2]}, log: null, f: func(x) { return x * 2 }}
+				echo(config.db?.host, config.log?.level, config.log?.level.name.first, config.cache?.[key()], calls)
+				echo(config.db?.ports?.[1], config.f?.(2), config.g?.(key()), calls, config?.db.host)
+				echo(config.log?.level ?? "info", (config.log?.level)?.name, null?.a)
+				`,
+			want: "h undefined undefined undefined 0 \n2 4 undefined 0 h \ninfo undefined undefined \n",
+		},
+		{
+			name: "interpret optional chaining stops at parentheses",
+			in: `
+				a := null
+				echo((a?.b).c)
+				`,
+			err: fmt.Errorf("Runtime error: can't access property of type undefined. [3,7]"),
+		},
+		{
+			name: "interpret optional call of a non function",
+			in: `
+				a := {b: 1}
+				echo(a.b?.())
+				`,
+			err: fmt.Errorf("Runtime error:  is not a function. [3,6]"),
+		},
+		{
+			name: "interpret rest parameters",
+			in: `
+				func f(a, ...rest) {
+					echo(a, rest, len(rest))
+				}
+				f(1, 2, 3)
+				f(1)
+				f()
+				echo(f)
+				`,
+			want: "1 [2, 3] 2 \n1 [] 0 \nundefined [] 0 \nfunc(a, ...rest) \n",
+		},
+		{
+			name: "interpret spread",
+			in: `
+				func sum(a, b, c) {
+					return a + b + c
+				}
+				a := [1, 2]
+				b := [3]
+				echo(sum(...a, 3), sum(0, ...b, ...a), [...a, ...b, 4], [..."hé", ...[]])
+				base := {x: 0, y: 2}
+				echo({...base, x: 1}, {x: 1, ...base}, {...null, ...["a"], ..."b"})
+				echo(...a, ...b)
+				echo(append(a, ...b), a)
+				`,
+			want: "6 4 [1, 2, 3, 4] [h, é] \n{x: 1, y: 2} {x: 0, y: 2} {0: b} \n1 2 3 \n[1, 2, 3] [1, 2] \n",
+		},
+		{
+			name: "interpret arguments are not visible in scopes",
+			in: `
+				func f(a) {
+					echo(_args0_)
+				}
+				f(1)
+				`,
+			err: fmt.Errorf("Resolving error: _args0_ is not defined. [3,6]"),
+		},
+		{
+			name: "interpret spread of a number",
+			in: `
+				echo(...1)
+				`,
+			err: fmt.Errorf("Runtime error: cannot spread number. [2,6]"),
+		},
+		{
+			name: "interpret default parameter values",
+			in: `
+				calls := 0
+				func next() {
+					calls += 1
+					return calls
+				}
+				func connect(host, port = 8080, opts = {}, id = next(), url = host + ":" + port) {
+					opts.n = id
+					echo(host, port, opts, url)
+				}
+				connect("a")
+				connect("b", 9, {x: 1}, 5)
+				connect("c", 7)
+				echo(calls, connect)
+				`,
+			want: "a 8080 {n: 1} a:8080 \nb 9 {x: 1, n: 5} b:9 \nc 7 {n: 2} c:7 \n" +
+				"2 func(host, port, opts, id, url) \n",
+		},
+		{
+			name: "interpret named arguments",
+			in: `
+				func connect(host, port = 8080, opts = {}) {
+					return host + ":" + port + " " + len(keys(opts))
+				}
+				args := ["x"]
+				echo(connect(host: "x", port: 9), connect("x", opts: {a: 1}), connect(...args, port: 1,))
+				echo(connect(opts: {}, host: "y"), join(separator: "-", array: [1, 2]))
+				`,
+			want: "x:9 0 x:8080 1 x:1 0 \ny:8080 0 1-2 \n",
+		},
+		{
+			name: "interpret unknown named argument",
+			in: `
+				func f(a, ...rest) {}
+				f(1, rest: 2)
+				`,
+			err: fmt.Errorf("Runtime error: unknown argument 'rest'. [3,6]"),
+		},
+		{
+			name: "interpret duplicated named argument",
+			in: `
+				func f(a, b) {}
+				f(1, b: 2, a: 3)
+				`,
+			err: fmt.Errorf("Runtime error: duplicated argument 'a'. [3,12]"),
+		},
+		{
+			name: "interpret positional argument after named argument",
+			in: `
+				func f(a, b) {}
+				f(b: 2, 1)
+				`,
+			err: fmt.Errorf("Parsing error: positional argument after named argument 'b'. [3,9]"),
+		},
+		{
+			name: "interpret failing default parameter value",
+			in: `
+				func f(a = b.c) {}
+				f()
+				`,
+			err: fmt.Errorf("Resolving error: b is not defined. [2,12]"),
+		},
+		{
+			name: "interpret destructuring declarations",
+			in: `
+				person := {name: "an", age: 30, city: "hn", zip: 1}
+				var {name, age: years, nick = name + "!", ...others} = person
+				echo(name, years, nick, others)
+				[a, [b, c = 3], ...rest] := [1, [2], 4, 5]
+				echo(a, b, c, rest)
+				var [x, y = x * 2], z = [7], 9
+				echo(x, y, z)
+				{
+					var {name} = {}
+					echo(name)
+				}
+				`,
+			want: "an 30 an! {city: hn, zip: 1} \n1 2 3 [4, 5] \n7 14 9 \nundefined \n",
+		},
+		{
+			name: "interpret destructuring assignments",
+			in: `
+				a, b := 1, 2
+				[a, b] = [b, a]
+				echo(a, b)
+				[a, ...b] = reduce([1, 2, 3], func(acc, v) { return append(acc, v * 10) }, [])
+				echo(a, b)
+				`,
+			want: "2 1 \n10 [20, 30] \n",
+		},
+		{
+			name: "interpret destructuring parameters and loops",
+			in: `
+				func area({width, height = width}, [unit] = ["m"]) {
+					return width * height + unit
+				}
+				echo(area({width: 2, height: 3}, ["cm"]), area({width: 4}), area)
+				for [i, j] := [0, 3]; i < j; i++ {
+					echo(i, j)
+				}
+				for i, {name, tags: [first]} in [{name: "a", tags: ["x"]}, {name: "b", tags: []}] {
+					echo(i, name, first)
+				}
+				`,
+			want: "6cm 16m func({width, height}, [unit]) \n0 3 \n1 3 \n2 3 \n0 a x \n1 b undefined \n",
+		},
+		{
+			name: "interpret destructuring of a wrong value",
+			in: `
+				var [a] = {a: 1}
+				`,
+			err: fmt.Errorf("Runtime error: cannot destructure object as an array. [2,5]"),
+		},
+		{
+			name: "interpret destructuring an undefined value",
+			in: `
+				var {a}
+				`,
+			err: fmt.Errorf("Runtime error: cannot destructure undefined as an object. [2,5]"),
+		},
+		{
+			name: "interpret duplicated names in a pattern",
+			in: `
+				func f() {
+					[a, {b: a}] := [1, {b: 2}]
+				}
+				`,
+			err: fmt.Errorf("Resolving error: a is already declared. [3,9]"),
+		},
+		{
+			name: "interpret rest element before another element",
+			in: `
+				var [...a, b] = [1, 2]
+				`,
+			err: fmt.Errorf("Parsing error: rest element must be last. [2,10]"),
+		},
+		{
+			name: "interpret multiple return values",
+			in: `
+				func divide(a, b) {
+					if b == 0 {
+						return null, "division by zero"
+					}
+					return a / b, null
+				}
+				q, err := divide(7, 2)
+				echo(q, err)
+				var r, e = divide(1, 0)
+				echo(r, e)
+				func pass() {
+					return divide(9, 3)
+				}
+				o := {}
+				q, o.err = pass()
+				echo(q, o)
+				a, b := 1, 2
+				a, b = b, a
+				echo(a, b)
+				`,
+			want: "3 null \nnull division by zero \n3 {err: null} \n2 1 \n",
+		},
+		{
+			name: "interpret too many variables for the returned values",
+			in: `
+				func f() {
+					return 1
+				}
+				a, b := f()
+				`,
+			err: fmt.Errorf("Runtime error: assignment mismatch: 2 variables but 1 value. [5,1]"),
+		},
+		{
+			name: "interpret too many returned values for the variables",
+			in: `
+				func f() {
+					return 1, 2, 3
+				}
+				a, b := 0, 0
+				a, b = f()
+				`,
+			err: fmt.Errorf("Runtime error: assignment mismatch: 2 variables but 3 values. [6,1]"),
+		},
+		{
+			name: "interpret multiple values assigned to one variable",
+			in: `
+				func f() {
+					return 1, 2
+				}
+				a := f()
+				`,
+			err: fmt.Errorf("Runtime error: assignment mismatch: 1 variable but 2 values. [5,1]"),
+		},
+		{
+			name: "interpret multiple values among other values",
+			in: `
+				func f() {
+					return 1, 2
+				}
+				a, b, c := f(), 3
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,12]"),
+		},
+		{
+			name: "interpret constants",
+			in: `
+				const limit, unit = 10, "kg"
+				const {name, tags: [first]} = {name: "a", tags: ["x"]}
+				const config = {debug: #f}
+				config.debug = #t
+				func f() {
+					len := func(x) { return 0 }
+					const limit = 1
+					return len([1]) + limit
+				}
+				echo(limit, unit, name, first, config, f(), len([1, 2]))
+				`,
+			want: "10 kg a x {debug: #t} 1 2 \n",
+		},
+		{
+			name: "interpret assignment of a constant",
+			in: `
+				const a = 1
+				func f() {
+					a += 1
+				}
+				`,
+			err: fmt.Errorf("Resolving error: cannot assign to constant a. [4,1]"),
+		},
+		{
+			name: "interpret assignment of a builtin",
+			in: `
+				b := 1
+				[b, len] = [2, 3]
+				`,
+			err: fmt.Errorf("Resolving error: cannot assign to constant len. [3,5]"),
+		},
+		{
+			name: "interpret redeclaration of a builtin",
+			in: `
+				var echo = 1
+				`,
+			err: fmt.Errorf("Resolving error: cannot redeclare constant echo. [2,5]"),
+		},
+		{
+			name: "interpret redeclaration of a constant",
+			in: `
+				func f() {
+					const a = 1
+					a, b := 2, 3
+				}
+				`,
+			err: fmt.Errorf("Resolving error: cannot redeclare constant a. [4,1]"),
+		},
+		{
+			name: "interpret constant without initialization",
+			in: `
+				const a
+				`,
+			err: fmt.Errorf("Parsing error: missing initialization of constant. [2,1]"),
+		},
+		{
+			name: "interpret match statement",
+			in: `
+				func describe(v) {
+					match v {
+						case 1, 2 {
+							return "small"
+						}
+						case {type: "user", name} {
+							return "user " + name
+						}
+						case [x, ...rest] if x > 0 {
+							return "list of " + x + " and " + len(rest)
+						}
+						case [_, _] {
+							return "pair"
+						}
+						case -1, null {
+							return "nothing"
+						}
+						default {
+							return "other"
+						}
+					}
+				}
+				echo(describe(2), describe({type: "user", name: "ann"}), describe([3, 4, 5]))
+				echo(describe([-3, 4]), describe(-1), describe(null), describe("1"), describe({type: "admin"}))
+				`,
+			want: "small user ann list of 3 and 2 \npair nothing nothing other other \n",
+		},
+		{
+			name: "interpret match statement with nested and rest patterns",
+			in: `
+				match {id: 1, tags: ["a", "b"], active: #t} {
+					case {id, tags: [first, ...], ...others} {
+						echo(id, first, others)
+					}
+				}
+				match [[1, 2], 3] {
+					case [[a, b], c] if a + b == c {
+						echo("sum", c)
+					}
+				}
+				`,
+			want: "1 a {active: #t} \nsum 3 \n",
+		},
+		{
+			name: "interpret match statement without matching case",
+			in: `
+				match 3 {
+					case 1 {
+						echo("one")
+					}
+					case 2 {
+						echo("two")
+					}
+				}
+				echo("done")
+				`,
+			want: "done \n",
+		},
+		{
+			name: "interpret match statement in loop",
+			in: `
+				for _, v in [1, 2, 3, 4] {
+					match v {
+						case 2 {
+							continue
+						}
+						case 4 {
+							break
+						}
+					}
+					echo(v)
+				}
+				`,
+			want: "1 \n3 \n",
+		},
+		{
+			name: "interpret match statement on multiple values",
+			in: `
+				func f() {
+					return 1, 2
+				}
+				match f() {
+					case _ {}
+				}
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,7]"),
+		},
+		{
+			name: "interpret appends to the same array",
+			in: `
+				a := append([1, 2, 3], 4)
+				d := append(a, 5)
+				e := append(a, 6)
+				echo(a, d, e)
+				`,
+			want: "[1, 2, 3, 4] [1, 2, 3, 4, 5] [1, 2, 3, 4, 6] \n",
+		},
+		{
+			name: "interpret break in a function called in a loop",
+			in: `
+				func f() {
+					break
+				}
+				for i in [1, 2] {
+					echo(i)
+					f()
+				}
+				`,
+			want: "0 \n",
+			err:  fmt.Errorf("Runtime error: break is not in a loop. [7,1]"),
+		},
+		{
+			name: "interpret continue in a function called in a loop",
+			in: `
+				for i in [1, 2] {
+					g := func() {
+						continue
+					}
+					echo(i)
+					g()
+				}
+				`,
+			want: "0 \n",
+			err:  fmt.Errorf("Runtime error: continue is not in a loop. [7,1]"),
+		},
+		{
+			name: "interpret multiple values in an array",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo([1, two()])
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,10]"),
+		},
+		{
+			name: "interpret multiple values in an object",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo({a: two()})
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,10]"),
+		},
+		{
+			name: "interpret multiple values as an argument",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo(1, two())
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,9]"),
+		},
+		{
+			name: "interpret multiple values as a named argument",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				f := func(a) {
+					return a
+				}
+				f(a: two())
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [8,6]"),
+		},
+		{
+			name: "interpret multiple values as a condition",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				if two() {
+				}
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,4]"),
+		},
+		{
+			name: "interpret multiple values as a loop condition",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				for two() {
+				}
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,5]"),
+		},
+		{
+			name: "interpret multiple values as a conditional test",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo(two() ? 1 : 2)
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,6]"),
+		},
+		{
+			name: "interpret multiple values as a logical operand",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo(#t && two())
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,12]"),
+		},
+		{
+			name: "interpret multiple values as a negated operand",
+			in: `
+				func two() {
+					return 1, 2
+				}
+				echo(!two())
+				`,
+			err: fmt.Errorf("Runtime error: multiple values in single-value context. [5,7]"),
+		},
+		{
+			name: "interpret object pattern assignment",
+			in: `
+				a := 0
+				b := 0
+				{a, b} = {a: 1, b: 2}
+				echo(a, b)
+				{a, c: b = 5} = {a: 3}
+				echo(a, b)
+				{
+					echo("block")
+				}
+				`,
+			want: "1 2 \n3 5 \nblock \n",
+		},
+		{
+			name: "interpret object pattern declaration",
+			in: `
+				func person() {
+					return {name: "an", age: 30}
+				}
+				{name, age: years} := person()
+				echo(name, years)
+				if #t {
+					{a, b} := {a: 1, b: 2}
+					{a, b} = {a: b, b: a}
+					echo(a, b)
+				}
+				{
+					{x, ...rest} := {x: 1, y: 2}
+					echo(x, rest)
+				}
+				`,
+			want: "an 30 \n2 1 \n1 {y: 2} \n",
+		},
+		{
+			name: "interpret keywords as names",
+			in: `
+				opts := {default: 1, as: 2, in: 3, match: 4, case: 5, if: 6, return: 7}
+				opts.default = opts.default + 10
+				echo(opts.default, opts.as, opts.in, opts.match, opts.case, opts?.if, opts["return"])
+				{default: d, if: f} := opts
+				in := d + f
+				match := func(x) {
+					return x * 2
+				}
+				as := [match(in)]
+				for k, v in as {
+					match v {
+						case 34 {
+							echo(k, v)
+						}
+						default {
+							echo("default")
+						}
+					}
+				}
+				`,
+			want: "11 2 3 4 5 6 7 \n0 34 \n",
+		},
+		{
+			name: "interpret decimal integer literals with leading zeros",
+			in: `
+				o := {}
+				o[07] = "a"
+				echo(007, 0_10, o[7], o)
+				`,
+			want: "7 10 a {7: a} \n",
+		},
+		{
+			name: "interpret block variables",
+			in: `
+				total := 0
+				for i := 0; i < 3; i++ {
+					var x = i * 2
+					if x > 0 {
+						var y = x + 1
+						y += 1
+						total += y
+					}
+				}
+				fns := []
+				for k, v in [10, 20] {
+					var w = v + k
+					fns = append(fns, func() {
+						return w
+					})
+				}
+				func f(n) {
+					if n > 0 {
+						var m = n * 10
+						r := f(n - 1)
+						return m + r
+					}
+					return 0
+				}
+				echo(total, fns[0](), fns[1](), f(3))
+				`,
+			want: "10 10 21 60 \n",
+		},
+		{
+			name: "interpret malformed number literal",
+			in: `
+				echo(1)
+				echo(0b12)
+				`,
+			err: fmt.Errorf("Lexing error: invalid digit '2' in binary literal '0b12'. [3,6]"),
+		},
+	}
+	for _, tt := range cases {
+		for _, backend := range backends {
+			t.Run(fmt.Sprintf("%s (%s)", tt.name, backend), func(t *testing.T) {
+				var buf bytes.Buffer
+				err := Interpret(tt.in, config.Config{Writer: &buf, Backend: backend})
+				if tt.err != nil {
+					require.EqualError(t, err, tt.err.Error())
+				} else {
+					require.NoError(t, err)
+				}
+				require.Equal(t, tt.want, buf.String())
+			})
+		}
+	}
+}
+
+func TestInterpretFile(t *testing.T) {
+	cases := []struct {
+		name  string
+		files map[string]string
+		want  string
+		err   error
+	}{
+		{
+			name: "interpret file with import #1",
+			files: map[string]string{
+				"main.covs": `
+					import "lib/math.covs" as m
+					echo(m.add(1, 2), m.pi, m.hidden)
+					`,
+				"lib/math.covs": `
+					import "util.covs" as u
+					export func add(a, b) {
+						return u.id(a) + b
+					}
+					export var pi = 3.14
+					hidden := 1
+					`,
+				"lib/util.covs": `
+					export id := func(a) {
+						return a
+					}
+					`,
+			},
+			want: "3 3.14 undefined \n",
+		},
+		{
+			name: "interpret file with import #2",
+			files: map[string]string{
+				"main.covs": `
+					import "a.covs" as a1
+					import "./a.covs" as a2
+					a1.counter.value = 1
+					echo(a2.counter.value)
+					`,
+				"a.covs": `
+					echo("load a")
+					export counter := {value: 0}
+					`,
+			},
+			want: "load a \n1 \n",
+		},
+		{
+			name: "interpret file with import #3",
+			files: map[string]string{
+				"main.covs": `
+					try {
+						import "lib/x.covs" as x
+					} catch (e) {
+						echo(e.message, e.line, e.charAt)
+					}
+					`,
+				"lib/x.covs": `
+					a := 1
+					b := a / 0
+					`,
+			},
+			want: "cannot divide by zero 3 10 \n",
+		},
+		{
+			name: "interpret file with exported destructuring declarations",
+			files: map[string]string{
+				"main.covs": `
+					import "lib.covs" as e
+					echo(e)
+					`,
+				"lib.covs": `
+					export var [p, [q, ...r]] = [1, [2, 3]]
+					export var {s, t: u} = {s: 4, t: 5}
+					`,
+			},
+			want: "{p: 1, q: 2, r: [3], s: 4, u: 5} \n",
+		},
+		{
+			name: "interpret file with exported constant",
+			files: map[string]string{
+				"main.covs": `
+					import "lib.covs" as e
+					e.v = 2
+					echo(e.v, e.K)
+					e.K += 1
+					`,
+				"lib.covs": `
+					export const K = 3
+					export var v = 1
+					`,
+			},
+			want: "2 3 \n",
+			err:  fmt.Errorf("Runtime error: cannot assign to constant K. [5,1]"),
+		},
+		{
+			name: "interpret file with import cycle",
+			files: map[string]string{
+				"main.covs": `
+					import "a.covs" as a
+					`,
+				"a.covs": `
+					import "b.covs" as b
+					`,
+				"b.covs": `
+					import "a.covs" as a
+					`,
+			},
+			err: fmt.Errorf("b.covs: Runtime error: import cycle detected: a.covs -> b.covs -> a.covs. [2,1]"),
+		},
+		{
+			name: "interpret file with missing module",
+			files: map[string]string{
+				"main.covs": `
+					import "a.covs" as a
+					`,
+			},
+			err: fmt.Errorf("Runtime error: cannot read module a.covs. [2,1]"),
+		},
+		{
+			name: "interpret file with nested export",
+			files: map[string]string{
+				"main.covs": `
+					if #t {
+						export a := 1
+					}
+					`,
+			},
+			err: fmt.Errorf("Resolving error: export is only allowed at the top level of a module. [3,1]"),
+		},
+		{
+			name: "interpret file with export in a function",
+			files: map[string]string{
+				"main.covs": `
+					func f() {
+						{
+							export var a = 1
+						}
+					}
+					`,
+			},
+			err: fmt.Errorf("Resolving error: export is only allowed at the top level of a module. [4,1]"),
+		},
+	}
+	for _, tt := range cases {
+		for _, backend := range backends {
+			t.Run(fmt.Sprintf("%s (%s)", tt.name, backend), func(t *testing.T) {
+				dir, err := ioutil.TempDir("", "covs")
+				require.NoError(t, err)
+				defer os.RemoveAll(dir)
+				for name, content := range tt.files {
+					path := filepath.Join(dir, name)
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+					require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+				}
+				var buf bytes.Buffer
+				err = InterpretFile(filepath.Join(dir, "main.covs"), config.Config{Writer: &buf, Backend: backend})
+				if tt.err != nil {
+					require.EqualError(t, err, tt.err.Error())
+				} else {
+					require.NoError(t, err)
+				}
+				require.Equal(t, tt.want, buf.String())
+			})
+		}
+	}
+}
+
+func TestInterpret_Warnings(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(string(backend), func(t *testing.T) {
+			var buf, warnings bytes.Buffer
+			err := Interpret(`
+				match "blue" {
+					case "red" {
+						echo("red")
+					}
+				}
+				echo("done")
+				`, config.Config{Writer: &buf, Warnings: &warnings, Backend: backend})
+			require.NoError(t, err)
+			require.Equal(t, "done \n", buf.String())
+			require.Equal(t, "Warning: match is not exhaustive, add a default case. [2,1]\n", warnings.String())
+		})
+	}
+}
+
+func TestProgram_Run(t *testing.T) {
+	p, err := Compile(`
+		var counter = {n: 0}
+		func inc() {
+			counter.n = counter.n + 1
+			return counter.n
+		}
+		var list = [1, 2, 3]
+		list[0] = inc()
+		for i, v in list {
+			list[i] = v * 10
+		}
+		echo(inc(), list, counter)
+		`)
+	require.NoError(t, err)
+	for _, backend := range backends {
+		t.Run(string(backend), func(t *testing.T) {
+			var wg sync.WaitGroup
+			outputs := make([]bytes.Buffer, 8)
+			errs := make([]error, len(outputs))
+			for i := range outputs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					errs[i] = p.Run(config.Config{Writer: &outputs[i], Backend: backend})
+				}(i)
+			}
+			wg.Wait()
+			for i := range outputs {
+				require.NoError(t, errs[i])
+				require.Equal(t, "2 [10, 20, 30] {n: 2} \n", outputs[i].String())
+			}
+		})
+	}
+}
+
+func TestProgram_CompileForVM(t *testing.T) {
+	p, err := Compile(`echo(1)`)
+	require.NoError(t, err)
+	require.NoError(t, p.Run(config.Config{Writer: ioutil.Discard, Backend: config.BackendTreeWalker}))
+	require.Nil(t, p.code)
+	require.NoError(t, p.Run(config.Config{Writer: ioutil.Discard, Backend: config.BackendVM}))
+	require.NotNil(t, p.code)
+}
+
+func BenchmarkInterpret_Fib(b *testing.B) {
+	script := `
+		func fib(n) {
+			if n < 2 {
+				return n
+			}
+			return fib(n-1) + fib(n-2)
+		}
+		fib(20)
+		`
+	for _, backend := range backends {
+		b.Run(string(backend), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := Interpret(script, config.Config{Writer: ioutil.Discard, Backend: backend}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestTMP(t *testing.T) {
+	cases := []struct {
+		name   string
+		in     string
+		inEC   *core.ExecutionContext
+		wantEC func() *core.ExecutionContext
+		err    error
+	}{}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			stmts, _ := parser.ToAST(tokens)
+			p, err := newProgram(stmts)
+			if err == nil {
+				err = p.execute(tt.inEC, config.BackendTreeWalker)
+			}
 			require.Equal(t, tt.err, err)
 			if err == nil {
 				require.Equal(t, tt.wantEC(), tt.inEC)
@@ -0,0 +1,121 @@
+package interpreter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/dhl1402/covidscript/internal/config"
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+// modules is shared by every module of a program, it makes sure each file is executed only once
+type modules struct {
+	conf    config.Config
+	root    string
+	cache   map[string]*core.ObjectValue
+	loading []string
+}
+
+// moduleLoader resolves import paths relative to the directory of the importing file
+type moduleLoader struct {
+	*modules
+	dir string
+}
+
+func newModuleLoader(conf config.Config, root string) moduleLoader {
+	return moduleLoader{
+		modules: &modules{
+			conf:  conf,
+			root:  root,
+			cache: map[string]*core.ObjectValue{},
+		},
+		dir: root,
+	}
+}
+
+func (l moduleLoader) Load(path string) (*core.ObjectValue, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(l.dir, path)
+	}
+	path = filepath.Clean(path)
+	if module, ok := l.cache[path]; ok {
+		return module, nil
+	}
+	for i, p := range l.loading {
+		if p == path {
+			chain := []string{}
+			for _, pp := range append(l.loading[i:], path) {
+				chain = append(chain, l.name(pp))
+			}
+			return nil, core.RuntimeError{Message: fmt.Sprintf("import cycle detected: %s", strings.Join(chain, " -> "))}
+		}
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, core.RuntimeError{Message: fmt.Sprintf("cannot read module %s", l.name(path))}
+	}
+	module, err := l.run(string(b), path)
+	if err != nil {
+		return nil, err
+	}
+	l.cache[path] = module
+	return module, nil
+}
+
+func (l moduleLoader) run(script string, path string) (*core.ObjectValue, error) {
+	l.loading = append(l.loading, path)
+	defer func() {
+		l.loading = l.loading[:len(l.loading)-1]
+	}()
+	gec := createGlobalEC(l.conf)
+	gec.Loader = moduleLoader{
+		modules: l.modules,
+		dir:     filepath.Dir(path),
+	}
+	p, err := Compile(script)
+	if err == nil {
+		p.warn(l.conf.Warnings)
+		err = p.execute(gec, l.conf.Backend)
+	}
+	if err != nil {
+		if _, ok := err.(moduleError); !ok && len(l.loading) > 1 {
+			err = moduleError{module: l.name(path), err: err}
+		}
+		return nil, err
+	}
+	module := &core.ObjectValue{
+		Properties: []*core.PropertyValue{},
+	}
+	for _, name := range gec.Exports {
+		v, _ := gec.Get(name)
+		module.Properties = append(module.Properties, &core.PropertyValue{
+			Key:      &core.LiteralValue{Type: core.LiteralTypeString, Value: name},
+			Value:    v,
+			Constant: gec.Constant(name),
+		})
+	}
+	return module, nil
+}
+
+func (l moduleLoader) name(path string) string {
+	if rel, err := filepath.Rel(l.root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// moduleError tells which imported file an error comes from
+type moduleError struct {
+	module string
+	err    error
+}
+
+func (err moduleError) Error() string {
+	return fmt.Sprintf("%s: %s", err.module, err.err.Error())
+}
+
+func (err moduleError) Unwrap() error {
+	return err.err
+}
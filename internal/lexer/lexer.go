@@ -1,141 +1,560 @@
 package lexer
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/dhl1402/covidscript/internal/core"
 	"github.com/dhl1402/covidscript/internal/utils"
 )
 
+var operators = []string{":=", "<=", ">=", "===", "==", "!==", "!=", "&&", "||", "+=", "-=", "*=", "/=", "%=", "++", "--", "**", "<<", ">>>", ">>", "??", "?.", "..."} // order matter
+
+// Lexer reads the tokens of a source one at a time. Columns are counted in runes, the white spaces at the
+// start of a line are not counted.
+type Lexer struct {
+	r        *bufio.Reader
+	line     int
+	charAt   int
+	raw      *strings.Builder // source of the string being read
+	lastLine int              // line where the last token ends
+	doc      []string         // lines of the doc comment read since the last token
+	docLine  int              // line of the last doc comment line
+}
+
+func NewLexer(r io.Reader) *Lexer {
+	return &Lexer{r: bufio.NewReader(r), line: 1, charAt: 1}
+}
+
 // Lex source code into tokens
 func Lex(sc string) ([]Token, error) {
+	l := NewLexer(strings.NewReader(sc))
 	tokens := []Token{}
-	tmp := ""
-	line := 1
-	charAt := 1
-	for len(sc) > 0 {
-		c := string(sc[0])
-		if c == "/" && len(sc) > 1 && string(sc[1]) == "/" {
-			comment, err := lexComment(sc)
-			if err != nil {
-				return nil, err
-			}
-			sc = sc[len(comment):]
-			continue
+	for {
+		t, err := l.Next()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// Next returns the next token, the error is io.EOF when the source is exhausted. A doc comment made of
+// /// lines right above a token is attached to it.
+func (l *Lexer) Next() (Token, error) {
+	t, err := l.next()
+	if err != nil {
+		return t, err
+	}
+	if l.doc != nil && t.Line == l.docLine+1 {
+		t.Doc = strings.Join(l.doc, "\n")
+	}
+	l.doc = nil
+	l.lastLine = l.line
+	return t, nil
+}
+
+func (l *Lexer) next() (Token, error) {
+	if err := l.skip(); err != nil {
+		return Token{}, err
+	}
+	r, err := l.peek()
+	if err != nil {
+		return Token{}, err
+	}
+	line, charAt := l.line, l.charAt
+	if utils.IsStringBoundary(string(r)) {
+		s, _, err := l.lexString()
+		if err != nil {
+			return Token{}, err
 		}
-		if utils.IsStringBoundary(c) {
-			str, err := lexString(sc)
+		return Token{Kind: TokenString, Value: s, Line: line, CharAt: charAt}, nil
+	}
+	if (r >= '0' && r <= '9') || l.startsWithFraction() {
+		n, err := l.number()
+		if err != nil {
+			return Token{}, err
+		}
+		return Token{Kind: TokenNumber, Value: n, Line: line, CharAt: charAt}, nil
+	}
+	if op := l.operator(); op != "" {
+		for range op {
+			l.read()
+		}
+		return Token{Kind: symbolKind(op), Value: op, Line: line, CharAt: charAt}, nil
+	}
+	if utils.IsSpecialChars(string(r)) {
+		l.read()
+		return Token{Kind: symbolKind(string(r)), Value: string(r), Line: line, CharAt: charAt}, nil
+	}
+	word, err := l.word()
+	if err != nil {
+		return Token{}, err
+	}
+	return Token{Kind: wordKind(word), Value: word, Line: line, CharAt: charAt}, nil
+}
+
+// read consumes a rune and moves the position past it
+func (l *Lexer) read() (rune, error) {
+	r, _, err := l.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if l.raw != nil {
+		l.raw.WriteRune(r)
+	}
+	c := string(r)
+	if utils.IsNewLine(c) {
+		l.line++
+		l.charAt = 1
+	} else if !utils.IsWhiteSpace(c) || l.charAt != 1 {
+		l.charAt++
+	}
+	return r, nil
+}
+
+func (l *Lexer) peek() (rune, error) {
+	r, _, err := l.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	return r, l.r.UnreadRune()
+}
+
+func (l *Lexer) startsWith(s string) bool {
+	b, _ := l.r.Peek(len(s))
+	return string(b) == s
+}
+
+// startsWithFraction reports whether the source continues with a dot followed by a digit
+func (l *Lexer) startsWithFraction() bool {
+	b, _ := l.r.Peek(2)
+	return len(b) == 2 && b[0] == '.' && b[1] >= '0' && b[1] <= '9'
+}
+
+// skip consumes white spaces, new lines and comments
+func (l *Lexer) skip() error {
+	for {
+		r, err := l.peek()
+		if err != nil {
+			return err
+		}
+		if l.startsWith("///") {
+			line := l.line
+			text, err := l.lineComment()
 			if err != nil {
-				return nil, err
+				return err
 			}
-			tokens = append(tokens, Token{Value: str, Line: line, CharAt: charAt})
-			sc = sc[len(str):]
-			charAt = charAt + len(str)
-			continue
-		}
-		if op := lexMultipleCharOperator(sc); op != "" {
-			if tmp != "" {
-				tokens = append(tokens, Token{Value: tmp, Line: line, CharAt: charAt - len(tmp)})
-				tmp = ""
-			}
-			tokens = append(tokens, Token{Value: op, Line: line, CharAt: charAt})
-			charAt = charAt + len(op) - 1
-			sc = sc[len(op)-1:]
-		} else if utils.IsReservedKeyword(c) || utils.IsSpecialChars(c) {
-			if tmp != "" {
-				tokens = append(tokens, Token{Value: tmp, Line: line, CharAt: charAt - len(tmp)})
-				tmp = ""
-			}
-			tokens = append(tokens, Token{Value: c, Line: line, CharAt: charAt})
-		} else if utils.IsWhiteSpace(c) || utils.IsNewLine(c) {
-			if tmp != "" {
-				tokens = append(tokens, Token{Value: tmp, Line: line, CharAt: charAt - len(tmp)})
-				tmp = ""
+			if line == l.lastLine {
+				// a doc comment after a token on the same line documents nothing
+				l.doc = nil
+				continue
 			}
+			if l.doc != nil && line != l.docLine+1 {
+				l.doc = nil
+			}
+			l.doc = append(l.doc, strings.TrimPrefix(strings.TrimPrefix(text, "///"), " "))
+			l.docLine = line
+		} else if l.startsWith("//") {
+			if _, err := l.lineComment(); err != nil {
+				return err
+			}
+			l.doc = nil
+		} else if l.startsWith("/*") {
+			if err := l.blockComment(); err != nil {
+				return err
+			}
+			l.doc = nil
+		} else if c := string(r); utils.IsWhiteSpace(c) || utils.IsNewLine(c) {
+			l.read()
 		} else {
-			tmp = tmp + c
-		}
-
-		sc = sc[1:]
-		if utils.IsNewLine(c) {
-			line++
-			charAt = 1
-		} else if !utils.IsWhiteSpace(c) || charAt != 1 {
-			charAt++
-		}
-		if sc == "" && tmp != "" {
-			tokens = append(tokens, Token{Value: tmp, Line: line, CharAt: charAt - len(tmp)})
-		}
-	}
-	result := []Token{}
-	var i int
-	for i = 0; i < len(tokens); i++ {
-		t := tokens[i]
-		var nt *Token
-		if i+1 < len(tokens) {
-			nt = &tokens[i+1]
-		}
-		var lt *Token
-		if len(result) > 0 {
-			lt = &result[len(result)-1]
-		}
-		if t.Value == "." && lt != nil && utils.IsInteger(lt.Value) && nt != nil && utils.IsInteger(nt.Value) {
-			result = result[:len(result)-1]
-			result = append(result, Token{
-				Value:  fmt.Sprintf("%s.%s", lt.Value, nt.Value),
-				Line:   lt.Line,
-				CharAt: lt.CharAt,
-			})
-			i++ // nt is processed
-		} else {
-			result = append(result, t)
+			return nil
+		}
+	}
+}
+
+// lineComment reads a comment until the end of the line
+func (l *Lexer) lineComment() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := l.peek()
+		if err == io.EOF || r == '\n' {
+			return b.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		l.read()
+		b.WriteRune(r)
+	}
+}
+
+// blockComment reads a comment until */
+func (l *Lexer) blockComment() error {
+	line, charAt := l.line, l.charAt
+	l.read()
+	l.read()
+	for !l.startsWith("*/") {
+		if _, err := l.read(); err == io.EOF {
+			return fmt.Errorf("Lexing error: unterminated comment. [%d,%d]", line, charAt)
+		} else if err != nil {
+			return err
 		}
 	}
-	return result, nil
+	l.read()
+	l.read()
+	return nil
 }
 
-func lexMultipleCharOperator(sc string) string {
-	operators := []string{":=", "<=", ">=", "===", "==", "!==", "!=", "&&", "||"} // order matter
+// operator returns the multiple char operator the source continues with
+func (l *Lexer) operator() string {
+	b, _ := l.r.Peek(3)
 	for _, op := range operators {
-		for i, r := range sc {
-			s := string(r)
-			if i >= len(op) {
-				return op
+		if len(b) >= len(op) && string(b[:len(op)]) == op {
+			if op == "?." && len(b) == 3 && b[2] >= '0' && b[2] <= '9' {
+				return "" // a ? .5 : b
 			}
-			if s != string(op[i]) {
+			return op
+		}
+	}
+	return ""
+}
+
+// word reads a name, a number or a keyword
+func (l *Lexer) word() (string, error) {
+	var b strings.Builder
+	for {
+		r, err := l.peek()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if !isAlphanumeric(r) {
+			c := string(r)
+			if utils.IsSpecialChars(c) || utils.IsWhiteSpace(c) || utils.IsNewLine(c) || utils.IsStringBoundary(c) {
+				break
+			}
+			if (r == '&' || r == '|') && l.operator() != "" {
 				break
 			}
 		}
+		l.read()
+		b.WriteRune(r)
+	}
+	return b.String(), nil
+}
+
+// number reads a number literal: an integer in base 10, 16 (0x), 8 (0o) or 2 (0b), or a decimal number with
+// a fraction and an exponent like 1.5e-3 or .5. Digits can be separated by _ like in 1_000.
+func (l *Lexer) number() (string, error) {
+	line, charAt := l.line, l.charAt
+	base := 10
+	for prefix, b := range numberBases {
+		if l.startsWith(prefix) || l.startsWith(strings.ToUpper(prefix)) {
+			base = b
+		}
+	}
+	var b strings.Builder
+	var last rune
+	dot, exponent := false, false
+	for {
+		r, err := l.peek()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if base == 10 && r == '.' && !dot && !exponent && l.startsWithFraction() {
+			dot = true
+		} else if base == 10 && (r == '+' || r == '-') && (last == 'e' || last == 'E') {
+			exponent = true
+		} else if base == 10 && (r == 'e' || r == 'E') {
+			exponent = true
+		} else if !isAlphanumeric(r) && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		l.read()
+		b.WriteRune(r)
+		last = r
+	}
+	n := b.String()
+	if err := checkNumber(n, base); err != nil {
+		return "", fmt.Errorf("Lexing error: %s. [%d,%d]", err.Error(), line, charAt)
+	}
+	return n, nil
+}
+
+var numberBases = map[string]int{"0x": 16, "0o": 8, "0b": 2}
+
+var numberBaseNames = map[int]string{16: "hexadecimal", 8: "octal", 2: "binary"}
+
+// checkNumber reports what is wrong with the number literal n written in base
+func checkNumber(n string, base int) error {
+	suffix := numberSuffix(n, base)
+	body := strings.TrimSuffix(n, suffix)
+	if base != 10 {
+		digits := body[2:]
+		if digits == "" {
+			return fmt.Errorf("%s literal '%s' has no digits", numberBaseNames[base], n)
+		}
+		for _, r := range digits {
+			if r != '_' && !isDigit(r, base) {
+				return fmt.Errorf("invalid digit '%c' in %s literal '%s'", r, numberBaseNames[base], n)
+			}
+		}
+		if err := checkSeparators(n, digits, base); err != nil {
+			return err
+		}
+		if _, err := strconv.ParseInt(strings.ReplaceAll(digits, "_", ""), base, 64); err != nil && suffix == "" {
+			return fmt.Errorf("%s literal '%s' is out of range", numberBaseNames[base], n)
+		}
+		return nil
+	}
+	if suffix == "n" && strings.ContainsAny(body, ".eE") {
+		return fmt.Errorf("bigint literal '%s' must be an integer", n)
+	}
+	if suffix == "d" && strings.ContainsAny(body, "eE") {
+		return fmt.Errorf("decimal literal '%s' cannot have an exponent", n)
+	}
+	mantissa, exponent := body, ""
+	if i := strings.IndexAny(body, "eE"); i >= 0 {
+		mantissa, exponent = body[:i], body[i+1:]
+		if strings.HasPrefix(exponent, "+") || strings.HasPrefix(exponent, "-") {
+			exponent = exponent[1:]
+		}
+		if exponent == "" {
+			return fmt.Errorf("exponent has no digits in number literal '%s'", n)
+		}
+	}
+	if _, err := strconv.ParseInt(strings.ReplaceAll(n, "_", ""), 10, 64); err != nil && !strings.ContainsAny(n, ".eE") && suffix == "" {
+		if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return fmt.Errorf("integer literal '%s' is out of range", n)
+		}
+	}
+	for _, part := range []string{mantissa, exponent} {
+		for _, r := range part {
+			if r != '_' && r != '.' && !isDigit(r, 10) {
+				return fmt.Errorf("invalid character '%c' in number literal '%s'", r, n)
+			}
+		}
+		for _, digits := range strings.Split(part, ".") {
+			if err := checkSeparators(n, digits, 10); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// numberSuffix returns the n of a bigint literal like 12n or the d of a decimal literal like 1.50d
+func numberSuffix(n string, base int) string {
+	if strings.HasSuffix(n, "n") || (base == 10 && strings.HasSuffix(n, "d")) {
+		return n[len(n)-1:]
 	}
 	return ""
 }
 
-func lexString(sc string) (string, error) {
-	result := ""
-	openStrChar := ""
-	for _, s := range sc {
-		c := string(s)
-		if openStrChar == "" && utils.IsStringBoundary(c) {
-			openStrChar = c
-			result = result + c
-		} else if openStrChar != "" {
-			if c == openStrChar {
-				return result + c, nil
+// checkSeparators reports a _ of the number literal n which is not between 2 digits
+func checkSeparators(n string, digits string, base int) error {
+	for i, r := range digits {
+		if r == '_' && (i == 0 || i == len(digits)-1 || !isDigit(rune(digits[i-1]), base) || !isDigit(rune(digits[i+1]), base)) {
+			return fmt.Errorf("'_' must separate successive digits in number literal '%s'", n)
+		}
+	}
+	return nil
+}
+
+func isDigit(r rune, base int) bool {
+	d, err := strconv.ParseUint(string(r), 16, 8)
+	return err == nil && int(d) < base
+}
+
+// lexString reads a string and returns its source. Strings quoted by " or ' may contain escape sequences,
+// strings quoted by ` are raw and may interpolate ${expression}, their parts are returned.
+func (l *Lexer) lexString() (string, []templatePart, error) {
+	outer := l.raw
+	l.raw = &strings.Builder{}
+	defer func() {
+		if outer != nil {
+			outer.WriteString(l.raw.String())
+		}
+		l.raw = outer
+	}()
+	line, charAt := l.line, l.charAt
+	quote, _ := l.read()
+	if quote == '`' {
+		parts, err := l.lexTemplate(line, charAt)
+		return l.raw.String(), parts, err
+	}
+	for {
+		escLine, escCharAt := l.line, l.charAt
+		r, err := l.read()
+		if err == io.EOF {
+			return "", nil, fmt.Errorf("Lexing error: missing closing quote. [%d,%d]", line, charAt)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if r == quote {
+			return l.raw.String(), nil, nil
+		}
+		if r != '\\' {
+			continue
+		}
+		// read the escaped rune, or \u{...} which is at most 10 runes long
+		esc := "\\"
+		for len(esc) == 1 || (strings.HasPrefix(esc, "\\u") && !strings.HasSuffix(esc, "}") && len(esc) < 10) {
+			r, err := l.peek()
+			if err != nil || utils.IsNewLine(string(r)) || (len(esc) > 1 && r == quote) {
+				break
+			}
+			l.read()
+			esc = esc + string(r)
+		}
+		if _, _, err := unescape(esc); err != nil {
+			return "", nil, fmt.Errorf("Lexing error: %s. [%d,%d]", err.Error(), escLine, escCharAt)
+		}
+	}
+}
+
+// templatePart is a raw string or the tokens of an interpolated expression of a template string
+type templatePart struct {
+	str    string
+	tokens []Token
+}
+
+// lexTemplate reads the rest of the template string starting at line, charAt
+func (l *Lexer) lexTemplate(line int, charAt int) ([]templatePart, error) {
+	parts := []templatePart{}
+	var b strings.Builder
+	for {
+		r, err := l.read()
+		if err == io.EOF {
+			return nil, fmt.Errorf("Lexing error: missing closing quote. [%d,%d]", line, charAt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r == '`' {
+			return append(parts, templatePart{str: b.String()}), nil
+		}
+		if r != '$' || !l.startsWith("{") {
+			b.WriteRune(r)
+			continue
+		}
+		l.read()
+		parts = append(parts, templatePart{str: b.String()})
+		b.Reset()
+		tokens := []Token{}
+		depth := 0
+		for {
+			t, err := l.Next()
+			if err == io.EOF {
+				return nil, fmt.Errorf("Lexing error: missing closing quote. [%d,%d]", line, charAt)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if t.Value == "}" && depth == 0 {
+				break
 			}
-			result = result + c
+			if t.Value == "{" {
+				depth++
+			} else if t.Value == "}" {
+				depth--
+			}
+			tokens = append(tokens, t)
 		}
+		parts = append(parts, templatePart{tokens: tokens})
 	}
-	return result, fmt.Errorf("Lexing error: missing closing quote.")
 }
 
-func lexComment(sc string) (string, error) {
-	result := ""
-	c := string(sc[0])
-	for !utils.IsNewLine(c) {
-		result = result + c
-		if len(result) == len(sc) {
-			break
+// unescape decodes the escape sequence at the start of s and returns its length in s
+func unescape(s string) (string, int, error) {
+	if len(s) < 2 {
+		return "", 0, fmt.Errorf("invalid escape sequence '%s'", s)
+	}
+	switch s[1] {
+	case 'n':
+		return "\n", 2, nil
+	case 't':
+		return "\t", 2, nil
+	case 'r':
+		return "\r", 2, nil
+	case '\\', '"', '\'':
+		return string(s[1]), 2, nil
+	case 'u':
+		end := strings.IndexByte(s, '}')
+		if len(s) < 3 || s[2] != '{' || end < 4 || end > 9 {
+			return "", 0, fmt.Errorf("invalid unicode escape sequence")
+		}
+		code, err := strconv.ParseUint(s[3:end], 16, 32)
+		if err != nil || code > unicode.MaxRune || (code >= 0xD800 && code <= 0xDFFF) {
+			return "", 0, fmt.Errorf("invalid unicode escape sequence '%s'", s[:end+1])
+		}
+		return string(rune(code)), end + 1, nil
+	}
+	r, _ := utf8.DecodeRuneInString(s[1:])
+	return "", 0, fmt.Errorf("invalid escape sequence '\\%c'", r)
+}
+
+// LexTemplate returns the raw strings of the template string token t and the tokens of the expressions
+// interpolated between them, there is one more string than expressions
+func LexTemplate(t Token) ([]string, [][]Token, error) {
+	l := NewLexer(strings.NewReader(t.Value))
+	l.line, l.charAt = t.Line, t.CharAt
+	_, parts, err := l.lexString()
+	if err != nil {
+		return nil, nil, err
+	}
+	strs := []string{}
+	exps := [][]Token{}
+	for _, p := range parts {
+		if p.tokens != nil {
+			exps = append(exps, p.tokens)
+		} else {
+			strs = append(strs, p.str)
+		}
+	}
+	return strs, exps, nil
+}
+
+// isAlphanumeric reports whether r is an ASCII letter or digit, which is never a special char
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// symbolKind classifies an operator or a special char
+func symbolKind(s string) TokenKind {
+	if core.IsOperatorSymbol(s) {
+		return TokenOperator
+	}
+	return TokenPunctuation
+}
+
+// wordKind classifies a word read by Lexer.word
+func wordKind(s string) TokenKind {
+	if s == "#t" || s == "#f" {
+		return TokenBoolean
+	}
+	if utils.IsReservedKeyword(s) {
+		return TokenKeyword
+	}
+	for i, r := range s {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && (unicode.IsMark(r) || unicode.IsDigit(r)))) {
+			return TokenIllegal
 		}
-		c = string(sc[len(result)])
 	}
-	return result, nil
+	return TokenIdentifier
 }
@@ -1,7 +1,11 @@
 package lexer
 
 import (
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/require"
 )
@@ -89,6 +93,21 @@ func TestLex_Value(t *testing.T) {
 			}, 3000)`,
 			want: []string{"setTimeout", "(", "func", "(", "a", ",", "b", ")", "{", "console", ".", "log", "(", "a", ",", "b", ")", "}", ",", "3000", ")"},
 		},
+		{
+			name: "lex compound assignment",
+			in: `a+=1
+			b.c-=2
+			d[0]*=e/=f%=3`,
+			want: []string{"a", "+=", "1", "b", ".", "c", "-=", "2", "d", "[", "0", "]", "*=", "e", "/=", "f", "%=", "3"},
+		},
+		{
+			name: "lex increment and decrement",
+			in: `for i:=0;i<3;i++ {
+				a[i]--
+			}
+			i++`,
+			want: []string{"for", "i", ":=", "0", ";", "i", "<", "3", ";", "i", "++", "{", "a", "[", "i", "]", "--", "}", "i", "++"},
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,24 +132,24 @@ func TestLex_Token(t *testing.T) {
 			name: "lex variable declaration",
 			in:   `var abc=123   `,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "abc", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 8},
-				{Value: "123", Line: 1, CharAt: 9},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "abc", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 8},
+				{Kind: TokenNumber, Value: "123", Line: 1, CharAt: 9},
 			},
 		},
 		{
 			name: "lex string variable declaration",
 			in:   `var a,b="123","456"   `,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: ",", Line: 1, CharAt: 6},
-				{Value: "b", Line: 1, CharAt: 7},
-				{Value: "=", Line: 1, CharAt: 8},
-				{Value: `"123"`, Line: 1, CharAt: 9},
-				{Value: ",", Line: 1, CharAt: 14},
-				{Value: `"456"`, Line: 1, CharAt: 15},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: ",", Line: 1, CharAt: 6},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 7},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 8},
+				{Kind: TokenString, Value: `"123"`, Line: 1, CharAt: 9},
+				{Kind: TokenPunctuation, Value: ",", Line: 1, CharAt: 14},
+				{Kind: TokenString, Value: `"456"`, Line: 1, CharAt: 15},
 			},
 		},
 		{
@@ -144,23 +163,23 @@ func TestLex_Token(t *testing.T) {
 				var c = 789
 			`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 7},
-				{Value: "456", Line: 1, CharAt: 9},
-				{Value: "var", Line: 2, CharAt: 1},
-				{Value: "b", Line: 2, CharAt: 5},
-				{Value: "=", Line: 2, CharAt: 7},
-				{Value: `"
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 7},
+				{Kind: TokenNumber, Value: "456", Line: 1, CharAt: 9},
+				{Kind: TokenKeyword, Value: "var", Line: 2, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "b", Line: 2, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 2, CharAt: 7},
+				{Kind: TokenString, Value: `"
 1
 2
 3
 "`,
 					Line: 2, CharAt: 9},
-				{Value: "var", Line: 3, CharAt: 1},
-				{Value: "c", Line: 3, CharAt: 5},
-				{Value: "=", Line: 3, CharAt: 7},
-				{Value: "789", Line: 3, CharAt: 9},
+				{Kind: TokenKeyword, Value: "var", Line: 7, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "c", Line: 7, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 7, CharAt: 7},
+				{Kind: TokenNumber, Value: "789", Line: 7, CharAt: 9},
 			},
 		},
 		{
@@ -169,14 +188,14 @@ func TestLex_Token(t *testing.T) {
 				 var xyz = 456
 			    `,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "abc", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 9},
-				{Value: "123", Line: 1, CharAt: 11},
-				{Value: "var", Line: 2, CharAt: 1},
-				{Value: "xyz", Line: 2, CharAt: 5},
-				{Value: "=", Line: 2, CharAt: 9},
-				{Value: "456", Line: 2, CharAt: 11},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "abc", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 9},
+				{Kind: TokenNumber, Value: "123", Line: 1, CharAt: 11},
+				{Kind: TokenKeyword, Value: "var", Line: 2, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "xyz", Line: 2, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 2, CharAt: 9},
+				{Kind: TokenNumber, Value: "456", Line: 2, CharAt: 11},
 			},
 		},
 		{
@@ -185,27 +204,27 @@ func TestLex_Token(t *testing.T) {
 				console.log(a,b)
 			}, 3000)`,
 			want: []Token{
-				{Value: "setTimeout", Line: 1, CharAt: 1},
-				{Value: "(", Line: 1, CharAt: 11},
-				{Value: "func", Line: 1, CharAt: 12},
-				{Value: "(", Line: 1, CharAt: 16},
-				{Value: "a", Line: 1, CharAt: 17},
-				{Value: ",", Line: 1, CharAt: 18},
-				{Value: "b", Line: 1, CharAt: 19},
-				{Value: ")", Line: 1, CharAt: 20},
-				{Value: "{", Line: 1, CharAt: 21},
-				{Value: "console", Line: 2, CharAt: 1},
-				{Value: ".", Line: 2, CharAt: 8},
-				{Value: "log", Line: 2, CharAt: 9},
-				{Value: "(", Line: 2, CharAt: 12},
-				{Value: "a", Line: 2, CharAt: 13},
-				{Value: ",", Line: 2, CharAt: 14},
-				{Value: "b", Line: 2, CharAt: 15},
-				{Value: ")", Line: 2, CharAt: 16},
-				{Value: "}", Line: 3, CharAt: 1},
-				{Value: ",", Line: 3, CharAt: 2},
-				{Value: "3000", Line: 3, CharAt: 4},
-				{Value: ")", Line: 3, CharAt: 8},
+				{Kind: TokenIdentifier, Value: "setTimeout", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "(", Line: 1, CharAt: 11},
+				{Kind: TokenKeyword, Value: "func", Line: 1, CharAt: 12},
+				{Kind: TokenPunctuation, Value: "(", Line: 1, CharAt: 16},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 17},
+				{Kind: TokenPunctuation, Value: ",", Line: 1, CharAt: 18},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 19},
+				{Kind: TokenPunctuation, Value: ")", Line: 1, CharAt: 20},
+				{Kind: TokenPunctuation, Value: "{", Line: 1, CharAt: 21},
+				{Kind: TokenIdentifier, Value: "console", Line: 2, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ".", Line: 2, CharAt: 8},
+				{Kind: TokenIdentifier, Value: "log", Line: 2, CharAt: 9},
+				{Kind: TokenPunctuation, Value: "(", Line: 2, CharAt: 12},
+				{Kind: TokenIdentifier, Value: "a", Line: 2, CharAt: 13},
+				{Kind: TokenPunctuation, Value: ",", Line: 2, CharAt: 14},
+				{Kind: TokenIdentifier, Value: "b", Line: 2, CharAt: 15},
+				{Kind: TokenPunctuation, Value: ")", Line: 2, CharAt: 16},
+				{Kind: TokenPunctuation, Value: "}", Line: 3, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ",", Line: 3, CharAt: 2},
+				{Kind: TokenNumber, Value: "3000", Line: 3, CharAt: 4},
+				{Kind: TokenPunctuation, Value: ")", Line: 3, CharAt: 8},
 			},
 		},
 		{
@@ -215,18 +234,18 @@ func TestLex_Token(t *testing.T) {
 					c:456
 				}`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 6},
-				{Value: "{", Line: 1, CharAt: 7},
-				{Value: "b", Line: 2, CharAt: 1},
-				{Value: ":", Line: 2, CharAt: 2},
-				{Value: `'123'`, Line: 2, CharAt: 3},
-				{Value: ",", Line: 2, CharAt: 8},
-				{Value: "c", Line: 3, CharAt: 1},
-				{Value: ":", Line: 3, CharAt: 2},
-				{Value: "456", Line: 3, CharAt: 3},
-				{Value: "}", Line: 4, CharAt: 1},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "{", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "b", Line: 2, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":", Line: 2, CharAt: 2},
+				{Kind: TokenString, Value: `'123'`, Line: 2, CharAt: 3},
+				{Kind: TokenPunctuation, Value: ",", Line: 2, CharAt: 8},
+				{Kind: TokenIdentifier, Value: "c", Line: 3, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":", Line: 3, CharAt: 2},
+				{Kind: TokenNumber, Value: "456", Line: 3, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "}", Line: 4, CharAt: 1},
 			},
 		},
 		{
@@ -235,158 +254,281 @@ func TestLex_Token(t *testing.T) {
 			+
 			2`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 6},
-				{Value: "1", Line: 1, CharAt: 7},
-				{Value: "+", Line: 2, CharAt: 1},
-				{Value: "2", Line: 3, CharAt: 1},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 6},
+				{Kind: TokenNumber, Value: "1", Line: 1, CharAt: 7},
+				{Kind: TokenOperator, Value: "+", Line: 2, CharAt: 1},
+				{Kind: TokenNumber, Value: "2", Line: 3, CharAt: 1},
 			},
 		},
 		{
 			name: "lex variable declaration with :=",
 			in:   `a:=b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: ":=", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":=", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator ||",
 			in:   `a||b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "||", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "||", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator &&",
 			in:   `a&&b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "&&", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "&&", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator >=",
 			in:   `a>=b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: ">=", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: ">=", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator <=",
 			in:   `a<=b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "<=", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "<=", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator ==",
 			in:   `a==b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "==", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "==", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator !=",
 			in:   `a!=b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "!=", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "!=", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex multi char operator ===",
 			in:   `a===b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "===", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "===", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 5},
 			},
 		},
 		{
 			name: "lex multi char operator !==",
 			in:   `a!==b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "!==", Line: 1, CharAt: 2},
-				{Value: "b", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "!==", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 5},
 			},
 		},
 		{
 			name: "lex float number #1",
 			in:   `123.123`,
 			want: []Token{
-				{Value: "123.123", Line: 1, CharAt: 1},
+				{Kind: TokenNumber, Value: "123.123", Line: 1, CharAt: 1},
 			},
 		},
 		{
 			name: "lex float number #2",
 			in:   `1.1==1.2`,
 			want: []Token{
-				{Value: "1.1", Line: 1, CharAt: 1},
-				{Value: "==", Line: 1, CharAt: 4},
-				{Value: "1.2", Line: 1, CharAt: 6},
+				{Kind: TokenNumber, Value: "1.1", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "==", Line: 1, CharAt: 4},
+				{Kind: TokenNumber, Value: "1.2", Line: 1, CharAt: 6},
 			},
 		},
 		{
 			name: "lex float number #3",
 			in:   `1.1==1.2.3`,
 			want: []Token{
-				{Value: "1.1", Line: 1, CharAt: 1},
-				{Value: "==", Line: 1, CharAt: 4},
-				{Value: "1.2", Line: 1, CharAt: 6},
-				{Value: ".", Line: 1, CharAt: 9},
-				{Value: "3", Line: 1, CharAt: 10},
+				{Kind: TokenNumber, Value: "1.1", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "==", Line: 1, CharAt: 4},
+				{Kind: TokenNumber, Value: "1.2", Line: 1, CharAt: 6},
+				{Kind: TokenNumber, Value: ".3", Line: 1, CharAt: 9},
+			},
+		},
+		{
+			name: "lex number literals with base prefixes",
+			in:   `0xFF+0b1010-0o755*0XaB`,
+			want: []Token{
+				{Kind: TokenNumber, Value: "0xFF", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "+", Line: 1, CharAt: 5},
+				{Kind: TokenNumber, Value: "0b1010", Line: 1, CharAt: 6},
+				{Kind: TokenOperator, Value: "-", Line: 1, CharAt: 12},
+				{Kind: TokenNumber, Value: "0o755", Line: 1, CharAt: 13},
+				{Kind: TokenOperator, Value: "*", Line: 1, CharAt: 18},
+				{Kind: TokenNumber, Value: "0XaB", Line: 1, CharAt: 19},
+			},
+		},
+		{
+			name: "lex number literals with exponents, fractions and separators",
+			in:   `1_000_000 1e-9-2.5E+3 .5 a.b`,
+			want: []Token{
+				{Kind: TokenNumber, Value: "1_000_000", Line: 1, CharAt: 1},
+				{Kind: TokenNumber, Value: "1e-9", Line: 1, CharAt: 11},
+				{Kind: TokenOperator, Value: "-", Line: 1, CharAt: 15},
+				{Kind: TokenNumber, Value: "2.5E+3", Line: 1, CharAt: 16},
+				{Kind: TokenNumber, Value: ".5", Line: 1, CharAt: 23},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 26},
+				{Kind: TokenPunctuation, Value: ".", Line: 1, CharAt: 27},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 28},
+			},
+		},
+		{
+			name: "lex bigint and decimal literals",
+			in:   `123n+0xFFn 12.30d-5d 0xd`,
+			want: []Token{
+				{Kind: TokenNumber, Value: "123n", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "+", Line: 1, CharAt: 5},
+				{Kind: TokenNumber, Value: "0xFFn", Line: 1, CharAt: 6},
+				{Kind: TokenNumber, Value: "12.30d", Line: 1, CharAt: 12},
+				{Kind: TokenOperator, Value: "-", Line: 1, CharAt: 18},
+				{Kind: TokenNumber, Value: "5d", Line: 1, CharAt: 19},
+				{Kind: TokenNumber, Value: "0xd", Line: 1, CharAt: 22},
+			},
+		},
+		{
+			name: "lex bitwise and shift operators",
+			in:   `a&b|c^~d<<1>>2>>>3&&e`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenOperator, Value: "&", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 3},
+				{Kind: TokenOperator, Value: "|", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "c", Line: 1, CharAt: 5},
+				{Kind: TokenOperator, Value: "^", Line: 1, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "~", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "d", Line: 1, CharAt: 8},
+				{Kind: TokenOperator, Value: "<<", Line: 1, CharAt: 9},
+				{Kind: TokenNumber, Value: "1", Line: 1, CharAt: 11},
+				{Kind: TokenOperator, Value: ">>", Line: 1, CharAt: 12},
+				{Kind: TokenNumber, Value: "2", Line: 1, CharAt: 14},
+				{Kind: TokenOperator, Value: ">>>", Line: 1, CharAt: 15},
+				{Kind: TokenNumber, Value: "3", Line: 1, CharAt: 18},
+				{Kind: TokenOperator, Value: "&&", Line: 1, CharAt: 19},
+				{Kind: TokenIdentifier, Value: "e", Line: 1, CharAt: 21},
+			},
+		},
+		{
+			name: "lex null-coalescing and optional chaining",
+			in:   `a?.b??c?.[0]?.()`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "?.", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenOperator, Value: "??", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "c", Line: 1, CharAt: 7},
+				{Kind: TokenPunctuation, Value: "?.", Line: 1, CharAt: 8},
+				{Kind: TokenPunctuation, Value: "[", Line: 1, CharAt: 10},
+				{Kind: TokenNumber, Value: "0", Line: 1, CharAt: 11},
+				{Kind: TokenPunctuation, Value: "]", Line: 1, CharAt: 12},
+				{Kind: TokenPunctuation, Value: "?.", Line: 1, CharAt: 13},
+				{Kind: TokenPunctuation, Value: "(", Line: 1, CharAt: 15},
+				{Kind: TokenPunctuation, Value: ")", Line: 1, CharAt: 16},
+			},
+		},
+		{
+			name: "lex conditional with a fraction",
+			in:   `a?.5:b`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "?", Line: 1, CharAt: 2},
+				{Kind: TokenNumber, Value: ".5", Line: 1, CharAt: 3},
+				{Kind: TokenPunctuation, Value: ":", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 6},
+			},
+		},
+		{
+			name: "lex spread",
+			in:   `f(a,...b)`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "f", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "(", Line: 1, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 3},
+				{Kind: TokenPunctuation, Value: ",", Line: 1, CharAt: 4},
+				{Kind: TokenPunctuation, Value: "...", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 8},
+				{Kind: TokenPunctuation, Value: ")", Line: 1, CharAt: 9},
+			},
+		},
+		{
+			name: "lex Inf and NaN as identifiers",
+			in:   `Inf NaN`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "Inf", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "NaN", Line: 1, CharAt: 5},
+			},
+		},
+		{
+			name: "lex contextual keywords as identifiers",
+			in:   `match case default as in if`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "match", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "case", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "default", Line: 1, CharAt: 12},
+				{Kind: TokenIdentifier, Value: "as", Line: 1, CharAt: 20},
+				{Kind: TokenIdentifier, Value: "in", Line: 1, CharAt: 23},
+				{Kind: TokenKeyword, Value: "if", Line: 1, CharAt: 26},
 			},
 		},
 		{
 			name: "lex unary token #1",
 			in:   `!a`,
 			want: []Token{
-				{Value: "!", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 2},
+				{Kind: TokenPunctuation, Value: "!", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 2},
 			},
 		},
 		{
 			name: "lex unary token #2",
 			in:   `a !b`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "!", Line: 1, CharAt: 3},
-				{Value: "b", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "!", Line: 1, CharAt: 3},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 4},
 			},
 		},
 		{
 			name: "lex unary token #3",
 			in:   `!a=!b`,
 			want: []Token{
-				{Value: "!", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 2},
-				{Value: "=", Line: 1, CharAt: 3},
-				{Value: "!", Line: 1, CharAt: 4},
-				{Value: "b", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "!", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 2},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "!", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 5},
 			},
 		},
 		{
 			name: "lex unary token #3",
 			in:   `a=2!`,
 			want: []Token{
-				{Value: "a", Line: 1, CharAt: 1},
-				{Value: "=", Line: 1, CharAt: 2},
-				{Value: "2", Line: 1, CharAt: 3},
-				{Value: "!", Line: 1, CharAt: 4},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 2},
+				{Kind: TokenNumber, Value: "2", Line: 1, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "!", Line: 1, CharAt: 4},
 			},
 		},
 		{
@@ -396,14 +538,14 @@ func TestLex_Token(t *testing.T) {
 					c:456
 				}`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 6},
-				{Value: "{", Line: 1, CharAt: 7},
-				{Value: "c", Line: 3, CharAt: 1},
-				{Value: ":", Line: 3, CharAt: 2},
-				{Value: "456", Line: 3, CharAt: 3},
-				{Value: "}", Line: 4, CharAt: 1},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "{", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "c", Line: 3, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":", Line: 3, CharAt: 2},
+				{Kind: TokenNumber, Value: "456", Line: 3, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "}", Line: 4, CharAt: 1},
 			},
 		},
 		{
@@ -413,14 +555,14 @@ func TestLex_Token(t *testing.T) {
 					c:456
 				}`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 6},
-				{Value: "{", Line: 1, CharAt: 7},
-				{Value: "c", Line: 3, CharAt: 1},
-				{Value: ":", Line: 3, CharAt: 2},
-				{Value: "456", Line: 3, CharAt: 3},
-				{Value: "}", Line: 4, CharAt: 1},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "{", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "c", Line: 3, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":", Line: 3, CharAt: 2},
+				{Kind: TokenNumber, Value: "456", Line: 3, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "}", Line: 4, CharAt: 1},
 			},
 		},
 		{
@@ -430,14 +572,14 @@ func TestLex_Token(t *testing.T) {
 					c:45//6
 				}`,
 			want: []Token{
-				{Value: "var", Line: 1, CharAt: 1},
-				{Value: "a", Line: 1, CharAt: 5},
-				{Value: "=", Line: 1, CharAt: 6},
-				{Value: "{", Line: 1, CharAt: 7},
-				{Value: "c", Line: 3, CharAt: 1},
-				{Value: ":", Line: 3, CharAt: 2},
-				{Value: "45", Line: 3, CharAt: 3},
-				{Value: "}", Line: 4, CharAt: 1},
+				{Kind: TokenKeyword, Value: "var", Line: 1, CharAt: 1},
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 5},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "{", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "c", Line: 3, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":", Line: 3, CharAt: 2},
+				{Kind: TokenNumber, Value: "45", Line: 3, CharAt: 3},
+				{Kind: TokenPunctuation, Value: "}", Line: 4, CharAt: 1},
 			},
 		},
 		{
@@ -445,6 +587,111 @@ func TestLex_Token(t *testing.T) {
 			in:   `// abc`,
 			want: []Token{},
 		},
+		{
+			name: "lex block comment",
+			in: `a /* b
+			c */ = /**/ 1 /* // */`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "=", Line: 2, CharAt: 6},
+				{Kind: TokenNumber, Value: "1", Line: 2, CharAt: 13},
+			},
+		},
+		{
+			name: "lex doc comment",
+			in: `/// Add returns
+			///the sum.
+			func add(a, b) {}
+			/// not a doc, there is a blank line
+
+			a := 1 /// not a doc of b
+			b := 2
+			/// not a doc, there is a comment
+			// comment
+			c := 3`,
+			want: []Token{
+				{Kind: TokenKeyword, Value: "func", Line: 3, CharAt: 1, Doc: "Add returns\nthe sum."},
+				{Kind: TokenIdentifier, Value: "add", Line: 3, CharAt: 6},
+				{Kind: TokenPunctuation, Value: "(", Line: 3, CharAt: 9},
+				{Kind: TokenIdentifier, Value: "a", Line: 3, CharAt: 10},
+				{Kind: TokenPunctuation, Value: ",", Line: 3, CharAt: 11},
+				{Kind: TokenIdentifier, Value: "b", Line: 3, CharAt: 13},
+				{Kind: TokenPunctuation, Value: ")", Line: 3, CharAt: 14},
+				{Kind: TokenPunctuation, Value: "{", Line: 3, CharAt: 16},
+				{Kind: TokenPunctuation, Value: "}", Line: 3, CharAt: 17},
+				{Kind: TokenIdentifier, Value: "a", Line: 6, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":=", Line: 6, CharAt: 3},
+				{Kind: TokenNumber, Value: "1", Line: 6, CharAt: 6},
+				{Kind: TokenIdentifier, Value: "b", Line: 7, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":=", Line: 7, CharAt: 3},
+				{Kind: TokenNumber, Value: "2", Line: 7, CharAt: 6},
+				{Kind: TokenIdentifier, Value: "c", Line: 10, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":=", Line: 10, CharAt: 3},
+				{Kind: TokenNumber, Value: "3", Line: 10, CharAt: 6},
+			},
+		},
+		{
+			name: "lex compound assignment",
+			in: `a += 1
+			a++`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "+=", Line: 1, CharAt: 3},
+				{Kind: TokenNumber, Value: "1", Line: 1, CharAt: 6},
+				{Kind: TokenIdentifier, Value: "a", Line: 2, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "++", Line: 2, CharAt: 2},
+			},
+		},
+		{
+			name: "lex power and conditional",
+			in:   `a ? -2**b : 1`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "?", Line: 1, CharAt: 3},
+				{Kind: TokenOperator, Value: "-", Line: 1, CharAt: 5},
+				{Kind: TokenNumber, Value: "2", Line: 1, CharAt: 6},
+				{Kind: TokenOperator, Value: "**", Line: 1, CharAt: 7},
+				{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 9},
+				{Kind: TokenPunctuation, Value: ":", Line: 1, CharAt: 11},
+				{Kind: TokenNumber, Value: "1", Line: 1, CharAt: 13},
+			},
+		},
+		{
+			name: "lex string with escape sequences",
+			in:   `a = "say \"hi\"\n" + 'it\'s'`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 3},
+				{Kind: TokenString, Value: `"say \"hi\"\n"`, Line: 1, CharAt: 5},
+				{Kind: TokenOperator, Value: "+", Line: 1, CharAt: 20},
+				{Kind: TokenString, Value: `'it\'s'`, Line: 1, CharAt: 22},
+			},
+		},
+		{
+			name: "lex template string",
+			in:   "a = `x ${ b + \"}\" } y\n${`c`}`\nd",
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "=", Line: 1, CharAt: 3},
+				{Kind: TokenString, Value: "`x ${ b + \"}\" } y\n${`c`}`", Line: 1, CharAt: 5},
+				{Kind: TokenIdentifier, Value: "d", Line: 3, CharAt: 1},
+			},
+		},
+		{
+			name: "lex unicode identifiers and strings",
+			in: `café := "héllo wörld" + 名前
+			ñ=café`,
+			want: []Token{
+				{Kind: TokenIdentifier, Value: "café", Line: 1, CharAt: 1},
+				{Kind: TokenPunctuation, Value: ":=", Line: 1, CharAt: 6},
+				{Kind: TokenString, Value: `"héllo wörld"`, Line: 1, CharAt: 9},
+				{Kind: TokenOperator, Value: "+", Line: 1, CharAt: 23},
+				{Kind: TokenIdentifier, Value: "名前", Line: 1, CharAt: 25},
+				{Kind: TokenIdentifier, Value: "ñ", Line: 2, CharAt: 1},
+				{Kind: TokenPunctuation, Value: "=", Line: 2, CharAt: 2},
+				{Kind: TokenIdentifier, Value: "café", Line: 2, CharAt: 3},
+			},
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -455,6 +702,246 @@ func TestLex_Token(t *testing.T) {
 	}
 }
 
+func TestLex_Error(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{
+			name: "lex unclosed string",
+			in:   `a = "abc`,
+			err:  fmt.Errorf("Lexing error: missing closing quote. [1,5]"),
+		},
+		{
+			name: "lex invalid escape sequence",
+			in:   `a = "ab\qc"`,
+			err:  fmt.Errorf("Lexing error: invalid escape sequence '\\q'. [1,8]"),
+		},
+		{
+			name: "lex invalid unicode escape sequence",
+			in:   `a = "\u{110000}"`,
+			err:  fmt.Errorf("Lexing error: invalid unicode escape sequence '\\u{110000}'. [1,6]"),
+		},
+		{
+			name: "lex invalid escape sequence after unicode characters",
+			in:   `a = "ĉu\q"`,
+			err:  fmt.Errorf("Lexing error: invalid escape sequence '\\q'. [1,8]"),
+		},
+		{
+			name: "lex invalid escape sequence of a unicode character",
+			in:   `a = "a\é"`,
+			err:  fmt.Errorf("Lexing error: invalid escape sequence '\\é'. [1,7]"),
+		},
+		{
+			name: "lex unterminated block comment",
+			in: `a := 1
+			  b /* c *`,
+			err: fmt.Errorf("Lexing error: unterminated comment. [2,3]"),
+		},
+		{
+			name: "lex unclosed template expression",
+			in:   "a = `${b`",
+			err:  fmt.Errorf("Lexing error: missing closing quote. [1,9]"),
+		},
+		{
+			name: "lex invalid octal digit",
+			in:   `a = 0o78`,
+			err:  fmt.Errorf("Lexing error: invalid digit '8' in octal literal '0o78'. [1,5]"),
+		},
+		{
+			name: "lex invalid binary digit",
+			in:   `a = 0b102`,
+			err:  fmt.Errorf("Lexing error: invalid digit '2' in binary literal '0b102'. [1,5]"),
+		},
+		{
+			name: "lex hexadecimal literal without digits",
+			in:   `a = 0x`,
+			err:  fmt.Errorf("Lexing error: hexadecimal literal '0x' has no digits. [1,5]"),
+		},
+		{
+			name: "lex hexadecimal literal out of range",
+			in:   `a = 0x1_0000_0000_0000_0000`,
+			err:  fmt.Errorf("Lexing error: hexadecimal literal '0x1_0000_0000_0000_0000' is out of range. [1,5]"),
+		},
+		{
+			name: "lex hexadecimal literal over 63 bits",
+			in:   `a = 0x8000_0000_0000_0000`,
+			err:  fmt.Errorf("Lexing error: hexadecimal literal '0x8000_0000_0000_0000' is out of range. [1,5]"),
+		},
+		{
+			name: "lex integer literal out of range",
+			in:   `a = 9_223_372_036_854_775_808`,
+			err:  fmt.Errorf("Lexing error: integer literal '9_223_372_036_854_775_808' is out of range. [1,5]"),
+		},
+		{
+			name: "lex bigint literal with a fraction",
+			in:   `a = 1.5n`,
+			err:  fmt.Errorf("Lexing error: bigint literal '1.5n' must be an integer. [1,5]"),
+		},
+		{
+			name: "lex decimal literal with an exponent",
+			in:   `a = 1e3d`,
+			err:  fmt.Errorf("Lexing error: decimal literal '1e3d' cannot have an exponent. [1,5]"),
+		},
+		{
+			name: "lex decimal suffix on a binary literal",
+			in:   `a = 0b1d`,
+			err:  fmt.Errorf("Lexing error: invalid digit 'd' in binary literal '0b1d'. [1,5]"),
+		},
+		{
+			name: "lex trailing separator",
+			in:   `a = 1_000_`,
+			err:  fmt.Errorf("Lexing error: '_' must separate successive digits in number literal '1_000_'. [1,5]"),
+		},
+		{
+			name: "lex successive separators",
+			in:   `a = 1__000`,
+			err:  fmt.Errorf("Lexing error: '_' must separate successive digits in number literal '1__000'. [1,5]"),
+		},
+		{
+			name: "lex separator next to the dot",
+			in:   `a = 1_.5`,
+			err:  fmt.Errorf("Lexing error: '_' must separate successive digits in number literal '1_.5'. [1,5]"),
+		},
+		{
+			name: "lex separator after the base prefix",
+			in:   `a = 0x_FF`,
+			err:  fmt.Errorf("Lexing error: '_' must separate successive digits in number literal '0x_FF'. [1,5]"),
+		},
+		{
+			name: "lex exponent without digits",
+			in:   `a = 1e+`,
+			err:  fmt.Errorf("Lexing error: exponent has no digits in number literal '1e+'. [1,5]"),
+		},
+		{
+			name: "lex invalid character in number",
+			in:   `a = 12ab`,
+			err:  fmt.Errorf("Lexing error: invalid character 'a' in number literal '12ab'. [1,5]"),
+		},
+		{
+			name: "lex invalid character in exponent",
+			in:   `a = 1e5x`,
+			err:  fmt.Errorf("Lexing error: invalid character 'x' in number literal '1e5x'. [1,5]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Lex(tt.in)
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestLexTemplate(t *testing.T) {
+	tokens, err := Lex("a := `x ${b}\n  ${ c.d }`")
+	require.Equal(t, err, nil)
+	strs, exps, err := LexTemplate(tokens[2])
+	require.Equal(t, err, nil)
+	require.Equal(t, []string{"x ", "\n  ", ""}, strs)
+	require.Equal(t, [][]Token{
+		{{Kind: TokenIdentifier, Value: "b", Line: 1, CharAt: 11}},
+		{
+			{Kind: TokenIdentifier, Value: "c", Line: 2, CharAt: 4},
+			{Kind: TokenPunctuation, Value: ".", Line: 2, CharAt: 5},
+			{Kind: TokenIdentifier, Value: "d", Line: 2, CharAt: 6},
+		},
+	}, exps)
+}
+
+func TestToken_Unquote(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: `"a\tb\\c\"d"`, want: "a\tb\\c\"d"},
+		{in: `'\u{1F600} \u{e9}'`, want: "\U0001F600 é"},
+		{in: "`a\\n\nb`", want: "a\\n\nb"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, Token{Value: tt.in}.Unquote())
+		})
+	}
+}
+
+func TestLexer_Next(t *testing.T) {
+	r, w := io.Pipe()
+	more := make(chan bool)
+	go func() {
+		w.Write([]byte("a := 1 + 2\n   "))
+		<-more
+		w.Write([]byte("b"))
+		w.Close()
+	}()
+	l := NewLexer(r)
+	// tokens are read before the rest of the source is written
+	for _, want := range []Token{
+		{Kind: TokenIdentifier, Value: "a", Line: 1, CharAt: 1},
+		{Kind: TokenPunctuation, Value: ":=", Line: 1, CharAt: 3},
+		{Kind: TokenNumber, Value: "1", Line: 1, CharAt: 6},
+		{Kind: TokenOperator, Value: "+", Line: 1, CharAt: 8},
+	} {
+		tok, err := l.Next()
+		require.Equal(t, err, nil)
+		require.Equal(t, want, tok)
+	}
+	more <- true
+	for _, want := range []Token{
+		{Kind: TokenNumber, Value: "2", Line: 1, CharAt: 10},
+		{Kind: TokenIdentifier, Value: "b", Line: 2, CharAt: 1},
+	} {
+		tok, err := l.Next()
+		require.Equal(t, err, nil)
+		require.Equal(t, want, tok)
+	}
+	_, err := l.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestLexer_OneByteReader(t *testing.T) {
+	in := "s := `${a + \"ü\"}` // ok\nb[0] = 1.5 && 2"
+	want, err := Lex(in)
+	require.Equal(t, err, nil)
+	l := NewLexer(iotest.OneByteReader(strings.NewReader(in)))
+	tokens := []Token{}
+	for {
+		tok, err := l.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Equal(t, err, nil)
+		tokens = append(tokens, tok)
+	}
+	require.Equal(t, want, tokens)
+}
+
+// BenchmarkLex lexes scripts of growing size, the time per byte stays the same as lexing is linear
+func BenchmarkLex(b *testing.B) {
+	chunk := `
+		// sum the squares
+		func sumSquares(arr) {
+			total := 0
+			for i, v in arr {
+				total += v ** 2
+			}
+			return total
+		}
+		echo(` + "`${sumSquares([1, 2.5, 3])} café`" + `, "a\tb" != 'c' && #t)
+		`
+	for _, n := range []int{10, 100, 1000, 10000} {
+		script := strings.Repeat(chunk, n)
+		b.Run(fmt.Sprintf("%d lines", strings.Count(script, "\n")), func(b *testing.B) {
+			b.SetBytes(int64(len(script)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Lex(script); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestLex_TMP(t *testing.T) {
 	cases := []struct {
 		name string
@@ -1,46 +1,118 @@
 package lexer
 
 import (
-	"regexp"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/dhl1402/covidscript/internal/core"
-	"github.com/dhl1402/covidscript/internal/utils"
+)
+
+// TokenKind classifies a token, it is assigned once by the lexer
+type TokenKind int
+
+const (
+	TokenIllegal     TokenKind = iota // a word which is neither a keyword, a literal nor a name, like 1a
+	TokenIdentifier                   // a variable or property name
+	TokenKeyword                      // a reserved keyword, null and undefined included
+	TokenNumber                       // a number literal
+	TokenString                       // a string literal with its quotes
+	TokenBoolean                      // #t or #f
+	TokenOperator                     // a binary operator
+	TokenPunctuation                  // any other special char or multiple char operator, like { or :=
 )
 
 type Token struct {
+	Kind   TokenKind
 	Value  string
 	Line   int
 	CharAt int
+	Doc    string // doc comment right above the token, without ///
 }
 
 func (t Token) IsIdentifier() bool {
-	s := t.Value
-	if match, err := regexp.MatchString("^[a-zA-Z_$][a-zA-Z_$0-9]*$", s); !match || err != nil {
-		return false
-	}
-	return s != "" && !t.IsOperatorSymbol() && !utils.IsReservedKeyword(s) && !utils.IsSpecialChars(s) && !utils.IsStringBoundary(s) && !utils.IsWhiteSpace(s) && !utils.IsNewLine(s)
+	return t.Kind == TokenIdentifier
+}
+
+// IsName reports whether t can name a property after . or in an object literal, keywords included
+func (t Token) IsName() bool {
+	return t.Kind == TokenIdentifier || t.Kind == TokenKeyword
 }
 
 func (t Token) IsNumber() bool {
-	_, err := strconv.Atoi(t.Value)
-	if err == nil {
-		return true
-	}
-	_, err = strconv.ParseFloat(t.Value, 64)
-	return err == nil
+	return t.Kind == TokenNumber
 }
 
 func (t Token) IsString() bool {
-	return t.Value != "" && utils.IsStringBoundary(string(t.Value[0]))
+	return t.Kind == TokenString
+}
+
+// IsTemplate reports whether t is a string quoted by ` which interpolates expressions
+func (t Token) IsTemplate() bool {
+	return t.Kind == TokenString && t.Value[0] == '`' && strings.Contains(t.Value, "${")
+}
+
+// Unquote returns the value of the string token t, escape sequences are decoded except in raw strings
+func (t Token) Unquote() string {
+	s := t.Value[1 : len(t.Value)-1]
+	if t.Value[0] == '`' || !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		r, n, _ := unescape(s[i:]) // escape sequences are checked by Lex
+		b.WriteString(r)
+		i = i + n - 1
+	}
+	return b.String()
+}
+
+// NumberValue returns the value of the number token t in decimal, without the base prefix, the bigint or
+// decimal suffix nor the _ separators. Floats are written in their shortest form.
+func (t Token) NumberValue() string {
+	n := strings.ReplaceAll(t.Value, "_", "")
+	base := t.numberBase()
+	switch numberSuffix(n, base) {
+	case "n":
+		if base != 10 {
+			n = n[2:]
+		}
+		i, _ := new(big.Int).SetString(strings.TrimSuffix(n, "n"), base)
+		return i.String()
+	case "d":
+		d, _ := core.ParseDecimal(strings.TrimSuffix(n, "d"))
+		return d.String()
+	}
+	if strings.ContainsAny(n, ".eE") && base == 10 {
+		v, _ := strconv.ParseFloat(n, 64)
+		return core.FormatFloat(v)
+	}
+	if base != 10 {
+		n = n[2:]
+	}
+	v, _ := strconv.ParseInt(n, base, 64) // range is checked by Lex
+	return strconv.FormatInt(v, 10)
+}
+
+func (t Token) numberBase() int {
+	if len(t.Value) > 2 {
+		if base, ok := numberBases[strings.ToLower(t.Value[:2])]; ok {
+			return base
+		}
+	}
+	return 10
 }
 
 func (t Token) IsBoolean() bool {
-	return t.Value == "#f" || t.Value == "#t"
+	return t.Kind == TokenBoolean
 }
 
 func (t Token) IsOperatorSymbol() bool {
-	return core.IsOperatorSymbol(t.Value)
+	return t.Kind == TokenOperator
 }
 
 func (t Token) IsPrimitiveValue() bool {
@@ -58,6 +130,12 @@ func (t Token) ParsePrimitiveType() (core.PrimitiveType, bool) {
 		return "boolean", true
 	}
 	if t.IsNumber() {
+		switch numberSuffix(t.Value, t.numberBase()) {
+		case "n":
+			return core.LiteralTypeBigInt, true
+		case "d":
+			return core.LiteralTypeDecimal, true
+		}
 		return "number", true
 	}
 	if t.IsString() {
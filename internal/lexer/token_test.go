@@ -0,0 +1,75 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken_Kind(t *testing.T) {
+	cases := []struct {
+		in   string
+		want TokenKind
+	}{
+		{in: "abc", want: TokenIdentifier},
+		{in: "_a1$", want: TokenIdentifier},
+		{in: "café", want: TokenIdentifier},
+		{in: "名前", want: TokenIdentifier},
+		{in: "ñ2", want: TokenIdentifier},
+		{in: "٣a", want: TokenIllegal},
+		{in: "a#", want: TokenIllegal},
+		{in: "func", want: TokenKeyword},
+		{in: "null", want: TokenKeyword},
+		{in: "#t", want: TokenBoolean},
+		{in: "1.5", want: TokenNumber},
+		{in: "0xFF", want: TokenNumber},
+		{in: "Inf", want: TokenIdentifier},
+		{in: "'a'", want: TokenString},
+		{in: "**", want: TokenOperator},
+		{in: "&&", want: TokenOperator},
+		{in: "!", want: TokenPunctuation},
+		{in: "+=", want: TokenPunctuation},
+	}
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			tokens, err := Lex(tt.in)
+			require.Equal(t, err, nil)
+			require.Equal(t, 1, len(tokens))
+			require.Equal(t, tt.want, tokens[0].Kind)
+		})
+	}
+}
+
+func TestToken_NumberValue(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: "123", want: "123"},
+		{in: "1.50", want: "1.5"},
+		{in: "2.0", want: "2.0"},
+		{in: "1_000_000", want: "1000000"},
+		{in: "0xFF", want: "255"},
+		{in: "0XfF", want: "255"},
+		{in: "0b1010", want: "10"},
+		{in: "0o755", want: "493"},
+		{in: "0x7FFF_FFFF_FFFF_FFFF", want: "9223372036854775807"},
+		{in: "1e-9", want: "1e-09"},
+		{in: "2.5E+3", want: "2500.0"},
+		{in: ".5", want: "0.5"},
+		{in: "123_456_789_012_345_678_901n", want: "123456789012345678901"},
+		{in: "0xFFn", want: "255"},
+		{in: "12.30d", want: "12.30"},
+		{in: ".5d", want: "0.5"},
+		{in: "007d", want: "7"},
+		{in: "0xd", want: "13"},
+		{in: "007", want: "7"},
+		{in: "0", want: "0"},
+		{in: "1_000", want: "1000"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, Token{Kind: TokenNumber, Value: tt.in}.NumberValue())
+		})
+	}
+}
@@ -18,7 +18,7 @@ func parseStatements(tokens []lexer.Token) ([]core.Statement, int, error) {
 	for i = 0; i < len(tokens); i++ {
 		t := tokens[i]
 		switch {
-		case t.Value == "var":
+		case t.Value == "var" || t.Value == "const":
 			s, processed, err := parseVariableDeclaration(tokens[i:])
 			if err != nil {
 				return nil, 0, err
@@ -43,6 +43,20 @@ func parseStatements(tokens []lexer.Token) ([]core.Statement, int, error) {
 			ss = append(ss, core.BreakStatement{Line: t.Line, CharAt: t.CharAt})
 		case t.Value == "continue":
 			ss = append(ss, core.ContinueStatement{Line: t.Line, CharAt: t.CharAt})
+		case t.Value == "{" && isPatternFollowedBy(tokens[i:], ":="):
+			s, processed, err := parseShorthandVariableDeclaration(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
+		case t.Value == "{" && isPatternFollowedBy(tokens[i:], "="):
+			s, processed, err := parseAssignmentStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
 		case t.Value == "{":
 			s, processed, err := parseBlockStatement(tokens[i:])
 			if err != nil {
@@ -59,13 +73,55 @@ func parseStatements(tokens []lexer.Token) ([]core.Statement, int, error) {
 			}
 			ss = append(ss, *s)
 			i = i + processed - 1
+		case t.Value == "match" && isMatchStatement(tokens[i:]):
+			s, processed, err := parseMatchStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
 		case t.Value == "for":
+			if s, processed, err := parseForInStatement(tokens[i:]); err == nil {
+				ss = append(ss, *s)
+				i = i + processed - 1
+				continue
+			} else if s != nil {
+				return nil, 0, err
+			}
 			s, processed, err := parseForStatement(tokens[i:])
 			if err != nil {
 				return nil, 0, err
 			}
 			ss = append(ss, *s)
 			i = i + processed - 1
+		case t.Value == "try":
+			s, processed, err := parseTryStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
+		case t.Value == "throw":
+			s, processed, err := parseThrowStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
+		case t.Value == "import":
+			s, processed, err := parseImportStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
+		case t.Value == "export":
+			s, processed, err := parseExportStatement(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ss = append(ss, *s)
+			i = i + processed - 1
 		case t.Value == ";":
 			continue
 		default:
@@ -115,16 +171,32 @@ func parseAssignmentStatement(tokens []lexer.Token) (*core.AssignmentStatement,
 	if len(tokens) == 0 {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse assignment statement")
 	}
+	if p, i, err := parsePattern(tokens); err == nil && i < len(tokens) && tokens[i].Value == "=" {
+		rightExp, processed, err := parseExpression(tokens[i+1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		return &core.AssignmentStatement{
+			Left:   p,
+			Right:  rightExp,
+			Line:   tokens[0].Line,
+			CharAt: tokens[0].CharAt,
+		}, i + processed + 1, nil
+	}
 	exp, i, err := parseExpression(tokens[0:])
 	if err != nil {
 		return nil, 0, err
 	}
+	if i < len(tokens) && tokens[i].Value == "," {
+		return parseTupleAssignment(tokens)
+	}
 	if i >= len(tokens) {
 		lastToken := tokens[len(tokens)-1]
 		return nil, 0, fmt.Errorf("Parsing error: unexpected end of statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
 	}
-	if tokens[i].Value != "=" {
-		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '='. [%d,%d]", tokens[i].Value, tokens[i].Line, tokens[i].CharAt)
+	t := tokens[i]
+	if !isAssignmentOperator(t.Value) {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '='. [%d,%d]", t.Value, t.Line, t.CharAt)
 	}
 	switch exp.(type) {
 	case *core.VariableExpression:
@@ -137,7 +209,23 @@ func parseAssignmentStatement(tokens []lexer.Token) (*core.AssignmentStatement,
 		Line:   tokens[0].Line,
 		CharAt: tokens[0].CharAt,
 	}
-	i++ // handle '=' -> +1
+	if t.Value != "=" {
+		as.Operator = &core.Operator{
+			Symbol: t.Value,
+			Line:   t.Line,
+			CharAt: t.CharAt,
+		}
+	}
+	i++ // handle operator -> +1
+	if t.Value == "++" || t.Value == "--" {
+		as.Right = &core.LiteralExpression{
+			Type:   core.LiteralTypeNumber,
+			Value:  "1",
+			Line:   t.Line,
+			CharAt: t.CharAt,
+		}
+		return as, i, nil
+	}
 	rightExp, processed, err := parseExpression(tokens[i:])
 	if err != nil {
 		return nil, 0, err
@@ -146,6 +234,87 @@ func parseAssignmentStatement(tokens []lexer.Token) (*core.AssignmentStatement,
 	return as, i + processed, nil
 }
 
+// parseTupleAssignment parses the assignment of several variables or properties like a, b = f() or a, b = b, a
+func parseTupleAssignment(tokens []lexer.Token) (*core.AssignmentStatement, int, error) {
+	exps, i, err := parseSequentExpressions(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	if t := tokens[i]; t.Value != "=" {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '='. [%d,%d]", t.Value, t.Line, t.CharAt)
+	}
+	for _, exp := range exps {
+		switch exp.(type) {
+		case *core.VariableExpression:
+		case *core.MemberAccessExpression:
+		default:
+			return nil, 0, fmt.Errorf("Parsing error: %s cannot be the left side of assignment statement. [%d,%d]", exp.GetType(), exp.GetLine(), exp.GetCharAt())
+		}
+	}
+	rights, processed, err := parseSequentExpressions(tokens[i+1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rights) == 0 {
+		t := tokens[i]
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse assignment. [%d,%d]", t.Line, t.CharAt)
+	}
+	as := &core.AssignmentStatement{
+		Left: &core.TupleExpression{
+			Elements: exps,
+			Line:     exps[0].GetLine(),
+			CharAt:   exps[0].GetCharAt(),
+		},
+		Right:  rights[0],
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}
+	if len(rights) > 1 {
+		as.Right = &core.TupleExpression{
+			Elements: rights,
+			Line:     rights[0].GetLine(),
+			CharAt:   rights[0].GetCharAt(),
+		}
+	}
+	return as, i + processed + 1, nil
+}
+
+// isMatchStatement reports whether tokens starting with match are a match statement. match is not reserved,
+// it is a name when it is assigned, declared or used like match(x) or match.x.
+func isMatchStatement(tokens []lexer.Token) bool {
+	if len(tokens) < 2 {
+		return false
+	}
+	switch nt := tokens[1].Value; {
+	case nt == ":=" || nt == "," || nt == "." || nt == "?." || isAssignmentOperator(nt):
+		return false
+	case nt == "(" || nt == "[":
+		_, _, err := parseMatchStatement(tokens)
+		return err == nil
+	}
+	return true
+}
+
+// isPatternFollowedBy reports whether tokens start with a destructuring pattern followed by op. Statements
+// like {a, b} = obj and {a, b} := obj are an assignment and a declaration, not blocks, they are written
+// without parentheses.
+func isPatternFollowedBy(tokens []lexer.Token, op string) bool {
+	_, i, err := parsePattern(tokens)
+	return err == nil && i < len(tokens) && tokens[i].Value == op
+}
+
+func isAssignmentOperator(s string) bool {
+	switch s {
+	case "=", "+=", "-=", "*=", "/=", "%=", "++", "--":
+		return true
+	}
+	return false
+}
+
 func parseExpressionStatement(tokens []lexer.Token) (*core.ExpressionStatement, int, error) {
 	if len(tokens) == 0 {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse expression statement")
@@ -178,6 +347,7 @@ func parseFunctionDeclaration(tokens []lexer.Token) (*core.FunctionDeclaration,
 			Line:   tokens[1].Line,
 			CharAt: tokens[1].CharAt,
 		},
+		Doc:    tokens[0].Doc,
 		Line:   tokens[0].Line,
 		CharAt: tokens[0].CharAt,
 	}
@@ -198,9 +368,15 @@ func parseReturnStatement(tokens []lexer.Token) (*core.ReturnStatement, int, err
 		Line:   tokens[0].Line,
 		CharAt: tokens[0].CharAt,
 	}
-	exp, i, _ := parseExpression(tokens[1:]) // skip 'return'
-	if exp != nil {
-		r.Argument = exp
+	exps, i, _ := parseSequentExpressions(tokens[1:]) // skip 'return'
+	if len(exps) == 1 {
+		r.Argument = exps[0]
+	} else if len(exps) > 1 {
+		r.Argument = &core.TupleExpression{
+			Elements: exps,
+			Line:     exps[0].GetLine(),
+			CharAt:   exps[0].GetCharAt(),
+		}
 	}
 	return r, i + 1, nil
 }
@@ -209,7 +385,7 @@ func parseVariableDeclaration(tokens []lexer.Token) (*core.VariableDeclaration,
 	if len(tokens) == 0 {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse variable declaration")
 	}
-	ids, i, err := parseSequentIdentifiers(tokens[1:]) // tokens[1:] -> skip 'var'
+	ids, i, err := parseSequentIdentifiers(tokens[1:]) // tokens[1:] -> skip 'var' or 'const'
 	if err != nil {
 		return nil, 0, err
 	}
@@ -217,6 +393,8 @@ func parseVariableDeclaration(tokens []lexer.Token) (*core.VariableDeclaration,
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse variable names. [%d,%d]", tokens[0].Line, tokens[0].CharAt)
 	}
 	s := &core.VariableDeclaration{
+		Const:  tokens[0].Value == "const",
+		Doc:    tokens[0].Doc,
 		Line:   tokens[0].Line,
 		CharAt: tokens[0].CharAt,
 	}
@@ -229,6 +407,9 @@ func parseVariableDeclaration(tokens []lexer.Token) (*core.VariableDeclaration,
 		})
 	}
 	if i+1 >= len(tokens) || tokens[i+1].Value != "=" {
+		if s.Const {
+			return nil, 0, fmt.Errorf("Parsing error: missing initialization of constant. [%d,%d]", tokens[0].Line, tokens[0].CharAt)
+		}
 		return s, i + 1, nil
 	}
 	// start parsing variable initialization
@@ -260,6 +441,7 @@ func parseShorthandVariableDeclaration(tokens []lexer.Token) (*core.VariableDecl
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse variable names. [%d,%d]", tokens[0].Line, tokens[0].CharAt)
 	}
 	s := &core.VariableDeclaration{
+		Doc:    tokens[0].Doc,
 		Line:   tokens[0].Line,
 		CharAt: tokens[0].CharAt,
 	}
@@ -416,278 +598,639 @@ func parseForStatement(tokens []lexer.Token) (*core.ForStatement, int, error) {
 	return forstmt, i, nil
 }
 
-func parseExpression(tokens []lexer.Token) (core.Expression, int, error) {
-	if len(tokens) == 0 {
-		return nil, 0, fmt.Errorf("Parsing error: cannot parse expression")
+func parseTryStatement(tokens []lexer.Token) (*core.TryStatement, int, error) {
+	if len(tokens) < 3 { // 3 is len of the most simple try
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse try statement")
 	}
-	var tmpExp core.Expression
-	var bexp *core.BinaryExpression
-	var lastBexp *core.BinaryExpression
-	var maexp *core.MemberAccessExpression
-	bexpsAfterGroup := []*core.BinaryExpression{}
-	openParen := 0
-	unaryQueue := [][]lexer.Token{{}} // by paren level
-	var i int
-	for i = 0; i < len(tokens); i++ {
+	trystmt := &core.TryStatement{
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}
+	i := 1 // skip 'try'
+	bstmt, processed, err := parseBlockStatement(tokens[i:])
+	if err != nil {
+		return nil, 0, err
+	}
+	trystmt.Block = *bstmt
+	i = i + processed
+	if i < len(tokens) && tokens[i].Value == "catch" {
+		i++ // skip 'catch'
+		if i < len(tokens) && tokens[i].Value == "(" {
+			if i+2 >= len(tokens) || !tokens[i+1].IsIdentifier() {
+				return nil, 0, fmt.Errorf("Parsing error: cannot parse catch parameter. [%d,%d]", tokens[i].Line, tokens[i].CharAt)
+			}
+			if tokens[i+2].Value != ")" {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected ')'. [%d,%d]", tokens[i+2].Value, tokens[i+2].Line, tokens[i+2].CharAt)
+			}
+			trystmt.Param = &core.Identifier{
+				Name:   tokens[i+1].Value,
+				Line:   tokens[i+1].Line,
+				CharAt: tokens[i+1].CharAt,
+			}
+			i = i + 3
+		}
+		bstmt, processed, err := parseBlockStatement(tokens[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		trystmt.Handler = bstmt
+		i = i + processed
+	}
+	if i < len(tokens) && tokens[i].Value == "finally" {
+		bstmt, processed, err := parseBlockStatement(tokens[i+1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		trystmt.Finalizer = bstmt
+		i = i + processed + 1
+	}
+	if trystmt.Handler == nil && trystmt.Finalizer == nil {
+		lastToken := tokens[i-1]
+		return nil, 0, fmt.Errorf("Parsing error: missing catch or finally after try. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	return trystmt, i, nil
+}
+
+func parseThrowStatement(tokens []lexer.Token) (*core.ThrowStatement, int, error) {
+	if len(tokens) < 2 {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse throw statement. [%d,%d]", tokens[0].Line, tokens[0].CharAt)
+	}
+	exp, i, err := parseExpression(tokens[1:]) // skip 'throw'
+	if err != nil || exp == nil {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse throw argument. [%d,%d]", tokens[1].Line, tokens[1].CharAt)
+	}
+	return &core.ThrowStatement{
+		Argument: exp,
+		Line:     tokens[0].Line,
+		CharAt:   tokens[0].CharAt,
+	}, i + 1, nil
+}
+
+func parseImportStatement(tokens []lexer.Token) (*core.ImportStatement, int, error) {
+	if len(tokens) < 4 { // import "a" as a
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse import statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	if !tokens[1].IsString() {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected module path. [%d,%d]", tokens[1].Value, tokens[1].Line, tokens[1].CharAt)
+	}
+	if tokens[2].Value != "as" {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected 'as'. [%d,%d]", tokens[2].Value, tokens[2].Line, tokens[2].CharAt)
+	}
+	if !tokens[3].IsIdentifier() {
+		return nil, 0, fmt.Errorf("Parsing error: %s is not a valid variable name. [%d,%d]", tokens[3].Value, tokens[3].Line, tokens[3].CharAt)
+	}
+	return &core.ImportStatement{
+		Path: tokens[1].Unquote(),
+		Alias: core.Identifier{
+			Name:   tokens[3].Value,
+			Line:   tokens[3].Line,
+			CharAt: tokens[3].CharAt,
+		},
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}, 4, nil
+}
+
+func parseExportStatement(tokens []lexer.Token) (*core.ExportStatement, int, error) {
+	if len(tokens) < 2 {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse export statement. [%d,%d]", tokens[0].Line, tokens[0].CharAt)
+	}
+	exstmt := &core.ExportStatement{
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}
+	switch tokens[1].Value {
+	case "var", "const":
+		s, processed, err := parseVariableDeclaration(tokens[1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		s.Doc = tokens[0].Doc
+		exstmt.Declaration = *s
+		return exstmt, processed + 1, nil
+	case "func":
+		s, processed, err := parseFunctionDeclaration(tokens[1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		s.Doc = tokens[0].Doc
+		exstmt.Declaration = *s
+		return exstmt, processed + 1, nil
+	}
+	if s, processed, err := parseShorthandVariableDeclaration(tokens[1:]); err == nil {
+		s.Doc = tokens[0].Doc
+		exstmt.Declaration = *s
+		return exstmt, processed + 1, nil
+	}
+	return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected declaration after export. [%d,%d]", tokens[1].Value, tokens[1].Line, tokens[1].CharAt)
+}
+
+// parseForInStatement returns a nil statement with an error when tokens are not a for-in statement,
+// and a non-nil statement with an error when they are but it is malformed
+func parseForInStatement(tokens []lexer.Token) (*core.ForInStatement, int, error) {
+	if len(tokens) < 3 {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse for statement")
+	}
+	ids, i, err := parseSequentIdentifiers(tokens[1:]) // skip 'for'
+	if err != nil || len(ids) == 0 {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse for statement")
+	}
+	i++ // i is processed tokens after 'for'
+	if i >= len(tokens) || tokens[i].Value != "in" {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse for statement")
+	}
+	forstmt := &core.ForInStatement{
+		Key:    ids[0],
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}
+	if len(ids) > 2 {
+		return forstmt, 0, fmt.Errorf("Parsing error: too many variables in for statement. [%d,%d]", ids[2].Line, ids[2].CharAt)
+	}
+	if len(ids) == 2 {
+		forstmt.Value = &ids[1]
+	}
+	i++ // skip 'in'
+	estmt, processed, err := parseExpressionStatement(tokens[i:])
+	if err != nil {
+		t := tokens[i-1]
+		return forstmt, 0, fmt.Errorf("Parsing error: cannot parse for statement. [%d,%d]", t.Line, t.CharAt)
+	}
+	forstmt.Right = estmt.Expression
+	i = i + processed
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return forstmt, 0, fmt.Errorf("Parsing error: unexpected end of statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	bstmt, processed, err := parseBlockStatement(tokens[i:])
+	if err != nil {
+		return forstmt, 0, err
+	}
+	forstmt.Body = *bstmt
+	return forstmt, i + processed, nil
+}
+
+func parseMatchStatement(tokens []lexer.Token) (*core.MatchStatement, int, error) {
+	if len(tokens) < 4 { // 4 is len of the most simple match
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse match statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	mstmt := &core.MatchStatement{
+		Cases:  []core.MatchCase{},
+		Line:   tokens[0].Line,
+		CharAt: tokens[0].CharAt,
+	}
+	estmt, i, err := parseExpressionStatement(tokens[1:]) // skip 'match'
+	if err != nil {
+		return nil, 0, err
+	}
+	mstmt.Discriminant = estmt.Expression
+	i++ // i is processed tokens after 'match'
+	if i >= len(tokens) || tokens[i].Value != "{" {
+		t := tokens[len(tokens)-1]
+		if i < len(tokens) {
+			t = tokens[i]
+		}
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '{'. [%d,%d]", t.Value, t.Line, t.CharAt)
+	}
+	i++ // skip '{'
+	for i < len(tokens) && tokens[i].Value != "}" {
 		t := tokens[i]
-		parenLevel := openParen + len(bexpsAfterGroup)
-		var nt *lexer.Token
-		if i+1 < len(tokens) {
-			nt = &tokens[i+1]
+		if mstmt.Default != nil {
+			return nil, 0, fmt.Errorf("Parsing error: default must be the last case of match. [%d,%d]", t.Line, t.CharAt)
 		}
-		if exp, processed, err := parseTempExpression(tokens[i:]); exp != nil {
-			aexp, _ := exp.(*core.ArrayExpression)
-			if tmpExp != nil && (aexp == nil || len(aexp.Elements) != 1) {
-				return tmpExp, i, nil
+		if t.Value == "default" {
+			bstmt, processed, err := parseBlockStatement(tokens[i+1:])
+			if err != nil {
+				return nil, 0, err
 			}
-			i = i + processed - 1
-			if tmpExp != nil && aexp != nil && len(aexp.Elements) == 1 {
-				if lastBexp != nil {
-					maexp = &core.MemberAccessExpression{
-						Object:             lastBexp.Right,
-						PropertyExpression: aexp.Elements[0],
-						Compute:            true,
-						Line:               lastBexp.Right.GetLine(),
-						CharAt:             lastBexp.Right.GetCharAt(),
-					}
-					lastBexp.Right = maexp
-					lastBexp.Group = false
-				} else if maexp == nil {
-					maexp = &core.MemberAccessExpression{
-						Object:             tmpExp,
-						PropertyExpression: aexp.Elements[0],
-						Compute:            true,
-						Line:               tmpExp.GetLine(),
-						CharAt:             tmpExp.GetCharAt(),
-					}
-				} else if maexp.PropertyExpression != nil || maexp.PropertyIdentifier.Name != "" {
-					maexp = &core.MemberAccessExpression{
-						Object:             maexp,
-						PropertyExpression: aexp.Elements[0],
-						Compute:            true,
-						Line:               maexp.Line,
-						CharAt:             maexp.CharAt,
-					}
-				} else {
-					tt := tokens[i-processed+1]
-					return nil, 0, fmt.Errorf("Parsing error: unexpected expression. [%d,%d]", tt.Line, tt.CharAt)
+			mstmt.Default = bstmt
+			i = i + processed + 1
+			continue
+		}
+		if t.Value != "case" {
+			return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected 'case'. [%d,%d]", t.Value, t.Line, t.CharAt)
+		}
+		c, processed, err := parseMatchCase(tokens[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		mstmt.Cases = append(mstmt.Cases, *c)
+		i = i + processed
+	}
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	return mstmt, i + 1, nil
+}
+
+// parseMatchCase parses a case like case 1, 2 {} or case [x, ...] if x > 0 {}
+func parseMatchCase(tokens []lexer.Token) (*core.MatchCase, int, error) {
+	c := &core.MatchCase{
+		Patterns: []core.CasePattern{},
+		Line:     tokens[0].Line,
+		CharAt:   tokens[0].CharAt,
+	}
+	i := 1 // skip 'case'
+	for i < len(tokens) {
+		p, processed, err := parseCasePattern(tokens[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		c.Patterns = append(c.Patterns, p)
+		i = i + processed
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
+		}
+		i++
+	}
+	if i < len(tokens) && tokens[i].Value == "if" {
+		estmt, processed, err := parseExpressionStatement(tokens[i+1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		c.Guard = estmt.Expression
+		i = i + processed + 1
+	}
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of statement. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	bstmt, processed, err := parseBlockStatement(tokens[i:])
+	if err != nil {
+		return nil, 0, err
+	}
+	c.Body = *bstmt
+	return c, i + processed, nil
+}
+
+// parseCasePattern parses a pattern of a match case: a literal, a name, _ or an array or object pattern like
+// [x, 0, ...rest] or {type: "user", name, ...others}
+func parseCasePattern(tokens []lexer.Token) (core.CasePattern, int, error) {
+	if len(tokens) == 0 {
+		return core.CasePattern{}, 0, fmt.Errorf("Parsing error: cannot parse pattern")
+	}
+	t := tokens[0]
+	p := core.CasePattern{
+		Line:   t.Line,
+		CharAt: t.CharAt,
+	}
+	switch {
+	case t.Value == "[" || t.Value == "{":
+		return parseCasePatternElements(tokens)
+	case t.Value == "_":
+		return p, 1, nil
+	case t.IsIdentifier():
+		p.ID = &core.Identifier{
+			Name:   t.Value,
+			Line:   t.Line,
+			CharAt: t.CharAt,
+		}
+		return p, 1, nil
+	case t.Value == "-" && len(tokens) > 1 && tokens[1].IsNumber():
+		exp, _, err := parseTempExpression(tokens[1:])
+		if err != nil {
+			return core.CasePattern{}, 0, err
+		}
+		p.Literal = &core.UnaryExpression{
+			Expression: exp,
+			Operator:   "-",
+			Line:       t.Line,
+			CharAt:     t.CharAt,
+		}
+		return p, 2, nil
+	}
+	if _, ok := t.ParsePrimitiveType(); ok && !t.IsTemplate() {
+		exp, processed, err := parseTempExpression(tokens)
+		if err != nil {
+			return core.CasePattern{}, 0, err
+		}
+		p.Literal = exp
+		return p, processed, nil
+	}
+	return core.CasePattern{}, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
+}
+
+func parseCasePatternElements(tokens []lexer.Token) (core.CasePattern, int, error) {
+	p := core.CasePattern{
+		Array:    tokens[0].Value == "[",
+		Object:   tokens[0].Value == "{",
+		Elements: []core.CasePattern{},
+		Line:     tokens[0].Line,
+		CharAt:   tokens[0].CharAt,
+	}
+	end := "]"
+	if p.Object {
+		end = "}"
+	}
+	i := 1
+	for i < len(tokens) && tokens[i].Value != end {
+		t := tokens[i]
+		var e core.CasePattern
+		key := ""
+		if t.Value == "..." {
+			e = core.CasePattern{Rest: true, Line: t.Line, CharAt: t.CharAt}
+			i++
+			if i < len(tokens) && tokens[i].IsIdentifier() {
+				e.ID = &core.Identifier{
+					Name:   tokens[i].Value,
+					Line:   tokens[i].Line,
+					CharAt: tokens[i].CharAt,
 				}
-				tmpExp = maexp
-			} else if len(unaryQueue[parenLevel]) > 0 {
-				tmpExp = wrapInUnaryExpression(exp, unaryQueue, parenLevel)
-			} else {
-				tmpExp = exp
+				i++
 			}
-			if maexp != nil && maexp.PropertyExpression == nil && maexp.PropertyIdentifier.Name == "" {
-				vexp, _ := tmpExp.(*core.VariableExpression)
-				if vexp == nil {
-					return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
-				}
-				maexp.PropertyIdentifier = core.Identifier{
-					Name:   vexp.Name,
-					Line:   vexp.Line,
-					CharAt: vexp.CharAt,
-				}
-				tmpExp = maexp
-			}
-			if bexp != nil && nt != nil && nt.Value == ")" && openParen <= len(bexpsAfterGroup) {
-				bexp.Group = true
-			}
-			if bexp != nil && bexp.Right == nil {
-				bexp.Right = tmpExp
-				lastBexp = bexp
-				lastBexpAfterGroup, _ := getLastBexpAfterGroup(bexpsAfterGroup, false)
-				// Check prececdence and swap expression order if needed
-				if lbexp, ok := bexp.Left.(*core.BinaryExpression); ok && lbexp.Operator.Compare(bexp.Operator) > 0 && !lbexp.Group && (lastBexpAfterGroup == nil || bexp != lastBexpAfterGroup) {
-					bexp.Left = lbexp.Right
-					bexp.CharAt = lbexp.Right.GetCharAt()
-					lbexp.Right = bexp
-					bexp = lbexp
-				}
-				tmpExp = bexp
-			}
-		} else if t.Value == "!" {
-			unaryQueue[parenLevel] = append(unaryQueue[parenLevel], t)
-		} else if t.Value == "." {
-			if lastBexp != nil {
-				maexp = &core.MemberAccessExpression{
-					Object: lastBexp.Right,
-					Line:   lastBexp.Right.GetLine(),
-					CharAt: lastBexp.Right.GetCharAt(),
-				}
-				lastBexp.Right = maexp
-				lastBexp.Group = false
-			} else if maexp == nil {
-				if tmpExp == nil {
-					return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
-				}
-				maexp = &core.MemberAccessExpression{
-					Object: tmpExp,
-					Line:   tmpExp.GetLine(),
-					CharAt: tmpExp.GetCharAt(),
-				}
-			} else if maexp.PropertyExpression != nil || maexp.PropertyIdentifier.Name != "" {
-				maexp = &core.MemberAccessExpression{
-					Object: maexp,
-					Line:   maexp.Line,
-					CharAt: maexp.CharAt,
-				}
-			} else {
-				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
+		} else if p.Object {
+			if !t.IsIdentifier() {
+				return core.CasePattern{}, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
 			}
-			tmpExp = nil
-		} else if t.IsOperatorSymbol() {
-			op := core.Operator{
-				Symbol: t.Value,
+			key = t.Value
+			e = core.CasePattern{
+				ID:     &core.Identifier{Name: t.Value, Line: t.Line, CharAt: t.CharAt},
 				Line:   t.Line,
 				CharAt: t.CharAt,
 			}
-			if bexp == nil {
-				if tmpExp == nil {
-					return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
-				}
-				if tmpExp == maexp {
-					maexp = nil
-				}
-				bexp = &core.BinaryExpression{
-					Left:     tmpExp,
-					Line:     tmpExp.GetLine(),
-					CharAt:   tmpExp.GetCharAt(),
-					Operator: op,
-				}
-			} else if bexp.Right != nil {
-				bexp = &core.BinaryExpression{
-					Left:     bexp,
-					Line:     bexp.Line,
-					CharAt:   bexp.CharAt,
-					Operator: op,
+			i++
+			if i < len(tokens) && tokens[i].Value == ":" {
+				pe, processed, err := parseCasePattern(tokens[i+1:])
+				if err != nil {
+					return core.CasePattern{}, 0, err
 				}
+				e = pe
+				i = i + processed + 1
+			}
+		} else {
+			pe, processed, err := parseCasePattern(tokens[i:])
+			if err != nil {
+				return core.CasePattern{}, 0, err
+			}
+			e = pe
+			i = i + processed
+		}
+		if p.Object {
+			p.Keys = append(p.Keys, key)
+		}
+		p.Elements = append(p.Elements, e)
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
+		}
+		if e.Rest {
+			return core.CasePattern{}, 0, fmt.Errorf("Parsing error: rest element must be last. [%d,%d]", tokens[i].Line, tokens[i].CharAt)
+		}
+		i++
+	}
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return core.CasePattern{}, 0, fmt.Errorf("Parsing error: unexpected end of pattern. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	if tokens[i].Value != end {
+		return core.CasePattern{}, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '%s'. [%d,%d]", tokens[i].Value, end, tokens[i].Line, tokens[i].CharAt)
+	}
+	return p, i + 1, nil
+}
+
+func parseExpression(tokens []lexer.Token) (core.Expression, int, error) {
+	return parseConditionalExpression(tokens)
+}
+
+// parseConditionalExpression parses test ? consequent : alternate, it is right-associative
+func parseConditionalExpression(tokens []lexer.Token) (core.Expression, int, error) {
+	test, i, err := parseBinaryExpression(tokens, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if i >= len(tokens) || tokens[i].Value != "?" {
+		return test, i, nil
+	}
+	i++ // skip '?'
+	consequent, processed, err := parseExpression(tokens[i:])
+	if err != nil {
+		return nil, 0, err
+	}
+	i = i + processed
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of expression. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	if tokens[i].Value != ":" {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected ':'. [%d,%d]", tokens[i].Value, tokens[i].Line, tokens[i].CharAt)
+	}
+	i++ // skip ':'
+	alternate, processed, err := parseConditionalExpression(tokens[i:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return &core.ConditionalExpression{
+		Test:       test,
+		Consequent: consequent,
+		Alternate:  alternate,
+		Line:       test.GetLine(),
+		CharAt:     test.GetCharAt(),
+	}, i + processed, nil
+}
+
+// parseBinaryExpression parses operands joined by binary operators which bind tighter than limit,
+// any operator when limit is nil. Operators of the same precedence are left-associative.
+func parseBinaryExpression(tokens []lexer.Token, limit *core.Operator) (core.Expression, int, error) {
+	left, i, err := parseUnaryExpression(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i < len(tokens) && tokens[i].IsOperatorSymbol() {
+		op := core.Operator{
+			Symbol: tokens[i].Value,
+			Line:   tokens[i].Line,
+			CharAt: tokens[i].CharAt,
+		}
+		if limit != nil && op.Compare(*limit) >= 0 {
+			break
+		}
+		right, processed, err := parseBinaryExpression(tokens[i+1:], &op)
+		if err != nil {
+			return nil, 0, err
+		}
+		left = &core.BinaryExpression{
+			Left:     left,
+			Right:    right,
+			Operator: op,
+			Line:     left.GetLine(),
+			CharAt:   left.GetCharAt(),
+		}
+		i = i + processed + 1
+	}
+	return left, i, nil
+}
+
+func parseUnaryExpression(tokens []lexer.Token) (core.Expression, int, error) {
+	if len(tokens) == 0 {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse expression")
+	}
+	t := tokens[0]
+	if t.Value != "!" && t.Value != "-" && t.Value != "+" && t.Value != "~" {
+		return parsePowerExpression(tokens)
+	}
+	exp, processed, err := parseUnaryExpression(tokens[1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return &core.UnaryExpression{
+		Expression: exp,
+		Operator:   t.Value,
+		Line:       t.Line,
+		CharAt:     t.CharAt,
+	}, processed + 1, nil
+}
+
+// parsePowerExpression parses base ** exponent. It binds tighter than a unary operator on its left,
+// so -2**2 is -(2**2), and is right-associative.
+func parsePowerExpression(tokens []lexer.Token) (core.Expression, int, error) {
+	base, i, err := parsePostfixExpression(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
+	if i >= len(tokens) || tokens[i].Value != "**" {
+		return base, i, nil
+	}
+	exponent, processed, err := parseUnaryExpression(tokens[i+1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return &core.BinaryExpression{
+		Left:  base,
+		Right: exponent,
+		Operator: core.Operator{
+			Symbol: "**",
+			Line:   tokens[i].Line,
+			CharAt: tokens[i].CharAt,
+		},
+		Line:   base.GetLine(),
+		CharAt: base.GetCharAt(),
+	}, i + processed + 1, nil
+}
+
+// parsePostfixExpression parses an operand followed by member accesses and calls
+func parsePostfixExpression(tokens []lexer.Token) (core.Expression, int, error) {
+	exp, i, err := parseOperand(tokens)
+	if err != nil {
+		return nil, 0, err
+	}
+	chain := false
+	for i < len(tokens) {
+		t := tokens[i]
+		optional := false
+		if t.Value == "?." {
+			// a?.b, a?.[k] and f?.() make the whole chain optional
+			if i+1 >= len(tokens) {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected end of expression. [%d,%d]", t.Line, t.CharAt)
+			}
+			if tokens[i+1].IsName() {
+				t = lexer.Token{Kind: lexer.TokenPunctuation, Value: ".", Line: t.Line, CharAt: t.CharAt}
+			} else if tokens[i+1].Value == "[" || tokens[i+1].Value == "(" {
+				i++
+				t = tokens[i]
 			} else {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", tokens[i+1].Value, tokens[i+1].Line, tokens[i+1].CharAt)
+			}
+			optional, chain = true, true
+		}
+		if t.Value == "." {
+			if i+1 >= len(tokens) || !tokens[i+1].IsName() {
 				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
 			}
-			if bexp != nil && openParen > 0 {
-				bexpsAfterGroup = append(bexpsAfterGroup, bexp)
-				openParen--
+			exp = &core.MemberAccessExpression{
+				Object: exp,
+				PropertyIdentifier: core.Identifier{
+					Name:   tokens[i+1].Value,
+					Line:   tokens[i+1].Line,
+					CharAt: tokens[i+1].CharAt,
+				},
+				Optional: optional,
+				Line:     exp.GetLine(),
+				CharAt:   exp.GetCharAt(),
+			}
+			i = i + 2
+		} else if t.Value == "[" {
+			// an array of one element after an operand is a computed member access
+			aexp, processed, err := parseArrayExpression(tokens[i:])
+			if err != nil || len(aexp.(*core.ArrayExpression).Elements) != 1 {
+				if optional {
+					return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
+				}
+				break
+			}
+			if se, ok := aexp.(*core.ArrayExpression).Elements[0].(*core.SpreadElement); ok {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected token '...'. [%d,%d]", se.Line, se.CharAt)
 			}
-			tmpExp = nil
+			exp = &core.MemberAccessExpression{
+				Object:             exp,
+				PropertyExpression: aexp.(*core.ArrayExpression).Elements[0],
+				Compute:            true,
+				Optional:           optional,
+				Line:               exp.GetLine(),
+				CharAt:             exp.GetCharAt(),
+			}
+			i = i + processed
 		} else if t.Value == "(" {
-			openParen++
-			if openParen+len(bexpsAfterGroup) >= len(unaryQueue) {
-				unaryQueue = append(unaryQueue, []lexer.Token{}) // increase level
+			args, processed, err := parseElements(tokens[i+1:], true)
+			if err != nil {
+				return nil, 0, err
 			}
-			if tmpExp != nil {
-				args, processed, err := parseSequentExpressions(tokens[i+1:])
-				if err != nil {
-					return nil, 0, err
-				}
-				i = i + processed
-				if i+1 >= len(tokens) {
-					lastToken := tokens[len(tokens)-1]
-					return nil, 0, fmt.Errorf("Parsing error: unexpected end of expression. [%d,%d]", lastToken.Line, lastToken.CharAt)
-				}
-				if tokens[i+1].Value != ")" {
-					return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected ')'. [%d,%d]", tokens[i+1].Value, tokens[i+1].Line, tokens[i+1].CharAt)
-				}
-				if lastBexp != nil {
-					tmpExp = &core.CallExpression{
-						Callee:    lastBexp.Right,
-						Arguments: args,
-						Line:      lastBexp.Right.GetLine(),
-						CharAt:    lastBexp.Right.GetCharAt(),
-					}
-					lastBexp.Right = tmpExp
-					lastBexp.Group = false
-					tmpExp = lastBexp
-				} else {
-					tmpExp = &core.CallExpression{
-						Callee:    tmpExp,
-						Arguments: args,
-						Line:      tmpExp.GetLine(),
-						CharAt:    tmpExp.GetCharAt(),
-					}
-				}
+			i = i + processed + 1
+			if i >= len(tokens) {
+				lastToken := tokens[len(tokens)-1]
+				return nil, 0, fmt.Errorf("Parsing error: unexpected end of expression. [%d,%d]", lastToken.Line, lastToken.CharAt)
 			}
-		} else if t.Value == ")" {
-			parenLevel--
-			if openParen == 0 && len(bexpsAfterGroup) == 0 {
-				// it means this ')' doesn't belong to current expreesion
-				break
+			if tokens[i].Value != ")" {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected ')'. [%d,%d]", tokens[i].Value, tokens[i].Line, tokens[i].CharAt)
 			}
-			if openParen > 0 && openParen >= len(bexpsAfterGroup) {
-				// if openParen is increased by some exp like (a) or ((a+b)+c) -> do not need to swap
-				if len(unaryQueue[parenLevel]) > 0 {
-					if openParen == len(bexpsAfterGroup) && bexp != nil {
-						tmpExp = wrapInUnaryExpression(bexp, unaryQueue, parenLevel).(*core.UnaryExpression)
-						bexp = nil
-					} else if bexp == nil {
-						tmpExp = wrapInUnaryExpression(tmpExp, unaryQueue, parenLevel)
-					} else {
-						bexp.Right = wrapInUnaryExpression(bexp.Right, unaryQueue, parenLevel)
-					}
-				}
-				openParen--
-			} else if bexp != nil {
-				if len(bexpsAfterGroup) > 0 {
-					// Swap expression order to match expression group
-					lastBexpAfterGroup, i := getLastBexpAfterGroup(bexpsAfterGroup, true)
-					if bexpBeforeGroup, ok := lastBexpAfterGroup.Left.(*core.BinaryExpression); ok {
-						lastBexpAfterGroup.Left = bexpBeforeGroup.Right
-						lastBexpAfterGroup.CharAt = bexpBeforeGroup.Right.GetCharAt()
-						bexpBeforeGroup.Right = bexp
-						groupedBexp := bexp
-						bexp = bexpBeforeGroup
-						if len(unaryQueue[parenLevel]) > 0 {
-							bexp.Right = wrapInUnaryExpression(bexp.Right, unaryQueue, parenLevel)
-						}
-
-						// loop from groupedBexp to lastBexpAfterGroup and update exp CharAt
-						groupedBexp.CharAt = lastBexpAfterGroup.CharAt
-						for tmpBexp, _ := groupedBexp.Left.(*core.BinaryExpression); tmpBexp != nil; {
-							tmpBexp.CharAt = lastBexpAfterGroup.CharAt
-							tmpBexp, _ = tmpBexp.Left.(*core.BinaryExpression)
-						}
-					} else if len(unaryQueue[parenLevel]) > 0 {
-						tmpExp, _ = wrapInUnaryExpression(bexp, unaryQueue, parenLevel).(*core.UnaryExpression)
-						bexp = nil
-					}
-					bexpsAfterGroup = append(bexpsAfterGroup[i+1:], bexpsAfterGroup[:i]...)
-				}
+			exp = &core.CallExpression{
+				Callee:    exp,
+				Arguments: args,
+				Optional:  optional,
+				Line:      exp.GetLine(),
+				CharAt:    exp.GetCharAt(),
 			}
-		} else if (bexp == nil || bexp.Right == nil) && tmpExp == nil {
-			return nil, 0, err
+			i++
 		} else {
 			break
 		}
 	}
-	if bexp != nil && bexp.Right != nil {
-		return bexp, i, nil
-	}
-	return tmpExp, i, nil
-}
-
-func getLastBexpAfterGroup(bexps []*core.BinaryExpression, rightShouldNotNil bool) (*core.BinaryExpression, int) {
-	for i := len(bexps) - 1; i >= 0; i-- {
-		bexp := bexps[i]
-		if !rightShouldNotNil {
-			return bexp, i
-		}
-		if bexp.Right != nil {
-			return bexp, i
+	if chain {
+		exp = &core.ChainExpression{
+			Expression: exp,
+			Line:       exp.GetLine(),
+			CharAt:     exp.GetCharAt(),
 		}
 	}
-	return nil, -1
+	return exp, i, nil
 }
 
-func wrapInUnaryExpression(exp core.Expression, unaryQueue [][]lexer.Token, parenLevel int) core.Expression {
-	for j := len(unaryQueue[parenLevel]) - 1; j >= 0; j-- {
-		exp = &core.UnaryExpression{
-			Expression: exp,
-			Line:       unaryQueue[parenLevel][j].Line,
-			CharAt:     unaryQueue[parenLevel][j].CharAt,
+// parseOperand parses a literal, a variable, an object, an array, a function or a group in parentheses
+func parseOperand(tokens []lexer.Token) (core.Expression, int, error) {
+	if len(tokens) == 0 || tokens[0].Value != "(" {
+		exp, processed, err := parseTempExpression(tokens)
+		if err != nil {
+			return nil, 0, err
 		}
+		return exp, processed, nil
+	}
+	exp, i, err := parseExpression(tokens[1:])
+	if err != nil {
+		return nil, 0, err
+	}
+	i++ // '(' is processed
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of expression. [%d,%d]", lastToken.Line, lastToken.CharAt)
 	}
-	unaryQueue[parenLevel] = []lexer.Token{}
-	return exp
+	if tokens[i].Value != ")" {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected ')'. [%d,%d]", tokens[i].Value, tokens[i].Line, tokens[i].CharAt)
+	}
+	if bexp, ok := exp.(*core.BinaryExpression); ok {
+		bexp.Group = true
+	}
+	return exp, i + 1, nil
 }
 
 // Check first token, if it is the start of an expression then parse it and return
@@ -696,10 +1239,15 @@ func parseTempExpression(tokens []lexer.Token) (core.Expression, int, error) {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse expression")
 	}
 	t := tokens[0]
+	if t.IsTemplate() {
+		return parseTemplateExpression(t)
+	}
 	if ptype, ok := t.ParsePrimitiveType(); ok {
 		v := t.Value
 		if ptype == core.LiteralTypeString {
-			v = t.Value[1 : len(t.Value)-1]
+			v = t.Unquote()
+		} else if core.IsNumeric(ptype) {
+			v = t.NumberValue()
 		}
 		return &core.LiteralExpression{
 			Type:   ptype,
@@ -727,29 +1275,192 @@ func parseTempExpression(tokens []lexer.Token) (core.Expression, int, error) {
 	return nil, 0, fmt.Errorf("Parsing error: cannot parse expression")
 }
 
+// parseTemplateExpression parses the expressions interpolated in the template string token t
+func parseTemplateExpression(t lexer.Token) (core.Expression, int, error) {
+	quasis, tokens, err := lexer.LexTemplate(t)
+	if err != nil {
+		return nil, 0, err
+	}
+	texp := &core.TemplateExpression{
+		Quasis: quasis,
+		Line:   t.Line,
+		CharAt: t.CharAt,
+	}
+	for _, ts := range tokens {
+		if len(ts) == 0 {
+			return nil, 0, fmt.Errorf("Parsing error: empty expression in template string. [%d,%d]", t.Line, t.CharAt)
+		}
+		exp, processed, err := parseExpression(ts)
+		if err != nil {
+			return nil, 0, err
+		}
+		if processed < len(ts) {
+			return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", ts[processed].Value, ts[processed].Line, ts[processed].CharAt)
+		}
+		texp.Expressions = append(texp.Expressions, exp)
+	}
+	return texp, 1, nil
+}
+
 func parseSequentIdentifiers(tokens []lexer.Token) ([]core.Identifier, int, error) {
 	ids := []core.Identifier{}
 	var i int
 	for i = 0; i < len(tokens); i++ {
 		t := tokens[i]
-		if !t.IsIdentifier() {
-			return ids, i, nil
-		}
-		if t.IsIdentifier() {
+		if t.Value == "[" || t.Value == "{" {
+			p, processed, err := parsePattern(tokens[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			ids = append(ids, core.Identifier{
+				Pattern: p,
+				Line:    t.Line,
+				CharAt:  t.CharAt,
+			})
+			i = i + processed
+		} else if t.IsIdentifier() {
 			ids = append(ids, core.Identifier{
 				Name:   t.Value,
 				Line:   t.Line,
 				CharAt: t.CharAt,
 			})
-			i++ // increase before break or
-			if i >= len(tokens) || tokens[i].Value != "," {
-				break
-			}
+			i++
+		} else {
+			return ids, i, nil
+		}
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
 		}
 	}
 	return ids, i, nil
 }
 
+// parseParams parses the parameters of a function, they can have a default value like port = 8080 and the
+// last one can be a rest parameter like ...rest
+func parseParams(tokens []lexer.Token) ([]core.Identifier, int, error) {
+	params := []core.Identifier{}
+	var i int
+	for i = 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if !t.IsIdentifier() && t.Value != "..." && t.Value != "[" && t.Value != "{" {
+			return params, i, nil
+		}
+		p, processed, err := parseBinding(tokens[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		params = append(params, p)
+		i = i + processed
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
+		}
+		if p.Rest {
+			return nil, 0, fmt.Errorf("Parsing error: rest parameter must be last. [%d,%d]", tokens[i].Line, tokens[i].CharAt)
+		}
+	}
+	return params, i, nil
+}
+
+// parsePattern parses a destructuring pattern like [a, b = 1, ...rest] or {name, age: years, ...others}
+func parsePattern(tokens []lexer.Token) (*core.Pattern, int, error) {
+	if len(tokens) == 0 || (tokens[0].Value != "[" && tokens[0].Value != "{") {
+		return nil, 0, fmt.Errorf("Parsing error: cannot parse pattern")
+	}
+	p := &core.Pattern{
+		Object:   tokens[0].Value == "{",
+		Elements: []core.Identifier{},
+		Line:     tokens[0].Line,
+		CharAt:   tokens[0].CharAt,
+	}
+	end := "]"
+	if p.Object {
+		end = "}"
+	}
+	i := 1
+	for i < len(tokens) && tokens[i].Value != end {
+		t := tokens[i]
+		key := ""
+		if p.Object && t.IsName() && i+1 < len(tokens) && tokens[i+1].Value == ":" {
+			key = t.Value
+			i = i + 2
+		}
+		if i >= len(tokens) {
+			break
+		}
+		id, processed, err := parseBinding(tokens[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		if p.Object && key == "" && !id.Rest {
+			if id.Pattern != nil {
+				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", tokens[i].Value, tokens[i].Line, tokens[i].CharAt)
+			}
+			key = id.Name
+		}
+		if p.Object {
+			p.Keys = append(p.Keys, key)
+		}
+		p.Elements = append(p.Elements, id)
+		i = i + processed
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
+		}
+		if id.Rest {
+			return nil, 0, fmt.Errorf("Parsing error: rest element must be last. [%d,%d]", tokens[i].Line, tokens[i].CharAt)
+		}
+		i++
+	}
+	if i >= len(tokens) {
+		lastToken := tokens[len(tokens)-1]
+		return nil, 0, fmt.Errorf("Parsing error: unexpected end of pattern. [%d,%d]", lastToken.Line, lastToken.CharAt)
+	}
+	if tokens[i].Value != end {
+		return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '%s'. [%d,%d]", tokens[i].Value, end, tokens[i].Line, tokens[i].CharAt)
+	}
+	return p, i + 1, nil
+}
+
+// parseBinding parses a parameter or an element of a pattern: a name or a nested pattern followed by its
+// default value like port = 8080, or a rest element like ...rest
+func parseBinding(tokens []lexer.Token) (core.Identifier, int, error) {
+	i := 0
+	rest := tokens[0].Value == "..."
+	if rest {
+		i++
+	}
+	if i >= len(tokens) {
+		return core.Identifier{}, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", tokens[0].Value, tokens[0].Line, tokens[0].CharAt)
+	}
+	t := tokens[i]
+	id := core.Identifier{
+		Rest:   rest,
+		Line:   t.Line,
+		CharAt: t.CharAt,
+	}
+	if t.IsIdentifier() {
+		id.Name = t.Value
+		i++
+	} else if !rest && (t.Value == "[" || t.Value == "{") {
+		p, processed, err := parsePattern(tokens[i:])
+		if err != nil {
+			return core.Identifier{}, 0, err
+		}
+		id.Pattern = p
+		i = i + processed
+	} else {
+		return core.Identifier{}, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
+	}
+	if !rest && i < len(tokens) && tokens[i].Value == "=" {
+		exp, processed, err := parseExpression(tokens[i+1:])
+		if err != nil {
+			return core.Identifier{}, 0, err
+		}
+		id.Default = exp
+		i = i + processed + 1
+	}
+	return id, i, nil
+}
+
 func parseSequentExpressions(tokens []lexer.Token) ([]core.Expression, int, error) {
 	exps := []core.Expression{}
 	var i int
@@ -769,6 +1480,55 @@ func parseSequentExpressions(tokens []lexer.Token) ([]core.Expression, int, erro
 	return exps, i, nil
 }
 
+// parseElements parses the arguments of a call or the elements of an array, they can be spread like ...a.
+// When named is true, the elements can end with named arguments like port: 8080.
+func parseElements(tokens []lexer.Token, named bool) ([]core.Expression, int, error) {
+	exps := []core.Expression{}
+	var i int
+	var na *core.NamedArgument // the last named argument
+	for i = 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if named && t.IsIdentifier() && i+1 < len(tokens) && tokens[i+1].Value == ":" {
+			exp, processed, err := parseExpression(tokens[i+2:])
+			if err != nil {
+				return nil, 0, err
+			}
+			na = &core.NamedArgument{
+				Name:   t.Value,
+				Value:  exp,
+				Line:   t.Line,
+				CharAt: t.CharAt,
+			}
+			exps = append(exps, na)
+			i = i + processed + 2
+		} else if na != nil && t.Value != ")" {
+			return nil, 0, fmt.Errorf("Parsing error: positional argument after named argument '%s'. [%d,%d]", na.Name, t.Line, t.CharAt)
+		} else if t.Value == "..." {
+			exp, processed, err := parseExpression(tokens[i+1:])
+			if err != nil {
+				return nil, 0, err
+			}
+			exps = append(exps, &core.SpreadElement{
+				Expression: exp,
+				Line:       t.Line,
+				CharAt:     t.CharAt,
+			})
+			i = i + processed + 1
+		} else {
+			exp, processed, _ := parseExpression(tokens[i:])
+			if exp == nil {
+				return exps, i, nil
+			}
+			exps = append(exps, exp)
+			i = i + processed // do not need to - 1, skip ',' anyway
+		}
+		if i >= len(tokens) || tokens[i].Value != "," {
+			break
+		}
+	}
+	return exps, i, nil
+}
+
 func parseObjectExpression(tokens []lexer.Token) (core.Expression, int, error) {
 	if len(tokens) == 0 || tokens[0].Value != "{" {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse object")
@@ -795,7 +1555,7 @@ func parseObjectExpression(tokens []lexer.Token) (core.Expression, int, error) {
 				i++
 				break
 			}
-			if prop != nil || (!nt.IsIdentifier() && nt.Value != "[") {
+			if prop != nil || (!nt.IsName() && nt.Value != "[" && nt.Value != "...") {
 				return nil, 0, fmt.Errorf("Parsing error: unexpected token '%s'. [%d,%d]", t.Value, t.Line, t.CharAt)
 			}
 			continue
@@ -805,7 +1565,17 @@ func parseObjectExpression(tokens []lexer.Token) (core.Expression, int, error) {
 				Line:   t.Line,
 				CharAt: t.CharAt,
 			}
-			if t.IsIdentifier() {
+			if t.Value == "..." {
+				exp, processed, err := parseExpression(tokens[i+1:])
+				if err != nil {
+					return nil, 0, err
+				}
+				i = i + processed
+				prop.Value = exp
+				prop.Spread = true
+				obj.Properties = append(obj.Properties, prop)
+				prop = nil
+			} else if t.IsName() {
 				prop.KeyIdentifier = core.Identifier{
 					Name:   t.Value,
 					Line:   t.Line,
@@ -846,7 +1616,7 @@ func parseArrayExpression(tokens []lexer.Token) (core.Expression, int, error) {
 	if len(tokens) == 0 || tokens[0].Value != "[" {
 		return nil, 0, fmt.Errorf("Parsing error: cannot parse array expession")
 	}
-	exps, processed, err := parseSequentExpressions(tokens[1:]) // skip '['
+	exps, processed, err := parseElements(tokens[1:], false) // skip '['
 	if err != nil {
 		return nil, 0, err
 	}
@@ -893,7 +1663,7 @@ func parseFunctionParamAndBody(tokens []lexer.Token) ([]core.Identifier, *core.B
 		return nil, nil, 0, fmt.Errorf("Parsing error: unexpected token '%s', expected '('. [%d,%d]", tokens[0].Value, tokens[0].Line, tokens[0].CharAt)
 	}
 	i := 0
-	params, processed, err := parseSequentIdentifiers(tokens[i+1:])
+	params, processed, err := parseParams(tokens[i+1:])
 	i = i + processed + 1
 	if err != nil {
 		return nil, nil, 0, err
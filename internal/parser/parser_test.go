@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -379,6 +380,41 @@ func TestParseExpression_Object(t *testing.T) {
 		in   string
 		want core.Expression
 	}{
+		{
+			name: "parse object expression {...a,b:1}",
+			in:   "{...a,b:1}",
+			want: &core.ObjectExpression{
+				Properties: []*core.ObjectProperty{
+					{
+						Value: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 5,
+						},
+						Spread: true,
+						Line:   1,
+						CharAt: 2,
+					},
+					{
+						KeyIdentifier: core.Identifier{
+							Name:   "b",
+							Line:   1,
+							CharAt: 7,
+						},
+						Value: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "1",
+							Line:   1,
+							CharAt: 9,
+						},
+						Line:   1,
+						CharAt: 7,
+					},
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
 		{
 			name: "parse object expression {}",
 			in:   "{}",
@@ -896,6 +932,31 @@ func TestParseExpression_ArrayExpression(t *testing.T) {
 		in   string
 		want core.Expression
 	}{
+		{
+			name: "parse array expression [...a,1]",
+			in:   "[...a,1]",
+			want: &core.ArrayExpression{
+				Elements: []core.Expression{
+					&core.SpreadElement{
+						Expression: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 5,
+						},
+						Line:   1,
+						CharAt: 2,
+					},
+					&core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 7,
+					},
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
 		{
 			name: "parse array expression []",
 			in:   "[]",
@@ -1749,6 +1810,63 @@ func TestParseExpression_Function(t *testing.T) {
 		in   string
 		want core.Expression
 	}{
+		{
+			name: "parse function expression with rest parameter",
+			in:   `func (a,...b){}`,
+			want: &core.FunctionExpression{
+				Params: []core.Identifier{
+					{
+						Name:   "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					{
+						Name:   "b",
+						Rest:   true,
+						Line:   1,
+						CharAt: 12,
+					},
+				},
+				Body: core.BlockStatement{
+					Statements: []core.Statement{},
+					Line:       1,
+					CharAt:     14,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse function expression with default parameter value",
+			in:   `func (a,b=1){}`,
+			want: &core.FunctionExpression{
+				Params: []core.Identifier{
+					{
+						Name:   "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					{
+						Name: "b",
+						Default: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "1",
+							Line:   1,
+							CharAt: 11,
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+				},
+				Body: core.BlockStatement{
+					Statements: []core.Statement{},
+					Line:       1,
+					CharAt:     13,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
 		{
 			name: "parse function expression #1",
 			in:   `func (b,c){}`,
@@ -1931,6 +2049,68 @@ func TestParseExpression_CallExpression(t *testing.T) {
 				CharAt: 3,
 			},
 		},
+		{
+			name: "parse call expression with named argument",
+			in:   "a(1,b:2)",
+			want: &core.CallExpression{
+				Callee: &core.VariableExpression{
+					Name:   "a",
+					Line:   1,
+					CharAt: 1,
+				},
+				Arguments: []core.Expression{
+					&core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 3,
+					},
+					&core.NamedArgument{
+						Name: "b",
+						Value: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "2",
+							Line:   1,
+							CharAt: 7,
+						},
+						Line:   1,
+						CharAt: 5,
+					},
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse call expression with spread",
+			in:   "a(1,...b)",
+			want: &core.CallExpression{
+				Callee: &core.VariableExpression{
+					Name:   "a",
+					Line:   1,
+					CharAt: 1,
+				},
+				Arguments: []core.Expression{
+					&core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 3,
+					},
+					&core.SpreadElement{
+						Expression: &core.VariableExpression{
+							Name:   "b",
+							Line:   1,
+							CharAt: 8,
+						},
+						Line:   1,
+						CharAt: 5,
+					},
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
 		{
 			name: "parse call expression #3",
 			in:   "((a))(1,(2+3))",
@@ -2244,6 +2424,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			name: "parse unary expression #1",
 			in:   `!1`,
 			want: &core.UnaryExpression{
+				Operator: "!",
 				Expression: &core.LiteralExpression{
 					Type:   "number",
 					Value:  "1",
@@ -2259,6 +2440,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			in:   `!a+b`,
 			want: &core.BinaryExpression{
 				Left: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.VariableExpression{
 						Name:   "a",
 						Line:   1,
@@ -2291,6 +2473,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 					CharAt: 1,
 				},
 				Right: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.VariableExpression{
 						Name:   "b",
 						Line:   1,
@@ -2319,6 +2502,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 						CharAt: 1,
 					},
 					Right: &core.UnaryExpression{
+						Operator: "!",
 						Expression: &core.MemberAccessExpression{
 							Object: &core.VariableExpression{
 								Name:   "b",
@@ -2368,6 +2552,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 					CharAt: 1,
 				},
 				Right: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.BinaryExpression{
 						Left: &core.MemberAccessExpression{
 							Object: &core.VariableExpression{
@@ -2413,7 +2598,9 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			name: "parse unary expression #6",
 			in:   `!!1`,
 			want: &core.UnaryExpression{
+				Operator: "!",
 				Expression: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.LiteralExpression{
 						Type:   "number",
 						Value:  "1",
@@ -2432,6 +2619,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			in:   `(!a)+b`,
 			want: &core.BinaryExpression{
 				Left: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.VariableExpression{
 						Name:   "a",
 						Line:   1,
@@ -2459,6 +2647,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			in:   `!(a)+b`,
 			want: &core.BinaryExpression{
 				Left: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.VariableExpression{
 						Name:   "a",
 						Line:   1,
@@ -2491,6 +2680,7 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 					CharAt: 1,
 				},
 				Right: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.VariableExpression{
 						Name:   "b",
 						Line:   1,
@@ -2513,23 +2703,27 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			in:   `!(!!(!a.b)+c)+d`,
 			want: &core.BinaryExpression{
 				Left: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.BinaryExpression{
 						Left: &core.UnaryExpression{
+							Operator: "!",
 							Expression: &core.UnaryExpression{
-								Expression: &core.MemberAccessExpression{
-									Object: &core.UnaryExpression{
-										Expression: &core.VariableExpression{
+								Operator: "!",
+								Expression: &core.UnaryExpression{
+									Operator: "!",
+									Expression: &core.MemberAccessExpression{
+										Object: &core.VariableExpression{
 											Name:   "a",
 											Line:   1,
 											CharAt: 7,
 										},
+										PropertyIdentifier: core.Identifier{
+											Name:   "b",
+											Line:   1,
+											CharAt: 9,
+										},
 										Line:   1,
-										CharAt: 6,
-									},
-									PropertyIdentifier: core.Identifier{
-										Name:   "b",
-										Line:   1,
-										CharAt: 9,
+										CharAt: 7,
 									},
 									Line:   1,
 									CharAt: 6,
@@ -2576,9 +2770,12 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 			in:   `!(!!(1+!a.b)+c)+d`,
 			want: &core.BinaryExpression{
 				Left: &core.UnaryExpression{
+					Operator: "!",
 					Expression: &core.BinaryExpression{
 						Left: &core.UnaryExpression{
+							Operator: "!",
 							Expression: &core.UnaryExpression{
+								Operator: "!",
 								Expression: &core.BinaryExpression{
 									Left: &core.LiteralExpression{
 										Type:   core.LiteralTypeNumber,
@@ -2586,20 +2783,21 @@ func TestParseExpression_UnaryExpression(t *testing.T) {
 										Line:   1,
 										CharAt: 6,
 									},
-									Right: &core.MemberAccessExpression{
-										Object: &core.UnaryExpression{
-											Expression: &core.VariableExpression{
+									Right: &core.UnaryExpression{
+										Operator: "!",
+										Expression: &core.MemberAccessExpression{
+											Object: &core.VariableExpression{
 												Name:   "a",
 												Line:   1,
 												CharAt: 9,
 											},
+											PropertyIdentifier: core.Identifier{
+												Name:   "b",
+												Line:   1,
+												CharAt: 11,
+											},
 											Line:   1,
-											CharAt: 8,
-										},
-										PropertyIdentifier: core.Identifier{
-											Name:   "b",
-											Line:   1,
-											CharAt: 11,
+											CharAt: 9,
 										},
 										Line:   1,
 										CharAt: 8,
@@ -5146,65 +5344,741 @@ func TestParseExpression_Precedence(t *testing.T) {
 	}
 }
 
-func TestToAST_VariableDeclaration(t *testing.T) {
+func TestParseExpression_Operators(t *testing.T) {
 	cases := []struct {
 		name string
 		in   string
-		want []core.Statement
+		want core.Expression
 	}{
 		{
-			name: "parse variable declaration statement (without initialization)",
-			in:   `var a`,
-			want: []core.Statement{
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
-								Name:   "a",
+			name: "parse optional chain and null-coalescing",
+			in:   "a?.b[0]?.(c)??d",
+			want: &core.BinaryExpression{
+				Left: &core.ChainExpression{
+					Expression: &core.CallExpression{
+						Callee: &core.MemberAccessExpression{
+							Object: &core.MemberAccessExpression{
+								Object: &core.VariableExpression{
+									Name:   "a",
+									Line:   1,
+									CharAt: 1,
+								},
+								PropertyIdentifier: core.Identifier{
+									Name:   "b",
+									Line:   1,
+									CharAt: 4,
+								},
+								Optional: true,
+								Line:     1,
+								CharAt:   1,
+							},
+							PropertyExpression: &core.LiteralExpression{
+								Type:   core.LiteralTypeNumber,
+								Value:  "0",
 								Line:   1,
-								CharAt: 5,
+								CharAt: 6,
+							},
+							Compute: true,
+							Line:    1,
+							CharAt:  1,
+						},
+						Arguments: []core.Expression{
+							&core.VariableExpression{
+								Name:   "c",
+								Line:   1,
+								CharAt: 11,
 							},
-							Init:   nil,
-							Line:   1,
-							CharAt: 5,
 						},
+						Optional: true,
+						Line:     1,
+						CharAt:   1,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
+				Right: &core.VariableExpression{
+					Name:   "d",
+					Line:   1,
+					CharAt: 15,
+				},
+				Operator: core.Operator{
+					Symbol: "??",
+					Line:   1,
+					CharAt: 13,
+				},
+				Line:   1,
+				CharAt: 1,
 			},
 		},
 		{
-			name: "parse variable declaration statement (without initialization)",
-			in: `var a
-		 		 var b`,
-			want: []core.Statement{
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
-								Name:   "a",
-								Line:   1,
-								CharAt: 5,
-							},
-							Init:   nil,
-							Line:   1,
-							CharAt: 5,
-						},
-					},
+			name: "parse bitwise operators precedence",
+			in:   "a|b^c&d==1<<~e",
+			want: &core.BinaryExpression{
+				Left: &core.VariableExpression{
+					Name:   "a",
 					Line:   1,
 					CharAt: 1,
 				},
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
-								Name:   "b",
-								Line:   2,
-								CharAt: 5,
-							},
-							Init:   nil,
-							Line:   2,
+				Right: &core.BinaryExpression{
+					Left: &core.VariableExpression{
+						Name:   "b",
+						Line:   1,
+						CharAt: 3,
+					},
+					Right: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "c",
+							Line:   1,
+							CharAt: 5,
+						},
+						Right: &core.BinaryExpression{
+							Left: &core.VariableExpression{
+								Name:   "d",
+								Line:   1,
+								CharAt: 7,
+							},
+							Right: &core.BinaryExpression{
+								Left: &core.LiteralExpression{
+									Type:   core.LiteralTypeNumber,
+									Value:  "1",
+									Line:   1,
+									CharAt: 10,
+								},
+								Right: &core.UnaryExpression{
+									Operator: "~",
+									Expression: &core.VariableExpression{
+										Name:   "e",
+										Line:   1,
+										CharAt: 14,
+									},
+									Line:   1,
+									CharAt: 13,
+								},
+								Operator: core.Operator{
+									Symbol: "<<",
+									Line:   1,
+									CharAt: 11,
+								},
+								Line:   1,
+								CharAt: 10,
+							},
+							Operator: core.Operator{
+								Symbol: "==",
+								Line:   1,
+								CharAt: 8,
+							},
+							Line:   1,
+							CharAt: 7,
+						},
+						Operator: core.Operator{
+							Symbol: "&",
+							Line:   1,
+							CharAt: 6,
+						},
+						Line:   1,
+						CharAt: 5,
+					},
+					Operator: core.Operator{
+						Symbol: "^",
+						Line:   1,
+						CharAt: 4,
+					},
+					Line:   1,
+					CharAt: 3,
+				},
+				Operator: core.Operator{
+					Symbol: "|",
+					Line:   1,
+					CharAt: 2,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse strict equality operators",
+			in:   "a===b!==c",
+			want: &core.BinaryExpression{
+				Left: &core.BinaryExpression{
+					Left: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "b",
+						Line:   1,
+						CharAt: 5,
+					},
+					Operator: core.Operator{
+						Symbol: "===",
+						Line:   1,
+						CharAt: 2,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				Right: &core.VariableExpression{
+					Name:   "c",
+					Line:   1,
+					CharAt: 9,
+				},
+				Operator: core.Operator{
+					Symbol: "!==",
+					Line:   1,
+					CharAt: 6,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse unary minus and plus",
+			in:   "-a+ +1",
+			want: &core.BinaryExpression{
+				Left: &core.UnaryExpression{
+					Operator: "-",
+					Expression: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 2,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				Right: &core.UnaryExpression{
+					Operator: "+",
+					Expression: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 6,
+					},
+					Line:   1,
+					CharAt: 5,
+				},
+				Operator: core.Operator{
+					Symbol: "+",
+					Line:   1,
+					CharAt: 3,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse unary minus of group",
+			in:   "-(1-2)",
+			want: &core.UnaryExpression{
+				Operator: "-",
+				Expression: &core.BinaryExpression{
+					Left: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 3,
+					},
+					Right: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "2",
+						Line:   1,
+						CharAt: 5,
+					},
+					Operator: core.Operator{
+						Symbol: "-",
+						Line:   1,
+						CharAt: 4,
+					},
+					Group:  true,
+					Line:   1,
+					CharAt: 3,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse power binds tighter than unary minus",
+			in:   "-2**2",
+			want: &core.UnaryExpression{
+				Operator: "-",
+				Expression: &core.BinaryExpression{
+					Left: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "2",
+						Line:   1,
+						CharAt: 2,
+					},
+					Right: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "2",
+						Line:   1,
+						CharAt: 5,
+					},
+					Operator: core.Operator{
+						Symbol: "**",
+						Line:   1,
+						CharAt: 3,
+					},
+					Line:   1,
+					CharAt: 2,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse power is right-associative",
+			in:   "1**2**3*4",
+			want: &core.BinaryExpression{
+				Left: &core.BinaryExpression{
+					Left: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.BinaryExpression{
+						Left: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "2",
+							Line:   1,
+							CharAt: 4,
+						},
+						Right: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "3",
+							Line:   1,
+							CharAt: 7,
+						},
+						Operator: core.Operator{
+							Symbol: "**",
+							Line:   1,
+							CharAt: 5,
+						},
+						Line:   1,
+						CharAt: 4,
+					},
+					Operator: core.Operator{
+						Symbol: "**",
+						Line:   1,
+						CharAt: 2,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				Right: &core.LiteralExpression{
+					Type:   core.LiteralTypeNumber,
+					Value:  "4",
+					Line:   1,
+					CharAt: 9,
+				},
+				Operator: core.Operator{
+					Symbol: "*",
+					Line:   1,
+					CharAt: 8,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse conditional expression",
+			in:   "a || b ? 1 : c ? 2 : 3",
+			want: &core.ConditionalExpression{
+				Test: &core.BinaryExpression{
+					Left: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "b",
+						Line:   1,
+						CharAt: 6,
+					},
+					Operator: core.Operator{
+						Symbol: "||",
+						Line:   1,
+						CharAt: 3,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				Consequent: &core.LiteralExpression{
+					Type:   core.LiteralTypeNumber,
+					Value:  "1",
+					Line:   1,
+					CharAt: 10,
+				},
+				Alternate: &core.ConditionalExpression{
+					Test: &core.VariableExpression{
+						Name:   "c",
+						Line:   1,
+						CharAt: 14,
+					},
+					Consequent: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "2",
+						Line:   1,
+						CharAt: 18,
+					},
+					Alternate: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "3",
+						Line:   1,
+						CharAt: 22,
+					},
+					Line:   1,
+					CharAt: 14,
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse conditional expression in group",
+			in:   "(a ? 1 : 2)+3",
+			want: &core.BinaryExpression{
+				Left: &core.ConditionalExpression{
+					Test: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 2,
+					},
+					Consequent: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 6,
+					},
+					Alternate: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "2",
+						Line:   1,
+						CharAt: 10,
+					},
+					Line:   1,
+					CharAt: 2,
+				},
+				Right: &core.LiteralExpression{
+					Type:   core.LiteralTypeNumber,
+					Value:  "3",
+					Line:   1,
+					CharAt: 13,
+				},
+				Operator: core.Operator{
+					Symbol: "+",
+					Line:   1,
+					CharAt: 12,
+				},
+				Line:   1,
+				CharAt: 2,
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			exp, _, err := parseExpression(tokens)
+			require.Equal(t, err, nil)
+			require.Equal(t, tt.want, exp)
+		})
+	}
+}
+
+func TestParseExpression_OperatorsError(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{
+			name: "parse conditional expression without alternate",
+			in:   "a ? 1 2",
+			err:  fmt.Errorf("Parsing error: unexpected token '2', expected ':'. [1,7]"),
+		},
+		{
+			name: "parse unclosed group",
+			in:   "(1+2",
+			err:  fmt.Errorf("Parsing error: unexpected end of expression. [1,4]"),
+		},
+		{
+			name: "parse optional chain without property",
+			in:   "a?.+b",
+			err:  fmt.Errorf("Parsing error: unexpected token '+'. [1,4]"),
+		},
+		{
+			name: "parse rest parameter before another parameter",
+			in:   "func (...a,b){}",
+			err:  fmt.Errorf("Parsing error: rest parameter must be last. [1,11]"),
+		},
+		{
+			name: "parse named element in array",
+			in:   "[a:1]",
+			err:  fmt.Errorf("Parsing error: unexpected token ':', expected ']. [1,3]"),
+		},
+		{
+			name: "parse spread in member access",
+			in:   "a[...b]",
+			err:  fmt.Errorf("Parsing error: unexpected token '...'. [1,3]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			_, _, err = parseExpression(tokens)
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestParseExpression_String(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want core.Expression
+		err  error
+	}{
+		{
+			name: "parse string with escape sequences",
+			in:   `"a\tb\"c"`,
+			want: &core.LiteralExpression{
+				Type:   core.LiteralTypeString,
+				Value:  "a\tb\"c",
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse raw string",
+			in:   "`a\\n\n$b`",
+			want: &core.LiteralExpression{
+				Type:   core.LiteralTypeString,
+				Value:  "a\\n\n$b",
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse template expression",
+			in:   "`a${b}c${d+1}`",
+			want: &core.TemplateExpression{
+				Quasis: []string{"a", "c", ""},
+				Expressions: []core.Expression{
+					&core.VariableExpression{
+						Name:   "b",
+						Line:   1,
+						CharAt: 5,
+					},
+					&core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "d",
+							Line:   1,
+							CharAt: 10,
+						},
+						Right: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "1",
+							Line:   1,
+							CharAt: 12,
+						},
+						Operator: core.Operator{
+							Symbol: "+",
+							Line:   1,
+							CharAt: 11,
+						},
+						Line:   1,
+						CharAt: 10,
+					},
+				},
+				Line:   1,
+				CharAt: 1,
+			},
+		},
+		{
+			name: "parse template expression with empty expression",
+			in:   "`a${}`",
+			err:  fmt.Errorf("Parsing error: empty expression in template string. [1,1]"),
+		},
+		{
+			name: "parse template expression with unexpected token",
+			in:   "`${a b}`",
+			err:  fmt.Errorf("Parsing error: unexpected token 'b'. [1,6]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			if err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+			exp, _, err := parseExpression(tokens)
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+			require.Equal(t, err, nil)
+			require.Equal(t, tt.want, exp)
+		})
+	}
+}
+
+func TestToAST_VariableDeclaration(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+	}{
+		{
+			name: "parse constant declaration statement",
+			in:   `const a=1`,
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 7,
+							},
+							Init: &core.LiteralExpression{
+								Type:   core.LiteralTypeNumber,
+								Value:  "1",
+								Line:   1,
+								CharAt: 9,
+							},
+							Line:   1,
+							CharAt: 7,
+						},
+					},
+					Const:  true,
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse variable declaration statement with pattern",
+			in:   `var {a,b:[c=1,...d]}=e`,
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Pattern: &core.Pattern{
+									Object: true,
+									Keys:   []string{"a", "b"},
+									Elements: []core.Identifier{
+										{
+											Name:   "a",
+											Line:   1,
+											CharAt: 6,
+										},
+										{
+											Pattern: &core.Pattern{
+												Elements: []core.Identifier{
+													{
+														Name: "c",
+														Default: &core.LiteralExpression{
+															Type:   core.LiteralTypeNumber,
+															Value:  "1",
+															Line:   1,
+															CharAt: 13,
+														},
+														Line:   1,
+														CharAt: 11,
+													},
+													{
+														Name:   "d",
+														Rest:   true,
+														Line:   1,
+														CharAt: 18,
+													},
+												},
+												Line:   1,
+												CharAt: 10,
+											},
+											Line:   1,
+											CharAt: 10,
+										},
+									},
+									Line:   1,
+									CharAt: 5,
+								},
+								Line:   1,
+								CharAt: 5,
+							},
+							Init: &core.VariableExpression{
+								Name:   "e",
+								Line:   1,
+								CharAt: 22,
+							},
+							Line:   1,
+							CharAt: 5,
+						},
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse variable declaration statement (without initialization)",
+			in:   `var a`,
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 5,
+							},
+							Init:   nil,
+							Line:   1,
+							CharAt: 5,
+						},
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse variable declaration statement (without initialization)",
+			in: `var a
+		 		 var b`,
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 5,
+							},
+							Init:   nil,
+							Line:   1,
+							CharAt: 5,
+						},
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "b",
+								Line:   2,
+								CharAt: 5,
+							},
+							Init:   nil,
+							Line:   2,
 							CharAt: 5,
 						},
 					},
@@ -5581,6 +6455,49 @@ func TestToAST_FunctionDeclaration(t *testing.T) {
 		in   string
 		want []core.Statement
 	}{
+		{
+			name: "parse function returning multiple values",
+			in:   `func a(){return b,1}`,
+			want: []core.Statement{
+				core.FunctionDeclaration{
+					ID: core.Identifier{
+						Name:   "a",
+						Line:   1,
+						CharAt: 6,
+					},
+					Params: []core.Identifier{},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{
+							core.ReturnStatement{
+								Argument: &core.TupleExpression{
+									Elements: []core.Expression{
+										&core.VariableExpression{
+											Name:   "b",
+											Line:   1,
+											CharAt: 17,
+										},
+										&core.LiteralExpression{
+											Type:   core.LiteralTypeNumber,
+											Value:  "1",
+											Line:   1,
+											CharAt: 19,
+										},
+									},
+									Line:   1,
+									CharAt: 17,
+								},
+								Line:   1,
+								CharAt: 10,
+							},
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
 		{
 			name: "parse function #1",
 			in:   `func a(){}`,
@@ -5827,48 +6744,264 @@ func TestToAST_FunctionDeclaration(t *testing.T) {
 							},
 						},
 						Line:   1,
-						CharAt: 12,
+						CharAt: 12,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, _ := ToAST(tokens)
+			require.Equal(t, tt.want, ast)
+		})
+	}
+}
+
+func TestToAST_ExpressionStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+	}{
+		{
+			name: "parse core.ExpressionStatement #1",
+			in:   "a",
+			want: []core.Statement{
+				core.ExpressionStatement{
+					Expression: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 1,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse core.ExpressionStatement #2",
+			in:   "var a a",
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 5,
+							},
+							Init:   nil,
+							Line:   1,
+							CharAt: 5,
+						},
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				core.ExpressionStatement{
+					Expression: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					Line:   1,
+					CharAt: 7,
+				},
+			},
+		},
+		{
+			name: "parse core.ExpressionStatement #3",
+			in:   "var a,b=1 a",
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 5,
+							},
+							Init: &core.LiteralExpression{
+								Type:   core.LiteralTypeNumber,
+								Value:  "1",
+								Line:   1,
+								CharAt: 9,
+							},
+							Line:   1,
+							CharAt: 5,
+						},
+						{
+							ID: core.Identifier{
+								Name:   "b",
+								Line:   1,
+								CharAt: 7,
+							},
+							Init:   nil,
+							Line:   1,
+							CharAt: 7,
+						},
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				core.ExpressionStatement{
+					Expression: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 11,
+					},
+					Line:   1,
+					CharAt: 11,
+				},
+			},
+		},
+		{
+			name: "parse core.ExpressionStatement #4",
+			in:   "func a(){b}",
+			want: []core.Statement{
+				core.FunctionDeclaration{
+					ID: core.Identifier{
+						Name:   "a",
+						Line:   1,
+						CharAt: 6,
+					},
+					Params: []core.Identifier{},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{
+							core.ExpressionStatement{
+								Expression: &core.VariableExpression{
+									Name:   "b",
+									Line:   1,
+									CharAt: 10,
+								},
+								Line:   1,
+								CharAt: 10,
+							},
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, _ := ToAST(tokens)
+			require.Equal(t, tt.want, ast)
+		})
+	}
+}
+
+func TestToAST_AssignmentStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+	}{
+		{
+			name: "parse assignment statement of several variables",
+			in:   "a,b.c=d",
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.TupleExpression{
+						Elements: []core.Expression{
+							&core.VariableExpression{
+								Name:   "a",
+								Line:   1,
+								CharAt: 1,
+							},
+							&core.MemberAccessExpression{
+								Object: &core.VariableExpression{
+									Name:   "b",
+									Line:   1,
+									CharAt: 3,
+								},
+								PropertyIdentifier: core.Identifier{
+									Name:   "c",
+									Line:   1,
+									CharAt: 5,
+								},
+								Line:   1,
+								CharAt: 3,
+							},
+						},
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "d",
+						Line:   1,
+						CharAt: 7,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse assignment statement with pattern",
+			in:   "[a,b]=c",
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.Pattern{
+						Elements: []core.Identifier{
+							{
+								Name:   "a",
+								Line:   1,
+								CharAt: 2,
+							},
+							{
+								Name:   "b",
+								Line:   1,
+								CharAt: 4,
+							},
+						},
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "c",
+						Line:   1,
+						CharAt: 7,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
 			},
 		},
-	}
-	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			tokens, err := lexer.Lex(tt.in)
-			require.Equal(t, err, nil)
-			ast, _ := ToAST(tokens)
-			require.Equal(t, tt.want, ast)
-		})
-	}
-}
-
-func TestToAST_ExpressionStatement(t *testing.T) {
-	cases := []struct {
-		name string
-		in   string
-		want []core.Statement
-	}{
 		{
-			name: "parse core.ExpressionStatement #1",
-			in:   "a",
+			name: "parse assignment statement #1",
+			in:   "a=b",
 			want: []core.Statement{
-				core.ExpressionStatement{
-					Expression: &core.VariableExpression{
+				core.AssignmentStatement{
+					Left: &core.VariableExpression{
 						Name:   "a",
 						Line:   1,
 						CharAt: 1,
 					},
+					Right: &core.VariableExpression{
+						Name:   "b",
+						Line:   1,
+						CharAt: 3,
+					},
 					Line:   1,
 					CharAt: 1,
 				},
 			},
 		},
 		{
-			name: "parse core.ExpressionStatement #2",
-			in:   "var a a",
+			name: "parse assignment statement #2",
+			in:   "a:=b",
 			want: []core.Statement{
 				core.VariableDeclaration{
 					Declarations: []core.VariableDeclarator{
@@ -5876,30 +7009,66 @@ func TestToAST_ExpressionStatement(t *testing.T) {
 							ID: core.Identifier{
 								Name:   "a",
 								Line:   1,
-								CharAt: 5,
+								CharAt: 1,
+							},
+							Init: &core.VariableExpression{
+								Name:   "b",
+								Line:   1,
+								CharAt: 4,
 							},
-							Init:   nil,
 							Line:   1,
-							CharAt: 5,
+							CharAt: 1,
 						},
 					},
 					Line:   1,
 					CharAt: 1,
 				},
-				core.ExpressionStatement{
-					Expression: &core.VariableExpression{
-						Name:   "a",
-						Line:   1,
-						CharAt: 7,
+			},
+		},
+		{
+			name: "parse assignment statement #3",
+			in:   "a,b:=c,d",
+			want: []core.Statement{
+				core.VariableDeclaration{
+					Declarations: []core.VariableDeclarator{
+						{
+							ID: core.Identifier{
+								Name:   "a",
+								Line:   1,
+								CharAt: 1,
+							},
+							Init: &core.VariableExpression{
+								Name:   "c",
+								Line:   1,
+								CharAt: 6,
+							},
+							Line:   1,
+							CharAt: 1,
+						},
+						{
+							ID: core.Identifier{
+								Name:   "b",
+								Line:   1,
+								CharAt: 3,
+							},
+							Init: &core.VariableExpression{
+								Name:   "d",
+								Line:   1,
+								CharAt: 8,
+							},
+							Line:   1,
+							CharAt: 3,
+						},
 					},
 					Line:   1,
-					CharAt: 7,
+					CharAt: 1,
 				},
 			},
 		},
 		{
-			name: "parse core.ExpressionStatement #3",
-			in:   "var a,b=1 a",
+			name: "parse call expression #4",
+			in: `var a=1
+			     a=(b)`,
 			want: []core.Statement{
 				core.VariableDeclaration{
 					Declarations: []core.VariableDeclarator{
@@ -5913,65 +7082,234 @@ func TestToAST_ExpressionStatement(t *testing.T) {
 								Type:   core.LiteralTypeNumber,
 								Value:  "1",
 								Line:   1,
-								CharAt: 9,
+								CharAt: 7,
 							},
 							Line:   1,
 							CharAt: 5,
 						},
-						{
-							ID: core.Identifier{
-								Name:   "b",
-								Line:   1,
-								CharAt: 7,
-							},
-							Init:   nil,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+				core.AssignmentStatement{
+					Left: &core.VariableExpression{
+						Name:   "a",
+						Line:   2,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "b",
+						Line:   2,
+						CharAt: 4,
+					},
+					Line:   2,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse assignment statement #5",
+			in:   "a.b=c",
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.MemberAccessExpression{
+						Object: &core.VariableExpression{
+							Name:   "a",
 							Line:   1,
-							CharAt: 7,
+							CharAt: 1,
+						},
+						PropertyIdentifier: core.Identifier{
+							Name:   "b",
+							Line:   1,
+							CharAt: 3,
 						},
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "c",
+						Line:   1,
+						CharAt: 5,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
-				core.ExpressionStatement{
-					Expression: &core.VariableExpression{
-						Name:   "a",
+			},
+		},
+		{
+			name: "parse assignment statement #6",
+			in:   "a.b=c",
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.MemberAccessExpression{
+						Object: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 1,
+						},
+						PropertyIdentifier: core.Identifier{
+							Name:   "b",
+							Line:   1,
+							CharAt: 3,
+						},
 						Line:   1,
-						CharAt: 11,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "c",
+						Line:   1,
+						CharAt: 5,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse compound assignment statement",
+			in:   "a.b*=c",
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.MemberAccessExpression{
+						Object: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 1,
+						},
+						PropertyIdentifier: core.Identifier{
+							Name:   "b",
+							Line:   1,
+							CharAt: 3,
+						},
+						Line:   1,
+						CharAt: 1,
+					},
+					Right: &core.VariableExpression{
+						Name:   "c",
+						Line:   1,
+						CharAt: 6,
+					},
+					Operator: &core.Operator{
+						Symbol: "*=",
+						Line:   1,
+						CharAt: 4,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse increment statement",
+			in: `a[0]++
+b--`,
+			want: []core.Statement{
+				core.AssignmentStatement{
+					Left: &core.MemberAccessExpression{
+						Object: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 1,
+						},
+						PropertyExpression: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "0",
+							Line:   1,
+							CharAt: 3,
+						},
+						Compute: true,
+						Line:    1,
+						CharAt:  1,
+					},
+					Right: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   1,
+						CharAt: 5,
+					},
+					Operator: &core.Operator{
+						Symbol: "++",
+						Line:   1,
+						CharAt: 5,
 					},
 					Line:   1,
-					CharAt: 11,
+					CharAt: 1,
+				},
+				core.AssignmentStatement{
+					Left: &core.VariableExpression{
+						Name:   "b",
+						Line:   2,
+						CharAt: 1,
+					},
+					Right: &core.LiteralExpression{
+						Type:   core.LiteralTypeNumber,
+						Value:  "1",
+						Line:   2,
+						CharAt: 2,
+					},
+					Operator: &core.Operator{
+						Symbol: "--",
+						Line:   2,
+						CharAt: 2,
+					},
+					Line:   2,
+					CharAt: 1,
 				},
 			},
 		},
 		{
-			name: "parse core.ExpressionStatement #4",
-			in:   "func a(){b}",
+			name: "parse assignment statement with member access at the end",
+			in: `a=b+c[0]
+d`,
 			want: []core.Statement{
-				core.FunctionDeclaration{
-					ID: core.Identifier{
+				core.AssignmentStatement{
+					Left: &core.VariableExpression{
 						Name:   "a",
 						Line:   1,
-						CharAt: 6,
+						CharAt: 1,
 					},
-					Params: []core.Identifier{},
-					Body: core.BlockStatement{
-						Statements: []core.Statement{
-							core.ExpressionStatement{
-								Expression: &core.VariableExpression{
-									Name:   "b",
-									Line:   1,
-									CharAt: 10,
-								},
+					Right: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "b",
+							Line:   1,
+							CharAt: 3,
+						},
+						Right: &core.MemberAccessExpression{
+							Object: &core.VariableExpression{
+								Name:   "c",
 								Line:   1,
-								CharAt: 10,
+								CharAt: 5,
+							},
+							PropertyExpression: &core.LiteralExpression{
+								Type:   core.LiteralTypeNumber,
+								Value:  "0",
+								Line:   1,
+								CharAt: 7,
 							},
+							Compute: true,
+							Line:    1,
+							CharAt:  5,
+						},
+						Operator: core.Operator{
+							Symbol: "+",
+							Line:   1,
+							CharAt: 4,
 						},
 						Line:   1,
-						CharAt: 9,
+						CharAt: 3,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
+				core.ExpressionStatement{
+					Expression: &core.VariableExpression{
+						Name:   "d",
+						Line:   2,
+						CharAt: 1,
+					},
+					Line:   2,
+					CharAt: 1,
+				},
 			},
 		},
 	}
@@ -5985,26 +7323,62 @@ func TestToAST_ExpressionStatement(t *testing.T) {
 	}
 }
 
-func TestToAST_AssignmentStatement(t *testing.T) {
+func TestToAST_IfStatement(t *testing.T) {
 	cases := []struct {
 		name string
 		in   string
 		want []core.Statement
 	}{
 		{
-			name: "parse assignment statement #1",
-			in:   "a=b",
+			name: "parse if statement #1",
+			in:   `if a:=2;a>0{}`,
 			want: []core.Statement{
-				core.AssignmentStatement{
-					Left: &core.VariableExpression{
-						Name:   "a",
+				core.IfStatement{
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 4,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "2",
+									Line:   1,
+									CharAt: 7,
+								},
+								Line:   1,
+								CharAt: 4,
+							},
+						},
 						Line:   1,
-						CharAt: 1,
+						CharAt: 4,
 					},
-					Right: &core.VariableExpression{
-						Name:   "b",
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 9,
+						},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "0",
+							Line:   1,
+							CharAt: 11,
+						},
+						Operator: core.Operator{
+							Symbol: ">",
+							Line:   1,
+							CharAt: 10,
+						},
 						Line:   1,
-						CharAt: 3,
+						CharAt: 9,
+					},
+					Consequent: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     12,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6012,25 +7386,164 @@ func TestToAST_AssignmentStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse assignment statement #2",
-			in:   "a:=b",
+			name: "parse if statement #2",
+			in: `if a:=2;a>0{
+				   var b=2
+				 }`,
 			want: []core.Statement{
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
+				core.IfStatement{
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 9,
+						},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "0",
+							Line:   1,
+							CharAt: 11,
+						},
+						Operator: core.Operator{
+							Symbol: ">",
+							Line:   1,
+							CharAt: 10,
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+					Consequent: core.BlockStatement{
+						Statements: []core.Statement{
+							core.VariableDeclaration{
+								Declarations: []core.VariableDeclarator{
+									{
+										ID: core.Identifier{
+											Name:   "b",
+											Line:   2,
+											CharAt: 5,
+										},
+										Init: &core.LiteralExpression{
+											Type:   "number",
+											Value:  "2",
+											Line:   2,
+											CharAt: 7,
+										},
+										Line:   2,
+										CharAt: 5,
+									},
+								},
+								Line:   2,
+								CharAt: 1,
+							},
+						},
+						Line:   1,
+						CharAt: 12,
+					},
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 4,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "2",
+									Line:   1,
+									CharAt: 7,
+								},
+								Line:   1,
+								CharAt: 4,
+							},
+						},
+						Line:   1,
+						CharAt: 4,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse if statement #4",
+			in:   `if a:=2;a>0{}elif a>2{}`,
+			want: []core.Statement{
+				core.IfStatement{
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 9,
+						},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "0",
+							Line:   1,
+							CharAt: 11,
+						},
+						Operator: core.Operator{
+							Symbol: ">",
+							Line:   1,
+							CharAt: 10,
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+					Consequent: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     12,
+					},
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 4,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "2",
+									Line:   1,
+									CharAt: 7,
+								},
+								Line:   1,
+								CharAt: 4,
+							},
+						},
+						Line:   1,
+						CharAt: 4,
+					},
+					Alternate: &core.IfStatement{
+						Test: &core.BinaryExpression{
+							Left: &core.VariableExpression{
 								Name:   "a",
 								Line:   1,
-								CharAt: 1,
+								CharAt: 19,
 							},
-							Init: &core.VariableExpression{
-								Name:   "b",
+							Right: &core.LiteralExpression{
+								Type:   "number",
+								Value:  "2",
 								Line:   1,
-								CharAt: 4,
+								CharAt: 21,
+							},
+							Operator: core.Operator{
+								Symbol: ">",
+								Line:   1,
+								CharAt: 20,
 							},
 							Line:   1,
-							CharAt: 1,
+							CharAt: 19,
+						},
+						Consequent: core.BlockStatement{
+							Statements: []core.Statement{},
+							Line:       1,
+							CharAt:     22,
 						},
+						Line:   1,
+						CharAt: 14,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6038,139 +7551,236 @@ func TestToAST_AssignmentStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse assignment statement #3",
-			in:   "a,b:=c,d",
+			name: "parse if statement #5",
+			in:   `if a:=2;a>0{}elif a>2{}elif a>1{}`,
 			want: []core.Statement{
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
-								Name:   "a",
+				core.IfStatement{
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "a",
+							Line:   1,
+							CharAt: 9,
+						},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "0",
+							Line:   1,
+							CharAt: 11,
+						},
+						Operator: core.Operator{
+							Symbol: ">",
+							Line:   1,
+							CharAt: 10,
+						},
+						Line:   1,
+						CharAt: 9,
+					},
+					Consequent: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     12,
+					},
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 4,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "2",
+									Line:   1,
+									CharAt: 7,
+								},
 								Line:   1,
-								CharAt: 1,
+								CharAt: 4,
 							},
-							Init: &core.VariableExpression{
-								Name:   "c",
+						},
+						Line:   1,
+						CharAt: 4,
+					},
+					Alternate: &core.IfStatement{
+						Test: &core.BinaryExpression{
+							Left: &core.VariableExpression{
+								Name:   "a",
 								Line:   1,
-								CharAt: 6,
+								CharAt: 19,
 							},
-							Line:   1,
-							CharAt: 1,
-						},
-						{
-							ID: core.Identifier{
-								Name:   "b",
+							Right: &core.LiteralExpression{
+								Type:   "number",
+								Value:  "2",
 								Line:   1,
-								CharAt: 3,
+								CharAt: 21,
 							},
-							Init: &core.VariableExpression{
-								Name:   "d",
+							Operator: core.Operator{
+								Symbol: ">",
 								Line:   1,
-								CharAt: 8,
+								CharAt: 20,
 							},
 							Line:   1,
-							CharAt: 3,
+							CharAt: 19,
 						},
-					},
-					Line:   1,
-					CharAt: 1,
-				},
-			},
-		},
-		{
-			name: "parse call expression #4",
-			in: `var a=1
-			     a=(b)`,
-			want: []core.Statement{
-				core.VariableDeclaration{
-					Declarations: []core.VariableDeclarator{
-						{
-							ID: core.Identifier{
-								Name:   "a",
+						Consequent: core.BlockStatement{
+							Statements: []core.Statement{},
+							Line:       1,
+							CharAt:     22,
+						},
+						Alternate: &core.IfStatement{
+							Test: &core.BinaryExpression{
+								Left: &core.VariableExpression{
+									Name:   "a",
+									Line:   1,
+									CharAt: 29,
+								},
+								Right: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "1",
+									Line:   1,
+									CharAt: 31,
+								},
+								Operator: core.Operator{
+									Symbol: ">",
+									Line:   1,
+									CharAt: 30,
+								},
 								Line:   1,
-								CharAt: 5,
+								CharAt: 29,
 							},
-							Init: &core.LiteralExpression{
-								Type:   core.LiteralTypeNumber,
-								Value:  "1",
-								Line:   1,
-								CharAt: 7,
+							Consequent: core.BlockStatement{
+								Statements: []core.Statement{},
+								Line:       1,
+								CharAt:     32,
 							},
 							Line:   1,
-							CharAt: 5,
+							CharAt: 24,
 						},
+						Line:   1,
+						CharAt: 14,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
-				core.AssignmentStatement{
-					Left: &core.VariableExpression{
-						Name:   "a",
-						Line:   2,
-						CharAt: 1,
-					},
-					Right: &core.VariableExpression{
-						Name:   "b",
-						Line:   2,
-						CharAt: 4,
-					},
-					Line:   2,
-					CharAt: 1,
-				},
 			},
 		},
 		{
-			name: "parse assignment statement #5",
-			in:   "a.b=c",
+			name: "parse if statement #6",
+			in:   `if a:=2;a>0{}elif a>2{}elif a>1{}else{}`,
 			want: []core.Statement{
-				core.AssignmentStatement{
-					Left: &core.MemberAccessExpression{
-						Object: &core.VariableExpression{
+				core.IfStatement{
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
 							Name:   "a",
 							Line:   1,
-							CharAt: 1,
+							CharAt: 9,
 						},
-						PropertyIdentifier: core.Identifier{
-							Name:   "b",
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "0",
 							Line:   1,
-							CharAt: 3,
+							CharAt: 11,
+						},
+						Operator: core.Operator{
+							Symbol: ">",
+							Line:   1,
+							CharAt: 10,
 						},
 						Line:   1,
-						CharAt: 1,
+						CharAt: 9,
 					},
-					Right: &core.VariableExpression{
-						Name:   "c",
+					Consequent: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     12,
+					},
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 4,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "2",
+									Line:   1,
+									CharAt: 7,
+								},
+								Line:   1,
+								CharAt: 4,
+							},
+						},
 						Line:   1,
-						CharAt: 5,
+						CharAt: 4,
 					},
-					Line:   1,
-					CharAt: 1,
-				},
-			},
-		},
-		{
-			name: "parse assignment statement #6",
-			in:   "a.b=c",
-			want: []core.Statement{
-				core.AssignmentStatement{
-					Left: &core.MemberAccessExpression{
-						Object: &core.VariableExpression{
-							Name:   "a",
+					Alternate: &core.IfStatement{
+						Test: &core.BinaryExpression{
+							Left: &core.VariableExpression{
+								Name:   "a",
+								Line:   1,
+								CharAt: 19,
+							},
+							Right: &core.LiteralExpression{
+								Type:   "number",
+								Value:  "2",
+								Line:   1,
+								CharAt: 21,
+							},
+							Operator: core.Operator{
+								Symbol: ">",
+								Line:   1,
+								CharAt: 20,
+							},
 							Line:   1,
-							CharAt: 1,
+							CharAt: 19,
 						},
-						PropertyIdentifier: core.Identifier{
-							Name:   "b",
+						Consequent: core.BlockStatement{
+							Statements: []core.Statement{},
+							Line:       1,
+							CharAt:     22,
+						},
+						Alternate: &core.IfStatement{
+							Test: &core.BinaryExpression{
+								Left: &core.VariableExpression{
+									Name:   "a",
+									Line:   1,
+									CharAt: 29,
+								},
+								Right: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "1",
+									Line:   1,
+									CharAt: 31,
+								},
+								Operator: core.Operator{
+									Symbol: ">",
+									Line:   1,
+									CharAt: 30,
+								},
+								Line:   1,
+								CharAt: 29,
+							},
+							Consequent: core.BlockStatement{
+								Statements: []core.Statement{},
+								Line:       1,
+								CharAt:     32,
+							},
+							Alternate: &core.IfStatement{
+								Consequent: core.BlockStatement{
+									Statements: []core.Statement{},
+									Line:       1,
+									CharAt:     38,
+								},
+								Line:   1,
+								CharAt: 34,
+							},
 							Line:   1,
-							CharAt: 3,
+							CharAt: 24,
 						},
 						Line:   1,
-						CharAt: 1,
-					},
-					Right: &core.VariableExpression{
-						Name:   "c",
-						Line:   1,
-						CharAt: 5,
+						CharAt: 14,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6188,142 +7798,91 @@ func TestToAST_AssignmentStatement(t *testing.T) {
 	}
 }
 
-func TestToAST_IfStatement(t *testing.T) {
+func TestToAST_ForStatement(t *testing.T) {
 	cases := []struct {
 		name string
 		in   string
 		want []core.Statement
 	}{
 		{
-			name: "parse if statement #1",
-			in:   `if a:=2;a>0{}`,
+			name: "parse for statement #1",
+			in:   `for i:=0;i<1;i=i+1{}`,
 			want: []core.Statement{
-				core.IfStatement{
+				core.ForStatement{
 					Init: &core.VariableDeclaration{
 						Declarations: []core.VariableDeclarator{
 							{
 								ID: core.Identifier{
-									Name:   "a",
+									Name:   "i",
 									Line:   1,
-									CharAt: 4,
+									CharAt: 5,
 								},
 								Init: &core.LiteralExpression{
 									Type:   "number",
-									Value:  "2",
+									Value:  "0",
 									Line:   1,
-									CharAt: 7,
+									CharAt: 8,
 								},
 								Line:   1,
-								CharAt: 4,
+								CharAt: 5,
 							},
 						},
 						Line:   1,
-						CharAt: 4,
+						CharAt: 5,
 					},
 					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
-							Name:   "a",
+							Name:   "i",
 							Line:   1,
-							CharAt: 9,
+							CharAt: 10,
 						},
 						Right: &core.LiteralExpression{
 							Type:   "number",
-							Value:  "0",
+							Value:  "1",
 							Line:   1,
-							CharAt: 11,
+							CharAt: 12,
 						},
 						Operator: core.Operator{
-							Symbol: ">",
+							Symbol: "<",
 							Line:   1,
-							CharAt: 10,
+							CharAt: 11,
 						},
 						Line:   1,
-						CharAt: 9,
-					},
-					Consequent: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     12,
+						CharAt: 10,
 					},
-					Line:   1,
-					CharAt: 1,
-				},
-			},
-		},
-		{
-			name: "parse if statement #2",
-			in: `if a:=2;a>0{
-				   var b=2
-				 }`,
-			want: []core.Statement{
-				core.IfStatement{
-					Test: &core.BinaryExpression{
+					Update: &core.AssignmentStatement{
 						Left: &core.VariableExpression{
-							Name:   "a",
-							Line:   1,
-							CharAt: 9,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "0",
-							Line:   1,
-							CharAt: 11,
-						},
-						Operator: core.Operator{
-							Symbol: ">",
+							Name:   "i",
 							Line:   1,
-							CharAt: 10,
+							CharAt: 14,
 						},
-						Line:   1,
-						CharAt: 9,
-					},
-					Consequent: core.BlockStatement{
-						Statements: []core.Statement{
-							core.VariableDeclaration{
-								Declarations: []core.VariableDeclarator{
-									{
-										ID: core.Identifier{
-											Name:   "b",
-											Line:   2,
-											CharAt: 5,
-										},
-										Init: &core.LiteralExpression{
-											Type:   "number",
-											Value:  "2",
-											Line:   2,
-											CharAt: 7,
-										},
-										Line:   2,
-										CharAt: 5,
-									},
-								},
-								Line:   2,
-								CharAt: 1,
+						Right: &core.BinaryExpression{
+							Left: &core.VariableExpression{
+								Name:   "i",
+								Line:   1,
+								CharAt: 16,
 							},
-						},
-						Line:   1,
-						CharAt: 12,
-					},
-					Init: &core.VariableDeclaration{
-						Declarations: []core.VariableDeclarator{
-							{
-								ID: core.Identifier{
-									Name:   "a",
-									Line:   1,
-									CharAt: 4,
-								},
-								Init: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "2",
-									Line:   1,
-									CharAt: 7,
-								},
+							Right: &core.LiteralExpression{
+								Type:   "number",
+								Value:  "1",
 								Line:   1,
-								CharAt: 4,
+								CharAt: 18,
+							},
+							Operator: core.Operator{
+								Symbol: "+",
+								Line:   1,
+								CharAt: 17,
 							},
+							Line:   1,
+							CharAt: 16,
 						},
 						Line:   1,
-						CharAt: 4,
+						CharAt: 14,
+					},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     19,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6331,84 +7890,63 @@ func TestToAST_IfStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse if statement #4",
-			in:   `if a:=2;a>0{}elif a>2{}`,
+			name: "parse for statement #2",
+			in:   `for ;i<1;i=i+1{}`,
 			want: []core.Statement{
-				core.IfStatement{
+				core.ForStatement{
 					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
-							Name:   "a",
+							Name:   "i",
 							Line:   1,
-							CharAt: 9,
+							CharAt: 6,
 						},
 						Right: &core.LiteralExpression{
 							Type:   "number",
-							Value:  "0",
+							Value:  "1",
 							Line:   1,
-							CharAt: 11,
+							CharAt: 8,
 						},
 						Operator: core.Operator{
-							Symbol: ">",
+							Symbol: "<",
 							Line:   1,
-							CharAt: 10,
+							CharAt: 7,
 						},
 						Line:   1,
-						CharAt: 9,
-					},
-					Consequent: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     12,
+						CharAt: 6,
 					},
-					Init: &core.VariableDeclaration{
-						Declarations: []core.VariableDeclarator{
-							{
-								ID: core.Identifier{
-									Name:   "a",
-									Line:   1,
-									CharAt: 4,
-								},
-								Init: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "2",
-									Line:   1,
-									CharAt: 7,
-								},
-								Line:   1,
-								CharAt: 4,
-							},
+					Update: &core.AssignmentStatement{
+						Left: &core.VariableExpression{
+							Name:   "i",
+							Line:   1,
+							CharAt: 10,
 						},
-						Line:   1,
-						CharAt: 4,
-					},
-					Alternate: &core.IfStatement{
-						Test: &core.BinaryExpression{
+						Right: &core.BinaryExpression{
 							Left: &core.VariableExpression{
-								Name:   "a",
+								Name:   "i",
 								Line:   1,
-								CharAt: 19,
+								CharAt: 12,
 							},
 							Right: &core.LiteralExpression{
 								Type:   "number",
-								Value:  "2",
+								Value:  "1",
 								Line:   1,
-								CharAt: 21,
+								CharAt: 14,
 							},
 							Operator: core.Operator{
-								Symbol: ">",
+								Symbol: "+",
 								Line:   1,
-								CharAt: 20,
+								CharAt: 13,
 							},
 							Line:   1,
-							CharAt: 19,
-						},
-						Consequent: core.BlockStatement{
-							Statements: []core.Statement{},
-							Line:       1,
-							CharAt:     22,
+							CharAt: 12,
 						},
 						Line:   1,
-						CharAt: 14,
+						CharAt: 10,
+					},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     15,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6416,113 +7954,63 @@ func TestToAST_IfStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse if statement #5",
-			in:   `if a:=2;a>0{}elif a>2{}elif a>1{}`,
+			name: "parse for statement #3",
+			in:   `for i<1;i=i+1{}`,
 			want: []core.Statement{
-				core.IfStatement{
+				core.ForStatement{
 					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
-							Name:   "a",
+							Name:   "i",
 							Line:   1,
-							CharAt: 9,
+							CharAt: 5,
 						},
 						Right: &core.LiteralExpression{
 							Type:   "number",
-							Value:  "0",
+							Value:  "1",
 							Line:   1,
-							CharAt: 11,
+							CharAt: 7,
 						},
 						Operator: core.Operator{
-							Symbol: ">",
+							Symbol: "<",
 							Line:   1,
-							CharAt: 10,
+							CharAt: 6,
 						},
 						Line:   1,
-						CharAt: 9,
-					},
-					Consequent: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     12,
+						CharAt: 5,
 					},
-					Init: &core.VariableDeclaration{
-						Declarations: []core.VariableDeclarator{
-							{
-								ID: core.Identifier{
-									Name:   "a",
-									Line:   1,
-									CharAt: 4,
-								},
-								Init: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "2",
-									Line:   1,
-									CharAt: 7,
-								},
-								Line:   1,
-								CharAt: 4,
-							},
+					Update: &core.AssignmentStatement{
+						Left: &core.VariableExpression{
+							Name:   "i",
+							Line:   1,
+							CharAt: 9,
 						},
-						Line:   1,
-						CharAt: 4,
-					},
-					Alternate: &core.IfStatement{
-						Test: &core.BinaryExpression{
+						Right: &core.BinaryExpression{
 							Left: &core.VariableExpression{
-								Name:   "a",
+								Name:   "i",
 								Line:   1,
-								CharAt: 19,
+								CharAt: 11,
 							},
 							Right: &core.LiteralExpression{
 								Type:   "number",
-								Value:  "2",
+								Value:  "1",
 								Line:   1,
-								CharAt: 21,
+								CharAt: 13,
 							},
 							Operator: core.Operator{
-								Symbol: ">",
-								Line:   1,
-								CharAt: 20,
-							},
-							Line:   1,
-							CharAt: 19,
-						},
-						Consequent: core.BlockStatement{
-							Statements: []core.Statement{},
-							Line:       1,
-							CharAt:     22,
-						},
-						Alternate: &core.IfStatement{
-							Test: &core.BinaryExpression{
-								Left: &core.VariableExpression{
-									Name:   "a",
-									Line:   1,
-									CharAt: 29,
-								},
-								Right: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "1",
-									Line:   1,
-									CharAt: 31,
-								},
-								Operator: core.Operator{
-									Symbol: ">",
-									Line:   1,
-									CharAt: 30,
-								},
+								Symbol: "+",
 								Line:   1,
-								CharAt: 29,
-							},
-							Consequent: core.BlockStatement{
-								Statements: []core.Statement{},
-								Line:       1,
-								CharAt:     32,
+								CharAt: 12,
 							},
 							Line:   1,
-							CharAt: 24,
+							CharAt: 11,
 						},
 						Line:   1,
-						CharAt: 14,
+						CharAt: 9,
+					},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     14,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6530,148 +8018,129 @@ func TestToAST_IfStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse if statement #6",
-			in:   `if a:=2;a>0{}elif a>2{}elif a>1{}else{}`,
+			name: "parse for statement #4",
+			in:   `for i:=0;;i=i+1{}`,
 			want: []core.Statement{
-				core.IfStatement{
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "a",
-							Line:   1,
-							CharAt: 9,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "0",
-							Line:   1,
-							CharAt: 11,
-						},
-						Operator: core.Operator{
-							Symbol: ">",
-							Line:   1,
-							CharAt: 10,
-						},
-						Line:   1,
-						CharAt: 9,
-					},
-					Consequent: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     12,
-					},
+				core.ForStatement{
 					Init: &core.VariableDeclaration{
 						Declarations: []core.VariableDeclarator{
 							{
 								ID: core.Identifier{
-									Name:   "a",
+									Name:   "i",
 									Line:   1,
-									CharAt: 4,
+									CharAt: 5,
 								},
 								Init: &core.LiteralExpression{
 									Type:   "number",
-									Value:  "2",
+									Value:  "0",
 									Line:   1,
-									CharAt: 7,
+									CharAt: 8,
 								},
 								Line:   1,
-								CharAt: 4,
+								CharAt: 5,
 							},
 						},
 						Line:   1,
-						CharAt: 4,
+						CharAt: 5,
 					},
-					Alternate: &core.IfStatement{
-						Test: &core.BinaryExpression{
+					Update: &core.AssignmentStatement{
+						Left: &core.VariableExpression{
+							Name:   "i",
+							Line:   1,
+							CharAt: 11,
+						},
+						Right: &core.BinaryExpression{
 							Left: &core.VariableExpression{
-								Name:   "a",
+								Name:   "i",
 								Line:   1,
-								CharAt: 19,
+								CharAt: 13,
 							},
 							Right: &core.LiteralExpression{
 								Type:   "number",
-								Value:  "2",
+								Value:  "1",
 								Line:   1,
-								CharAt: 21,
+								CharAt: 15,
 							},
 							Operator: core.Operator{
-								Symbol: ">",
+								Symbol: "+",
 								Line:   1,
-								CharAt: 20,
+								CharAt: 14,
 							},
 							Line:   1,
-							CharAt: 19,
-						},
-						Consequent: core.BlockStatement{
-							Statements: []core.Statement{},
-							Line:       1,
-							CharAt:     22,
+							CharAt: 13,
 						},
-						Alternate: &core.IfStatement{
-							Test: &core.BinaryExpression{
-								Left: &core.VariableExpression{
-									Name:   "a",
+						Line:   1,
+						CharAt: 11,
+					},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     16,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse for statement #5",
+			in:   `for i:=0;i<1;{}`,
+			want: []core.Statement{
+				core.ForStatement{
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "i",
 									Line:   1,
-									CharAt: 29,
+									CharAt: 5,
 								},
-								Right: &core.LiteralExpression{
+								Init: &core.LiteralExpression{
 									Type:   "number",
-									Value:  "1",
-									Line:   1,
-									CharAt: 31,
-								},
-								Operator: core.Operator{
-									Symbol: ">",
+									Value:  "0",
 									Line:   1,
-									CharAt: 30,
-								},
-								Line:   1,
-								CharAt: 29,
-							},
-							Consequent: core.BlockStatement{
-								Statements: []core.Statement{},
-								Line:       1,
-								CharAt:     32,
-							},
-							Alternate: &core.IfStatement{
-								Consequent: core.BlockStatement{
-									Statements: []core.Statement{},
-									Line:       1,
-									CharAt:     38,
+									CharAt: 8,
 								},
 								Line:   1,
-								CharAt: 34,
+								CharAt: 5,
 							},
+						},
+						Line:   1,
+						CharAt: 5,
+					},
+					Test: &core.BinaryExpression{
+						Left: &core.VariableExpression{
+							Name:   "i",
 							Line:   1,
-							CharAt: 24,
+							CharAt: 10,
+						},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "1",
+							Line:   1,
+							CharAt: 12,
+						},
+						Operator: core.Operator{
+							Symbol: "<",
+							Line:   1,
+							CharAt: 11,
 						},
 						Line:   1,
-						CharAt: 14,
+						CharAt: 10,
+					},
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     14,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
 			},
 		},
-	}
-	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			tokens, err := lexer.Lex(tt.in)
-			require.Equal(t, err, nil)
-			ast, _ := ToAST(tokens)
-			require.Equal(t, tt.want, ast)
-		})
-	}
-}
-
-func TestToAST_ForStatement(t *testing.T) {
-	cases := []struct {
-		name string
-		in   string
-		want []core.Statement
-	}{
 		{
-			name: "parse for statement #1",
-			in:   `for i:=0;i<1;i=i+1{}`,
+			name: "parse for statement #6",
+			in:   `for i:=0;i<1{}`,
 			want: []core.Statement{
 				core.ForStatement{
 					Init: &core.VariableDeclaration{
@@ -6715,39 +8184,46 @@ func TestToAST_ForStatement(t *testing.T) {
 						Line:   1,
 						CharAt: 10,
 					},
-					Update: &core.AssignmentStatement{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 14,
-						},
-						Right: &core.BinaryExpression{
-							Left: &core.VariableExpression{
-								Name:   "i",
-								Line:   1,
-								CharAt: 16,
-							},
-							Right: &core.LiteralExpression{
-								Type:   "number",
-								Value:  "1",
-								Line:   1,
-								CharAt: 18,
-							},
-							Operator: core.Operator{
-								Symbol: "+",
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     13,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse for statement #7",
+			in:   `for i:=0;;{}`,
+			want: []core.Statement{
+				core.ForStatement{
+					Init: &core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "i",
+									Line:   1,
+									CharAt: 5,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "0",
+									Line:   1,
+									CharAt: 8,
+								},
 								Line:   1,
-								CharAt: 17,
+								CharAt: 5,
 							},
-							Line:   1,
-							CharAt: 16,
 						},
 						Line:   1,
-						CharAt: 14,
+						CharAt: 5,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     19,
+						CharAt:     11,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6755,63 +8231,69 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #2",
-			in:   `for ;i<1;i=i+1{}`,
+			name: "parse for statement #8",
+			in:   `for i<1{}`,
 			want: []core.Statement{
 				core.ForStatement{
 					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
 							Name:   "i",
 							Line:   1,
-							CharAt: 6,
+							CharAt: 5,
 						},
 						Right: &core.LiteralExpression{
 							Type:   "number",
 							Value:  "1",
 							Line:   1,
-							CharAt: 8,
+							CharAt: 7,
 						},
 						Operator: core.Operator{
 							Symbol: "<",
 							Line:   1,
-							CharAt: 7,
+							CharAt: 6,
 						},
 						Line:   1,
-						CharAt: 6,
+						CharAt: 5,
 					},
-					Update: &core.AssignmentStatement{
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     8,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse for statement #9",
+			in:   `for i<1;{}`,
+			want: []core.Statement{
+				core.ForStatement{
+					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
 							Name:   "i",
 							Line:   1,
-							CharAt: 10,
+							CharAt: 5,
 						},
-						Right: &core.BinaryExpression{
-							Left: &core.VariableExpression{
-								Name:   "i",
-								Line:   1,
-								CharAt: 12,
-							},
-							Right: &core.LiteralExpression{
-								Type:   "number",
-								Value:  "1",
-								Line:   1,
-								CharAt: 14,
-							},
-							Operator: core.Operator{
-								Symbol: "+",
-								Line:   1,
-								CharAt: 13,
-							},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "1",
 							Line:   1,
-							CharAt: 12,
+							CharAt: 7,
+						},
+						Operator: core.Operator{
+							Symbol: "<",
+							Line:   1,
+							CharAt: 6,
 						},
 						Line:   1,
-						CharAt: 10,
+						CharAt: 5,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     15,
+						CharAt:     9,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6819,63 +8301,69 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #3",
-			in:   `for i<1;i=i+1{}`,
+			name: "parse for statement #10",
+			in:   `for ;i<1{}`,
 			want: []core.Statement{
 				core.ForStatement{
 					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
 							Name:   "i",
 							Line:   1,
-							CharAt: 5,
+							CharAt: 6,
 						},
 						Right: &core.LiteralExpression{
 							Type:   "number",
 							Value:  "1",
 							Line:   1,
-							CharAt: 7,
+							CharAt: 8,
 						},
 						Operator: core.Operator{
 							Symbol: "<",
 							Line:   1,
-							CharAt: 6,
+							CharAt: 7,
 						},
 						Line:   1,
-						CharAt: 5,
+						CharAt: 6,
 					},
-					Update: &core.AssignmentStatement{
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     9,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse for statement #11",
+			in:   `for ;i<1;{}`,
+			want: []core.Statement{
+				core.ForStatement{
+					Test: &core.BinaryExpression{
 						Left: &core.VariableExpression{
 							Name:   "i",
 							Line:   1,
-							CharAt: 9,
+							CharAt: 6,
 						},
-						Right: &core.BinaryExpression{
-							Left: &core.VariableExpression{
-								Name:   "i",
-								Line:   1,
-								CharAt: 11,
-							},
-							Right: &core.LiteralExpression{
-								Type:   "number",
-								Value:  "1",
-								Line:   1,
-								CharAt: 13,
-							},
-							Operator: core.Operator{
-								Symbol: "+",
-								Line:   1,
-								CharAt: 12,
-							},
+						Right: &core.LiteralExpression{
+							Type:   "number",
+							Value:  "1",
 							Line:   1,
-							CharAt: 11,
+							CharAt: 8,
+						},
+						Operator: core.Operator{
+							Symbol: "<",
+							Line:   1,
+							CharAt: 7,
 						},
 						Line:   1,
-						CharAt: 9,
+						CharAt: 6,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     14,
+						CharAt:     10,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6883,64 +8371,43 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #4",
-			in:   `for i:=0;;i=i+1{}`,
+			name: "parse for statement #12",
+			in:   `for ;;i=i+1{}`,
 			want: []core.Statement{
 				core.ForStatement{
-					Init: &core.VariableDeclaration{
-						Declarations: []core.VariableDeclarator{
-							{
-								ID: core.Identifier{
-									Name:   "i",
-									Line:   1,
-									CharAt: 5,
-								},
-								Init: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "0",
-									Line:   1,
-									CharAt: 8,
-								},
-								Line:   1,
-								CharAt: 5,
-							},
-						},
-						Line:   1,
-						CharAt: 5,
-					},
 					Update: &core.AssignmentStatement{
 						Left: &core.VariableExpression{
 							Name:   "i",
 							Line:   1,
-							CharAt: 11,
+							CharAt: 7,
 						},
 						Right: &core.BinaryExpression{
 							Left: &core.VariableExpression{
 								Name:   "i",
 								Line:   1,
-								CharAt: 13,
+								CharAt: 9,
 							},
 							Right: &core.LiteralExpression{
 								Type:   "number",
 								Value:  "1",
 								Line:   1,
-								CharAt: 15,
+								CharAt: 11,
 							},
 							Operator: core.Operator{
 								Symbol: "+",
 								Line:   1,
-								CharAt: 14,
+								CharAt: 10,
 							},
 							Line:   1,
-							CharAt: 13,
+							CharAt: 9,
 						},
 						Line:   1,
-						CharAt: 11,
+						CharAt: 7,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     16,
+						CharAt:     12,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -6948,8 +8415,23 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #5",
-			in:   `for i:=0;i<1;{}`,
+			name: "parse for statement #13",
+			in:   `for{}`,
+			want: []core.Statement{
+				core.ForStatement{
+					Body: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     4,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse for statement with increment",
+			in:   `for i:=0;i<1;i++{}`,
 			want: []core.Statement{
 				core.ForStatement{
 					Init: &core.VariableDeclaration{
@@ -6961,7 +8443,7 @@ func TestToAST_ForStatement(t *testing.T) {
 									CharAt: 5,
 								},
 								Init: &core.LiteralExpression{
-									Type:   "number",
+									Type:   core.LiteralTypeNumber,
 									Value:  "0",
 									Line:   1,
 									CharAt: 8,
@@ -6980,7 +8462,7 @@ func TestToAST_ForStatement(t *testing.T) {
 							CharAt: 10,
 						},
 						Right: &core.LiteralExpression{
-							Type:   "number",
+							Type:   core.LiteralTypeNumber,
 							Value:  "1",
 							Line:   1,
 							CharAt: 12,
@@ -6993,63 +8475,130 @@ func TestToAST_ForStatement(t *testing.T) {
 						Line:   1,
 						CharAt: 10,
 					},
+					Update: &core.AssignmentStatement{
+						Left: &core.VariableExpression{
+							Name:   "i",
+							Line:   1,
+							CharAt: 14,
+						},
+						Right: &core.LiteralExpression{
+							Type:   core.LiteralTypeNumber,
+							Value:  "1",
+							Line:   1,
+							CharAt: 15,
+						},
+						Operator: &core.Operator{
+							Symbol: "++",
+							Line:   1,
+							CharAt: 15,
+						},
+						Line:   1,
+						CharAt: 14,
+					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     14,
+						CharAt:     17,
 					},
 					Line:   1,
 					CharAt: 1,
 				},
 			},
 		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, _ := ToAST(tokens)
+			require.Equal(t, tt.want, ast)
+		})
+	}
+}
+
+func TestToAST_TryStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+		err  error
+	}{
 		{
-			name: "parse for statement #6",
-			in:   `for i:=0;i<1{}`,
+			name: "parse try statement #1",
+			in:   `try{a()}catch(e){}finally{}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Init: &core.VariableDeclaration{
-						Declarations: []core.VariableDeclarator{
-							{
-								ID: core.Identifier{
-									Name:   "i",
-									Line:   1,
-									CharAt: 5,
-								},
-								Init: &core.LiteralExpression{
-									Type:   "number",
-									Value:  "0",
-									Line:   1,
-									CharAt: 8,
+				core.TryStatement{
+					Block: core.BlockStatement{
+						Statements: []core.Statement{
+							core.ExpressionStatement{
+								Expression: &core.CallExpression{
+									Callee: &core.VariableExpression{
+										Name:   "a",
+										Line:   1,
+										CharAt: 5,
+									},
+									Arguments: []core.Expression{},
+									Line:      1,
+									CharAt:    5,
 								},
 								Line:   1,
 								CharAt: 5,
 							},
 						},
 						Line:   1,
-						CharAt: 5,
+						CharAt: 4,
 					},
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 10,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   1,
-							CharAt: 12,
-						},
-						Operator: core.Operator{
-							Symbol: "<",
-							Line:   1,
-							CharAt: 11,
-						},
+					Param: &core.Identifier{
+						Name:   "e",
 						Line:   1,
-						CharAt: 10,
+						CharAt: 15,
 					},
-					Body: core.BlockStatement{
+					Handler: &core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     17,
+					},
+					Finalizer: &core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     26,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse try statement #2",
+			in:   `try{}catch{}`,
+			want: []core.Statement{
+				core.TryStatement{
+					Block: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     4,
+					},
+					Handler: &core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     11,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse try statement #3",
+			in:   `try{}finally{}`,
+			want: []core.Statement{
+				core.TryStatement{
+					Block: core.BlockStatement{
+						Statements: []core.Statement{},
+						Line:       1,
+						CharAt:     4,
+					},
+					Finalizer: &core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
 						CharAt:     13,
@@ -7060,35 +8609,108 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #7",
-			in:   `for i:=0;;{}`,
+			name: "parse try statement without catch or finally",
+			in:   `try{}`,
+			err:  fmt.Errorf("Parsing error: missing catch or finally after try. [1,5]"),
+		},
+		{
+			name: "parse try statement with invalid catch parameter",
+			in:   `try{}catch(1){}`,
+			err:  fmt.Errorf("Parsing error: cannot parse catch parameter. [1,11]"),
+		},
+		{
+			name: "parse throw statement",
+			in:   `throw "a"`,
 			want: []core.Statement{
-				core.ForStatement{
-					Init: &core.VariableDeclaration{
+				core.ThrowStatement{
+					Argument: &core.LiteralExpression{
+						Type:   "string",
+						Value:  "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse throw statement without argument",
+			in:   `throw`,
+			err:  fmt.Errorf("Parsing error: cannot parse throw statement. [1,1]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, err := ToAST(tokens)
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				require.Equal(t, tt.want, ast)
+			}
+		})
+	}
+}
+
+func TestToAST_ModuleStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+		err  error
+	}{
+		{
+			name: "parse import statement",
+			in:   `import "lib/a.covs" as a`,
+			want: []core.Statement{
+				core.ImportStatement{
+					Path: "lib/a.covs",
+					Alias: core.Identifier{
+						Name:   "a",
+						Line:   1,
+						CharAt: 24,
+					},
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse import statement without alias",
+			in:   `import "lib/a.covs"`,
+			err:  fmt.Errorf("Parsing error: cannot parse import statement. [1,8]"),
+		},
+		{
+			name: "parse import statement with invalid path",
+			in:   `import a as a`,
+			err:  fmt.Errorf("Parsing error: unexpected token 'a', expected module path. [1,8]"),
+		},
+		{
+			name: "parse export statement #1",
+			in:   `export var a = 1`,
+			want: []core.Statement{
+				core.ExportStatement{
+					Declaration: core.VariableDeclaration{
 						Declarations: []core.VariableDeclarator{
 							{
 								ID: core.Identifier{
-									Name:   "i",
+									Name:   "a",
 									Line:   1,
-									CharAt: 5,
+									CharAt: 12,
 								},
 								Init: &core.LiteralExpression{
 									Type:   "number",
-									Value:  "0",
+									Value:  "1",
 									Line:   1,
-									CharAt: 8,
+									CharAt: 16,
 								},
 								Line:   1,
-								CharAt: 5,
+								CharAt: 12,
 							},
 						},
 						Line:   1,
-						CharAt: 5,
-					},
-					Body: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     11,
+						CharAt: 8,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -7096,34 +8718,55 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #8",
-			in:   `for i<1{}`,
+			name: "parse export statement #2",
+			in:   `export func a(){}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 5,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
+				core.ExportStatement{
+					Declaration: core.FunctionDeclaration{
+						ID: core.Identifier{
+							Name:   "a",
 							Line:   1,
-							CharAt: 7,
+							CharAt: 13,
 						},
-						Operator: core.Operator{
-							Symbol: "<",
-							Line:   1,
-							CharAt: 6,
+						Params: []core.Identifier{},
+						Body: core.BlockStatement{
+							Statements: []core.Statement{},
+							Line:       1,
+							CharAt:     16,
 						},
 						Line:   1,
-						CharAt: 5,
+						CharAt: 8,
 					},
-					Body: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     8,
+					Line:   1,
+					CharAt: 1,
+				},
+			},
+		},
+		{
+			name: "parse export statement #3",
+			in:   `export a := 1`,
+			want: []core.Statement{
+				core.ExportStatement{
+					Declaration: core.VariableDeclaration{
+						Declarations: []core.VariableDeclarator{
+							{
+								ID: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 8,
+								},
+								Init: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "1",
+									Line:   1,
+									CharAt: 13,
+								},
+								Line:   1,
+								CharAt: 8,
+							},
+						},
+						Line:   1,
+						CharAt: 8,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -7131,69 +8774,55 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #9",
-			in:   `for i<1;{}`,
+			name: "parse export statement without declaration",
+			in:   `export 1`,
+			err:  fmt.Errorf("Parsing error: unexpected token '1', expected declaration after export. [1,8]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, err := ToAST(tokens)
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				require.Equal(t, tt.want, ast)
+			}
+		})
+	}
+}
+
+func TestToAST_ForInStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+		err  error
+	}{
+		{
+			name: "parse for in statement #1",
+			in:   `for i,v in a{}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 5,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   1,
-							CharAt: 7,
-						},
-						Operator: core.Operator{
-							Symbol: "<",
-							Line:   1,
-							CharAt: 6,
-						},
+				core.ForInStatement{
+					Key: core.Identifier{
+						Name:   "i",
 						Line:   1,
 						CharAt: 5,
 					},
-					Body: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     9,
+					Value: &core.Identifier{
+						Name:   "v",
+						Line:   1,
+						CharAt: 7,
 					},
-					Line:   1,
-					CharAt: 1,
-				},
-			},
-		},
-		{
-			name: "parse for statement #10",
-			in:   `for ;i<1{}`,
-			want: []core.Statement{
-				core.ForStatement{
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 6,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   1,
-							CharAt: 8,
-						},
-						Operator: core.Operator{
-							Symbol: "<",
-							Line:   1,
-							CharAt: 7,
-						},
+					Right: &core.VariableExpression{
+						Name:   "a",
 						Line:   1,
-						CharAt: 6,
+						CharAt: 12,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     9,
+						CharAt:     13,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -7201,34 +8830,40 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #11",
-			in:   `for ;i<1;{}`,
+			name: "parse for in statement #2",
+			in:   `for k in {a:1}{}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Test: &core.BinaryExpression{
-						Left: &core.VariableExpression{
-							Name:   "i",
-							Line:   1,
-							CharAt: 6,
-						},
-						Right: &core.LiteralExpression{
-							Type:   "number",
-							Value:  "1",
-							Line:   1,
-							CharAt: 8,
-						},
-						Operator: core.Operator{
-							Symbol: "<",
-							Line:   1,
-							CharAt: 7,
+				core.ForInStatement{
+					Key: core.Identifier{
+						Name:   "k",
+						Line:   1,
+						CharAt: 5,
+					},
+					Right: &core.ObjectExpression{
+						Properties: []*core.ObjectProperty{
+							{
+								KeyIdentifier: core.Identifier{
+									Name:   "a",
+									Line:   1,
+									CharAt: 11,
+								},
+								Value: &core.LiteralExpression{
+									Type:   "number",
+									Value:  "1",
+									Line:   1,
+									CharAt: 13,
+								},
+								Line:   1,
+								CharAt: 11,
+							},
 						},
 						Line:   1,
-						CharAt: 6,
+						CharAt: 10,
 					},
 					Body: core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     10,
+						CharAt:     15,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -7236,43 +8871,130 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #12",
-			in:   `for ;;i=i+1{}`,
+			name: "parse for in statement with too many variables",
+			in:   `for a,b,c in d{}`,
+			err:  fmt.Errorf("Parsing error: too many variables in for statement. [1,9]"),
+		},
+		{
+			name: "parse for in statement without collection",
+			in:   `for a in`,
+			err:  fmt.Errorf("Parsing error: cannot parse for statement. [1,7]"),
+		},
+		{
+			name: "parse for in statement without body",
+			in:   `for a in b`,
+			err:  fmt.Errorf("Parsing error: unexpected end of statement. [1,10]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := lexer.Lex(tt.in)
+			require.Equal(t, err, nil)
+			ast, err := ToAST(tokens)
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				require.Equal(t, tt.want, ast)
+			}
+		})
+	}
+}
+
+func TestToAST_MatchStatement(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []core.Statement
+		err  error
+	}{
+		{
+			name: "parse match statement with literal and array patterns",
+			in:   `match a{case 1,-2{}case [x,...]if x{}default{}}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Update: &core.AssignmentStatement{
-						Left: &core.VariableExpression{
-							Name:   "i",
+				core.MatchStatement{
+					Discriminant: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					Cases: []core.MatchCase{
+						{
+							Patterns: []core.CasePattern{
+								{
+									Literal: &core.LiteralExpression{
+										Type:   "number",
+										Value:  "1",
+										Line:   1,
+										CharAt: 14,
+									},
+									Line:   1,
+									CharAt: 14,
+								},
+								{
+									Literal: &core.UnaryExpression{
+										Expression: &core.LiteralExpression{
+											Type:   "number",
+											Value:  "2",
+											Line:   1,
+											CharAt: 17,
+										},
+										Operator: "-",
+										Line:     1,
+										CharAt:   16,
+									},
+									Line:   1,
+									CharAt: 16,
+								},
+							},
+							Body: core.BlockStatement{
+								Statements: []core.Statement{},
+								Line:       1,
+								CharAt:     18,
+							},
 							Line:   1,
-							CharAt: 7,
+							CharAt: 9,
 						},
-						Right: &core.BinaryExpression{
-							Left: &core.VariableExpression{
-								Name:   "i",
-								Line:   1,
-								CharAt: 9,
+						{
+							Patterns: []core.CasePattern{
+								{
+									Array: true,
+									Elements: []core.CasePattern{
+										{
+											ID: &core.Identifier{
+												Name:   "x",
+												Line:   1,
+												CharAt: 26,
+											},
+											Line:   1,
+											CharAt: 26,
+										},
+										{
+											Rest:   true,
+											Line:   1,
+											CharAt: 28,
+										},
+									},
+									Line:   1,
+									CharAt: 25,
+								},
 							},
-							Right: &core.LiteralExpression{
-								Type:   "number",
-								Value:  "1",
+							Guard: &core.VariableExpression{
+								Name:   "x",
 								Line:   1,
-								CharAt: 11,
+								CharAt: 35,
 							},
-							Operator: core.Operator{
-								Symbol: "+",
-								Line:   1,
-								CharAt: 10,
+							Body: core.BlockStatement{
+								Statements: []core.Statement{},
+								Line:       1,
+								CharAt:     36,
 							},
 							Line:   1,
-							CharAt: 9,
+							CharAt: 20,
 						},
-						Line:   1,
-						CharAt: 7,
 					},
-					Body: core.BlockStatement{
+					Default: &core.BlockStatement{
 						Statements: []core.Statement{},
 						Line:       1,
-						CharAt:     12,
+						CharAt:     45,
 					},
 					Line:   1,
 					CharAt: 1,
@@ -7280,31 +9002,139 @@ func TestToAST_ForStatement(t *testing.T) {
 			},
 		},
 		{
-			name: "parse for statement #13",
-			in:   `for{}`,
+			name: "parse match statement with object pattern",
+			in:   `match a{case {type:"u",name,...r}{}}`,
 			want: []core.Statement{
-				core.ForStatement{
-					Body: core.BlockStatement{
-						Statements: []core.Statement{},
-						Line:       1,
-						CharAt:     4,
+				core.MatchStatement{
+					Discriminant: &core.VariableExpression{
+						Name:   "a",
+						Line:   1,
+						CharAt: 7,
+					},
+					Cases: []core.MatchCase{
+						{
+							Patterns: []core.CasePattern{
+								{
+									Object: true,
+									Keys:   []string{"type", "name", ""},
+									Elements: []core.CasePattern{
+										{
+											Literal: &core.LiteralExpression{
+												Type:   "string",
+												Value:  "u",
+												Line:   1,
+												CharAt: 20,
+											},
+											Line:   1,
+											CharAt: 20,
+										},
+										{
+											ID: &core.Identifier{
+												Name:   "name",
+												Line:   1,
+												CharAt: 24,
+											},
+											Line:   1,
+											CharAt: 24,
+										},
+										{
+											ID: &core.Identifier{
+												Name:   "r",
+												Line:   1,
+												CharAt: 32,
+											},
+											Rest:   true,
+											Line:   1,
+											CharAt: 29,
+										},
+									},
+									Line:   1,
+									CharAt: 14,
+								},
+							},
+							Body: core.BlockStatement{
+								Statements: []core.Statement{},
+								Line:       1,
+								CharAt:     34,
+							},
+							Line:   1,
+							CharAt: 9,
+						},
 					},
 					Line:   1,
 					CharAt: 1,
 				},
 			},
 		},
+		{
+			name: "parse match statement with case after default",
+			in:   `match a{case 1{}default{}case 2{}}`,
+			err:  fmt.Errorf("Parsing error: default must be the last case of match. [1,26]"),
+		},
+		{
+			name: "parse match statement without case",
+			in:   `match a{foo}`,
+			err:  fmt.Errorf("Parsing error: unexpected token 'foo', expected 'case'. [1,9]"),
+		},
+		{
+			name: "parse match statement with rest element before the last",
+			in:   `match a{case [...r,x]{}}`,
+			err:  fmt.Errorf("Parsing error: rest element must be last. [1,19]"),
+		},
+		{
+			name: "parse match statement with expression pattern",
+			in:   `match a{case x+1{}}`,
+			err:  fmt.Errorf("Parsing error: unexpected token '+', expected '{'. [1,15]"),
+		},
+		{
+			name: "parse unterminated match statement",
+			in:   `match a{case 1{}`,
+			err:  fmt.Errorf("Parsing error: unexpected end of statement. [1,16]"),
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			tokens, err := lexer.Lex(tt.in)
 			require.Equal(t, err, nil)
-			ast, _ := ToAST(tokens)
-			require.Equal(t, tt.want, ast)
+			ast, err := ToAST(tokens)
+			require.Equal(t, tt.err, err)
+			if tt.err == nil {
+				require.Equal(t, tt.want, ast)
+			}
 		})
 	}
 }
 
+func TestToAST_DocComment(t *testing.T) {
+	tokens, err := lexer.Lex(`
+	/// f returns
+	/// nothing
+	func f() {}
+	/// v doc
+	var v = 1
+	/// s doc
+	s := 2
+	/// e doc
+	export func e() {}
+	func g() {
+		/// x doc
+		x := 1
+		/* not a doc */
+		y := 2
+	}`)
+	require.Equal(t, err, nil)
+	stmts, err := ToAST(tokens)
+	require.Equal(t, err, nil)
+	require.Equal(t, "f returns\nnothing", stmts[0].(core.FunctionDeclaration).Doc)
+	require.Equal(t, "v doc", stmts[1].(core.VariableDeclaration).Doc)
+	require.Equal(t, "s doc", stmts[2].(core.VariableDeclaration).Doc)
+	require.Equal(t, "e doc", stmts[3].(core.ExportStatement).Declaration.(core.FunctionDeclaration).Doc)
+	g := stmts[4].(core.FunctionDeclaration)
+	require.Equal(t, "", g.Doc)
+	require.Equal(t, "x doc", g.Body.Statements[0].(core.VariableDeclaration).Doc)
+	require.Equal(t, "", g.Body.Statements[1].(core.VariableDeclaration).Doc)
+}
+
 func Test_TMP(t *testing.T) {
 	cases := []struct {
 		name string
@@ -0,0 +1,632 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+// scope mirrors an execution context created at runtime: a function call, an if, a for, one iteration of
+// a for-in or a block of a try statement. The global scope is nil.
+type scope struct {
+	slots  map[string]int
+	consts map[string]bool // the names declared by const
+	outer  *scope
+}
+
+// function is a function body waiting to be resolved in the scope where the function is created
+type function struct {
+	scope  *scope
+	params []core.Identifier
+	body   core.BlockStatement
+}
+
+type resolver struct {
+	scope     *scope
+	globals   map[string]bool
+	constants map[string]bool // the globals declared by const
+	functions []function
+	warnings  []string
+}
+
+// Resolve binds every local variable of stmts to a slot of the execution context declaring it and reports
+// undeclared variables and illegal redeclarations. Globals are still looked up by name, they are globals
+// and the names declared at the top level of stmts. Constants are the globals which cannot be assigned nor
+// declared again, like constants the names declared by const cannot be in their scope.
+//
+// A variable can be used after its declaration. Function bodies are resolved once every scope around them
+// is complete, so they can use the variables declared after them, like functions calling each other.
+//
+// The warnings are about code which runs but is likely wrong, like a match of literal values without a
+// default case.
+func Resolve(stmts []core.Statement, globals []string, constants []string) ([]string, error) {
+	r := &resolver{globals: map[string]bool{}, constants: map[string]bool{}}
+	for _, g := range globals {
+		r.globals[g] = true
+	}
+	for _, c := range constants {
+		r.constants[c] = true
+	}
+	if err := r.module(stmts); err != nil {
+		return nil, err
+	}
+	for len(r.functions) > 0 {
+		f := r.functions[0]
+		r.functions = r.functions[1:]
+		if err := r.function(f); err != nil {
+			return nil, err
+		}
+	}
+	return r.warnings, nil
+}
+
+func (r *resolver) push() {
+	r.scope = &scope{slots: map[string]int{}, consts: map[string]bool{}, outer: r.scope}
+}
+
+func (r *resolver) pop() {
+	r.scope = r.scope.outer
+}
+
+// declare adds id to the current scope and returns it bound to its slot. Declaring a name again in the same
+// scope reuses its slot, unless it is a constant.
+func (r *resolver) declare(id core.Identifier) (core.Identifier, error) {
+	if r.consts()[id.Name] {
+		return id, fmt.Errorf("Resolving error: cannot redeclare constant %s. [%d,%d]", id.Name, id.Line, id.CharAt)
+	}
+	if r.scope == nil {
+		r.globals[id.Name] = true
+		return id, nil
+	}
+	slot, ok := r.scope.slots[id.Name]
+	if !ok {
+		slot = len(r.scope.slots)
+		r.scope.slots[id.Name] = slot
+	}
+	id.Binding = &core.Binding{Slot: slot}
+	return id, nil
+}
+
+// consts returns the constants of the current scope
+func (r *resolver) consts() map[string]bool {
+	if r.scope == nil {
+		return r.constants
+	}
+	return r.scope.consts
+}
+
+// constant makes the names declared by ids constants of the current scope
+func (r *resolver) constant(ids []core.Identifier) {
+	for _, id := range ids {
+		if id.Pattern != nil {
+			r.constant(id.Pattern.Elements)
+		} else {
+			r.consts()[id.Name] = true
+		}
+	}
+}
+
+// assignable reports an error when the variable name assigned at line, charAt is a constant
+func (r *resolver) assignable(name string, line int, charAt int) error {
+	consts := r.constants
+	for s := r.scope; s != nil; s = s.outer {
+		if _, ok := s.slots[name]; ok {
+			consts = s.consts
+			break
+		}
+	}
+	if consts[name] {
+		return fmt.Errorf("Resolving error: cannot assign to constant %s. [%d,%d]", name, line, charAt)
+	}
+	return nil
+}
+
+// targets checks the variables assigned by left, the left side of an assignment
+func (r *resolver) targets(left core.Expression) error {
+	switch l := left.(type) {
+	case *core.VariableExpression:
+		return r.assignable(l.Name, l.Line, l.CharAt)
+	case *core.TupleExpression:
+		for _, e := range l.Elements {
+			if err := r.targets(e); err != nil {
+				return err
+			}
+		}
+	case *core.Pattern:
+		for _, id := range l.Elements {
+			if id.Pattern != nil {
+				if err := r.targets(id.Pattern); err != nil {
+					return err
+				}
+			} else if err := r.assignable(id.Name, id.Line, id.CharAt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// declareAll declares ids, which are declared by the same statement and must have different names. The
+// identifiers of patterns are declared too, then the default values are resolved so they can use all of them.
+func (r *resolver) declareAll(ids []core.Identifier) error {
+	if err := r.declareNames(ids, map[string]bool{}); err != nil {
+		return err
+	}
+	return r.defaults(ids)
+}
+
+func (r *resolver) declareNames(ids []core.Identifier, seen map[string]bool) error {
+	for i, id := range ids {
+		if id.Pattern != nil {
+			if err := r.declareNames(id.Pattern.Elements, seen); err != nil {
+				return err
+			}
+			continue
+		}
+		if seen[id.Name] {
+			return fmt.Errorf("Resolving error: %s is already declared. [%d,%d]", id.Name, id.Line, id.CharAt)
+		}
+		seen[id.Name] = true
+		d, err := r.declare(id)
+		if err != nil {
+			return err
+		}
+		ids[i] = d
+	}
+	return nil
+}
+
+// defaults resolves the default values of ids and of the identifiers of their patterns
+func (r *resolver) defaults(ids []core.Identifier) error {
+	for _, id := range ids {
+		if id.Default != nil {
+			if err := r.expression(id.Default); err != nil {
+				return err
+			}
+		}
+		if id.Pattern != nil {
+			if err := r.defaults(id.Pattern.Elements); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup finds the binding of the variable name used at line, charAt
+func (r *resolver) lookup(name string, line int, charAt int) (*core.Binding, error) {
+	depth := 0
+	for s := r.scope; s != nil; s = s.outer {
+		if slot, ok := s.slots[name]; ok {
+			return &core.Binding{Depth: depth, Slot: slot}, nil
+		}
+		depth++
+	}
+	if !r.globals[name] {
+		return nil, fmt.Errorf("Resolving error: %s is not defined. [%d,%d]", name, line, charAt)
+	}
+	return nil, nil
+}
+
+// module resolves the top level statements, the only ones which can be exports
+func (r *resolver) module(stmts []core.Statement) error {
+	for i, stmt := range stmts {
+		if s, ok := stmt.(core.ExportStatement); ok {
+			stmt = s.Declaration
+		}
+		s, err := r.statement(stmt)
+		if err != nil {
+			return err
+		}
+		if e, ok := stmts[i].(core.ExportStatement); ok {
+			e.Declaration = s
+			s = e
+		}
+		stmts[i] = s
+	}
+	return nil
+}
+
+func (r *resolver) statements(stmts []core.Statement) error {
+	for i, stmt := range stmts {
+		s, err := r.statement(stmt)
+		if err != nil {
+			return err
+		}
+		stmts[i] = s
+	}
+	return nil
+}
+
+// statement resolves stmt and returns it with its bindings, statements are values so they are copied
+func (r *resolver) statement(stmt core.Statement) (core.Statement, error) {
+	switch s := stmt.(type) {
+	case core.VariableDeclaration:
+		ids := []core.Identifier{}
+		for _, d := range s.Declarations {
+			if d.Init != nil {
+				if err := r.expression(d.Init); err != nil {
+					return nil, err
+				}
+			}
+			ids = append(ids, d.ID)
+		}
+		if err := r.declareAll(ids); err != nil {
+			return nil, err
+		}
+		if s.Const {
+			r.constant(ids)
+		}
+		for i := range s.Declarations {
+			s.Declarations[i].ID = ids[i]
+		}
+		return s, nil
+	case *core.VariableDeclaration:
+		vd, err := r.statement(*s)
+		if err != nil {
+			return nil, err
+		}
+		*s = vd.(core.VariableDeclaration)
+		return s, nil
+	case core.FunctionDeclaration:
+		id, err := r.declare(s.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.ID = id
+		r.later(s.Params, s.Body)
+		return s, nil
+	case core.ReturnStatement:
+		if s.Argument != nil {
+			return s, r.expression(s.Argument)
+		}
+	case core.ThrowStatement:
+		return s, r.expression(s.Argument)
+	case core.ExpressionStatement:
+		return s, r.expression(s.Expression)
+	case core.AssignmentStatement:
+		if err := r.expression(s.Right); err != nil {
+			return nil, err
+		}
+		if err := r.expression(s.Left); err != nil {
+			return nil, err
+		}
+		return s, r.targets(s.Left)
+	case *core.AssignmentStatement:
+		as, err := r.statement(*s)
+		if err != nil {
+			return nil, err
+		}
+		*s = as.(core.AssignmentStatement)
+		return s, nil
+	case core.BlockStatement:
+		return s, r.statements(s.Statements)
+	case core.IfStatement:
+		r.push()
+		defer r.pop()
+		for is := &s; is != nil; is = is.Alternate {
+			if is.Init != nil {
+				init, err := r.statement(is.Init)
+				if err != nil {
+					return nil, err
+				}
+				is.Init = init
+			}
+			if is.Test != nil {
+				if err := r.expression(is.Test); err != nil {
+					return nil, err
+				}
+			}
+			if err := r.statements(is.Consequent.Statements); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case core.ForStatement:
+		r.push()
+		defer r.pop()
+		if s.Init != nil {
+			init, err := r.statement(s.Init)
+			if err != nil {
+				return nil, err
+			}
+			s.Init = init
+		}
+		if s.Test != nil {
+			if err := r.expression(s.Test); err != nil {
+				return nil, err
+			}
+		}
+		if err := r.statements(s.Body.Statements); err != nil {
+			return nil, err
+		}
+		if s.Update != nil {
+			if _, err := r.statement(s.Update); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case core.ForInStatement:
+		if err := r.expression(s.Right); err != nil {
+			return nil, err
+		}
+		r.push()
+		defer r.pop()
+		ids := []core.Identifier{s.Key}
+		if s.Value != nil {
+			ids = append(ids, *s.Value)
+		}
+		if err := r.declareAll(ids); err != nil {
+			return nil, err
+		}
+		s.Key = ids[0]
+		if s.Value != nil {
+			s.Value = &ids[1]
+		}
+		return s, r.statements(s.Body.Statements)
+	case core.TryStatement:
+		if err := r.block(nil, s.Block); err != nil {
+			return nil, err
+		}
+		if s.Handler != nil {
+			params := []core.Identifier{}
+			if s.Param != nil {
+				params = append(params, *s.Param)
+			}
+			if err := r.block(params, *s.Handler); err != nil {
+				return nil, err
+			}
+			if s.Param != nil {
+				s.Param = &params[0]
+			}
+		}
+		if s.Finalizer != nil {
+			if err := r.block(nil, *s.Finalizer); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case core.MatchStatement:
+		if err := r.expression(s.Discriminant); err != nil {
+			return nil, err
+		}
+		for _, c := range s.Cases {
+			if err := r.matchCase(c); err != nil {
+				return nil, err
+			}
+		}
+		if s.Default != nil {
+			if err := r.block(nil, *s.Default); err != nil {
+				return nil, err
+			}
+		}
+		r.exhaustive(s)
+		return s, nil
+	case core.ImportStatement:
+		alias, err := r.declare(s.Alias)
+		if err != nil {
+			return nil, err
+		}
+		s.Alias = alias
+		return s, nil
+	case core.ExportStatement:
+		return nil, fmt.Errorf("Resolving error: export is only allowed at the top level of a module. [%d,%d]", s.Line, s.CharAt)
+	}
+	return stmt, nil
+}
+
+// block resolves a block running in its own execution context where params are declared first
+func (r *resolver) block(params []core.Identifier, block core.BlockStatement) error {
+	r.push()
+	defer r.pop()
+	if err := r.declareAll(params); err != nil {
+		return err
+	}
+	return r.statements(block.Statements)
+}
+
+// matchCase resolves a case of a match statement in its own scope, where each pattern declares its names
+func (r *resolver) matchCase(c core.MatchCase) error {
+	r.push()
+	defer r.pop()
+	var names map[string]bool
+	for i, p := range c.Patterns {
+		seen := map[string]bool{}
+		if err := r.casePattern(p, seen); err != nil {
+			return err
+		}
+		if i == 0 {
+			names = seen
+			continue
+		}
+		if name := differentName(names, seen); name != "" {
+			return fmt.Errorf("Resolving error: %s is not declared by every alternative of the case. [%d,%d]", name, p.Line, p.CharAt)
+		}
+	}
+	if c.Guard != nil {
+		if err := r.expression(c.Guard); err != nil {
+			return err
+		}
+	}
+	return r.statements(c.Body.Statements)
+}
+
+// casePattern declares the names of p, which must be different, and resolves its literals
+func (r *resolver) casePattern(p core.CasePattern, seen map[string]bool) error {
+	if p.Literal != nil {
+		return r.expression(p.Literal)
+	}
+	for _, e := range p.Elements {
+		if err := r.casePattern(e, seen); err != nil {
+			return err
+		}
+	}
+	if p.ID == nil {
+		return nil
+	}
+	if seen[p.ID.Name] {
+		return fmt.Errorf("Resolving error: %s is already declared. [%d,%d]", p.ID.Name, p.ID.Line, p.ID.CharAt)
+	}
+	seen[p.ID.Name] = true
+	id, err := r.declare(*p.ID)
+	if err != nil {
+		return err
+	}
+	*p.ID = id
+	return nil
+}
+
+// differentName returns the first name in alphabetical order which is in only one of a and b, "" when they have
+// the same names
+func differentName(a map[string]bool, b map[string]bool) string {
+	var names []string
+	for name := range a {
+		if !b[name] {
+			names = append(names, name)
+		}
+	}
+	for name := range b {
+		if !a[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// exhaustive warns about a match of enum-like values, whose cases only have literal patterns, without a default
+// case. Unguarded cases of #t and #f cover every boolean.
+func (r *resolver) exhaustive(s core.MatchStatement) {
+	if s.Default != nil || len(s.Cases) == 0 {
+		return
+	}
+	bools := map[string]bool{}
+	for _, c := range s.Cases {
+		for _, p := range c.Patterns {
+			if p.Literal == nil {
+				return
+			}
+			if l, ok := p.Literal.(*core.LiteralExpression); ok && l.Type == core.LiteralTypeBoolean && c.Guard == nil {
+				bools[l.Value] = true
+			}
+		}
+	}
+	if bools["#t"] && bools["#f"] {
+		return
+	}
+	r.warnings = append(r.warnings, fmt.Sprintf("Warning: match is not exhaustive, add a default case. [%d,%d]", s.Line, s.CharAt))
+}
+
+// later queues the body of a function, it is resolved when the scopes around it are complete
+func (r *resolver) later(params []core.Identifier, body core.BlockStatement) {
+	r.functions = append(r.functions, function{scope: r.scope, params: params, body: body})
+}
+
+// function resolves a function body, its parameters are the first slots of the call context
+func (r *resolver) function(f function) error {
+	r.scope = f.scope
+	return r.block(f.params, f.body)
+}
+
+func (r *resolver) expression(exp core.Expression) error {
+	switch e := exp.(type) {
+	case *core.VariableExpression:
+		b, err := r.lookup(e.Name, e.Line, e.CharAt)
+		if err != nil {
+			return err
+		}
+		e.Binding = b
+	case *core.BinaryExpression:
+		if err := r.expression(e.Left); err != nil {
+			return err
+		}
+		return r.expression(e.Right)
+	case *core.UnaryExpression:
+		return r.expression(e.Expression)
+	case *core.TemplateExpression:
+		for _, exp := range e.Expressions {
+			if err := r.expression(exp); err != nil {
+				return err
+			}
+		}
+	case *core.ConditionalExpression:
+		if err := r.expression(e.Test); err != nil {
+			return err
+		}
+		if err := r.expression(e.Consequent); err != nil {
+			return err
+		}
+		return r.expression(e.Alternate)
+	case *core.CallExpression:
+		if err := r.expression(e.Callee); err != nil {
+			return err
+		}
+		for _, arg := range e.Arguments {
+			if err := r.expression(arg); err != nil {
+				return err
+			}
+		}
+	case *core.FunctionExpression:
+		r.later(e.Params, e.Body)
+	case *core.ChainExpression:
+		return r.expression(e.Expression)
+	case *core.SpreadElement:
+		return r.expression(e.Expression)
+	case *core.NamedArgument:
+		return r.expression(e.Value)
+	case *core.TupleExpression:
+		for _, exp := range e.Elements {
+			if err := r.expression(exp); err != nil {
+				return err
+			}
+		}
+	case *core.Pattern: // the left side of an assignment, its identifiers are existing variables
+		for i, id := range e.Elements {
+			if id.Pattern != nil {
+				if err := r.expression(id.Pattern); err != nil {
+					return err
+				}
+			} else {
+				b, err := r.lookup(id.Name, id.Line, id.CharAt)
+				if err != nil {
+					return err
+				}
+				e.Elements[i].Binding = b
+			}
+			if id.Default != nil {
+				if err := r.expression(id.Default); err != nil {
+					return err
+				}
+			}
+		}
+	case *core.MemberAccessExpression:
+		if err := r.expression(e.Object); err != nil {
+			return err
+		}
+		if e.Compute {
+			return r.expression(e.PropertyExpression)
+		}
+	case *core.ArrayExpression:
+		for _, elem := range e.Elements {
+			if err := r.expression(elem); err != nil {
+				return err
+			}
+		}
+	case *core.ObjectExpression:
+		for _, p := range e.Properties {
+			if p.Computed {
+				if err := r.expression(p.KeyExpression); err != nil {
+					return err
+				}
+			}
+			if err := r.expression(p.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
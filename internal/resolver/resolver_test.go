@@ -0,0 +1,273 @@
+package resolver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dhl1402/covidscript/internal/core"
+	"github.com/dhl1402/covidscript/internal/lexer"
+	"github.com/dhl1402/covidscript/internal/parser"
+)
+
+func parse(t *testing.T, in string) []core.Statement {
+	tokens, err := lexer.Lex(in)
+	require.Equal(t, err, nil)
+	stmts, err := parser.ToAST(tokens)
+	require.Equal(t, err, nil)
+	return stmts
+}
+
+func TestResolve(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{
+			name: "resolve globals",
+			in: `
+			a := 1
+			echo(a)`,
+			err: nil,
+		},
+		{
+			name: "resolve function calling a function declared later",
+			in: `
+			func a() {
+				return b()
+			}
+			func b() {}`,
+			err: nil,
+		},
+		{
+			name: "resolve undeclared variable",
+			in:   `echo(a)`,
+			err:  fmt.Errorf("Resolving error: a is not defined. [1,6]"),
+		},
+		{
+			name: "resolve variable used before its declaration",
+			in: `
+			echo(a)
+			a := 1`,
+			err: fmt.Errorf("Resolving error: a is not defined. [2,6]"),
+		},
+		{
+			name: "resolve assignment to undeclared variable",
+			in:   `a = 1`,
+			err:  fmt.Errorf("Resolving error: a is not defined. [1,1]"),
+		},
+		{
+			name: "resolve variable outside of its block",
+			in: `
+			if #t {
+				a := 1
+			}
+			echo(a)`,
+			err: fmt.Errorf("Resolving error: a is not defined. [5,6]"),
+		},
+		{
+			name: "resolve undeclared variable in function",
+			in: `
+			func a() {
+				return b
+			}`,
+			err: fmt.Errorf("Resolving error: b is not defined. [3,8]"),
+		},
+		{
+			name: "resolve duplicate parameters",
+			in:   `func a(b, c, b) {}`,
+			err:  fmt.Errorf("Resolving error: b is already declared. [1,14]"),
+		},
+		{
+			name: "resolve duplicate declarations",
+			in:   `var a, b, a = 1, 2, 3`,
+			err:  fmt.Errorf("Resolving error: a is already declared. [1,11]"),
+		},
+		{
+			name: "resolve duplicate for in variables",
+			in:   `for k, k in [] {}`,
+			err:  fmt.Errorf("Resolving error: k is already declared. [1,8]"),
+		},
+		{
+			name: "resolve redeclaration",
+			in: `
+			var a = 1
+			var a = 2`,
+			err: nil,
+		},
+		{
+			name: "resolve assignment of a global constant",
+			in: `
+			const a = 1
+			func f() {
+				a = 2
+			}`,
+			err: fmt.Errorf("Resolving error: cannot assign to constant a. [4,1]"),
+		},
+		{
+			name: "resolve assignment of a shadowed constant",
+			in: `
+			const a = 1
+			func f() {
+				a := 2
+				a = 3
+				echo := 4
+				echo = a
+			}`,
+			err: nil,
+		},
+		{
+			name: "resolve declaration of a builtin constant",
+			in: `
+			func echo() {}`,
+			err: fmt.Errorf("Resolving error: cannot redeclare constant echo. [2,6]"),
+		},
+		{
+			name: "resolve names of a match case",
+			in: `
+			match [1, 2] {
+				case [a, ...b] if a > 0 {
+					echo(a, b)
+				}
+			}`,
+			err: nil,
+		},
+		{
+			name: "resolve name of a match case outside of its case",
+			in: `
+			match 1 {
+				case a {}
+				default {
+					echo(a)
+				}
+			}`,
+			err: fmt.Errorf("Resolving error: a is not defined. [5,6]"),
+		},
+		{
+			name: "resolve duplicate names in a match pattern",
+			in: `
+			match [1, 2] {
+				case [a, a] {}
+			}`,
+			err: fmt.Errorf("Resolving error: a is already declared. [3,10]"),
+		},
+		{
+			name: "resolve alternatives declaring different names",
+			in: `
+			match [1, 2] {
+				case [a, 2], [1, a] {}
+				case [a, _], [_, b] {}
+			}`,
+			err: fmt.Errorf("Resolving error: a is not declared by every alternative of the case. [4,14]"),
+		},
+		{
+			name: "resolve export in a block",
+			in: `
+			{
+				export a := 1
+			}`,
+			err: fmt.Errorf("Resolving error: export is only allowed at the top level of a module. [3,1]"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve(parse(t, tt.in), []string{"echo"}, []string{"echo"})
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestResolve_Binding(t *testing.T) {
+	stmts := parse(t, `
+	var g = 1
+	func a(b, c) {
+		d := b
+		return func(e) {
+			return c + d + e + g
+		}
+	}`)
+	_, err := Resolve(stmts, []string{}, []string{})
+	require.Equal(t, nil, err)
+
+	require.Nil(t, stmts[0].(core.VariableDeclaration).Declarations[0].ID.Binding)
+	fd := stmts[1].(core.FunctionDeclaration)
+	require.Nil(t, fd.ID.Binding)
+	require.Equal(t, &core.Binding{Slot: 0}, fd.Params[0].Binding)
+	require.Equal(t, &core.Binding{Slot: 1}, fd.Params[1].Binding)
+
+	d := fd.Body.Statements[0].(core.VariableDeclaration)
+	require.Equal(t, &core.Binding{Slot: 2}, d.Declarations[0].ID.Binding)
+	require.Equal(t, &core.Binding{Slot: 0}, d.Declarations[0].Init.(*core.VariableExpression).Binding)
+
+	fexp := fd.Body.Statements[1].(core.ReturnStatement).Argument.(*core.FunctionExpression)
+	require.Equal(t, &core.Binding{Slot: 0}, fexp.Params[0].Binding)
+	sum := fexp.Body.Statements[0].(core.ReturnStatement).Argument.(*core.BinaryExpression)
+	require.Nil(t, sum.Right.(*core.VariableExpression).Binding)
+	sum = sum.Left.(*core.BinaryExpression)
+	require.Equal(t, &core.Binding{Slot: 0}, sum.Right.(*core.VariableExpression).Binding)
+	sum = sum.Left.(*core.BinaryExpression)
+	require.Equal(t, &core.Binding{Depth: 1, Slot: 2}, sum.Right.(*core.VariableExpression).Binding)
+	require.Equal(t, &core.Binding{Depth: 1, Slot: 1}, sum.Left.(*core.VariableExpression).Binding)
+}
+
+func TestResolve_Warnings(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "warn about match of literals without default",
+			in: `
+			func f(color) {
+				match color {
+					case "red" {}
+					case "green", "blue" {}
+				}
+			}`,
+			want: []string{"Warning: match is not exhaustive, add a default case. [3,1]"},
+		},
+		{
+			name: "match of literals with default",
+			in: `
+			match 1 {
+				case 1 {}
+				default {}
+			}`,
+		},
+		{
+			name: "match of every boolean",
+			in: `
+			match #t {
+				case #t {}
+				case #f {}
+			}`,
+		},
+		{
+			name: "match of a guarded boolean",
+			in: `
+			match #t {
+				case #t if echo {}
+				case #f {}
+			}`,
+			want: []string{"Warning: match is not exhaustive, add a default case. [2,1]"},
+		},
+		{
+			name: "match of patterns",
+			in: `
+			match [1] {
+				case [1] {}
+				case {a} {}
+			}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := Resolve(parse(t, tt.in), []string{"echo"}, []string{"echo"})
+			require.Equal(t, nil, err)
+			require.Equal(t, tt.want, warnings)
+		})
+	}
+}
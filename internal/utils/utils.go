@@ -2,14 +2,16 @@ package utils
 
 import "strconv"
 
+var reservedKeywords = map[string]bool{"var": true, "const": true, "func": true, "return": true, "if": true, "else": true, "elif": true, "#t": true, "#f": true, "null": true, "undefined": true, "for": true, "break": true, "continue": true, "try": true, "catch": true, "finally": true, "throw": true, "import": true, "export": true}
+
 func IsReservedKeyword(s string) bool {
-	ss := []string{"var", "func", "return", "if", "else", "elif", "#t", "#f", "null", "undefined", "for", "break", "continue"}
-	return IncludeStr(ss, s)
+	return reservedKeywords[s]
 }
 
+var specialChars = map[string]bool{"=": true, ":": true, ",": true, ".": true, "(": true, ")": true, "{": true, "}": true, "[": true, "]": true, "\"": true, "'": true, "`": true, "+": true, "-": true, "*": true, "/": true, "%": true, "<": true, ">": true, ";": true, "!": true, "?": true, "&": true, "|": true, "^": true, "~": true}
+
 func IsSpecialChars(s string) bool {
-	ss := []string{"=", ":", ",", ".", "(", ")", "{", "}", "[", "]", "\"", "'", "`", "+", "-", "*", "/", "%", "<", ">", ";", "!"}
-	return IncludeStr(ss, s)
+	return specialChars[s]
 }
 
 func IsInteger(s string) bool {
@@ -17,29 +19,22 @@ func IsInteger(s string) bool {
 	return err == nil
 }
 
+var stringBoundaries = map[string]bool{"\"": true, "'": true, "`": true}
+
 func IsStringBoundary(s string) bool {
-	ss := []string{"\"", "'", "`"}
-	return IncludeStr(ss, s)
+	return stringBoundaries[s]
 }
 
+var whiteSpaces = map[string]bool{" ": true, "\t": true, "\b": true}
+
 func IsWhiteSpace(s string) bool {
-	ss := []string{" ", "\t", "\b"}
-	return IncludeStr(ss, s)
+	return whiteSpaces[s]
 }
 
 func IsNewLine(s string) bool {
 	return s == "\n"
 }
 
-func IncludeStr(ss []string, s string) bool {
-	for _, v := range ss {
-		if v == s {
-			return true
-		}
-	}
-	return false
-}
-
 func ToBoolStr(b bool) string {
 	if b {
 		return "#t"
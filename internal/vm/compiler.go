@@ -0,0 +1,782 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dhl1402/covidscript/internal/core"
+)
+
+type loop struct {
+	depth     int // scope depth where break and continue land
+	breaks    []int
+	continues []int
+	fallbacks []*fallback
+}
+
+// try is a try statement being compiled. Jumps leaving it remove its handlers and run its finalizer.
+type try struct {
+	stmt     core.TryStatement
+	depth    int // execution contexts around the statement
+	scopes   int // scopes around the statement
+	loops    int // loops around the statement
+	handlers int // handlers added by the statement for the part being compiled
+}
+
+// scope is a scope of the function being compiled. A flat scope has no execution context, its variables are
+// locals of the frame, locals gives the local of each slot bound by the resolver.
+type scope struct {
+	flat   bool
+	locals map[int]int
+}
+
+// mark is the state of the compiler when it starts a flat scope, it is restored when the scope turns out
+// to need an execution context
+type mark struct {
+	instructions int
+	nodes        int
+	constants    int
+	locals       int
+	pending      int
+	breaks       int
+	continues    int
+	fallbacks    int
+}
+
+type compiler struct {
+	code      *code
+	functions map[interface{}]*code
+	depth     int // execution contexts of the scopes being compiled
+	loops     []*loop
+	tries     []*try
+	chains    [][]int // jumps of the optional links of the chains being compiled, to the end of their chain
+	scopes    []scope // the scopes of the function being compiled, the first one is its call context
+	dynamic   bool    // the flat scope being compiled needs an execution context
+}
+
+// Compile compiles stmts and the body of every function they create
+func Compile(stmts []core.Statement) *Program {
+	functions := map[interface{}]*code{}
+	return &Program{
+		main:      compile(stmts, functions),
+		functions: functions,
+	}
+}
+
+// compile compiles stmts, the bodies of the functions they create are added to functions
+func compile(stmts []core.Statement, functions map[interface{}]*code) *code {
+	c := &compiler{code: &code{}, functions: functions, scopes: []scope{{}}}
+	c.statements(stmts)
+	c.emit(opReturnUndefined, 0, 0)
+	return c.code
+}
+
+// compileFunction compiles the body of a function with params. When its parameters are plain identifiers
+// and its body does not need an execution context, like the body of a flat scope, its call has none.
+func compileFunction(params []core.Identifier, stmts []core.Statement, functions map[interface{}]*code) *code {
+	if !plain(params) {
+		return compile(stmts, functions)
+	}
+	c := &compiler{code: &code{flat: true}, functions: functions, scopes: []scope{{flat: true, locals: map[int]int{}}}}
+	for i := range params {
+		c.scopes[0].locals[i] = i
+	}
+	c.code.locals = len(params)
+	c.statements(stmts)
+	if c.dynamic {
+		return compile(stmts, functions)
+	}
+	c.emit(opReturnUndefined, 0, 0)
+	return c.code
+}
+
+// plain reports whether params are identifiers bound to the slots of the call context in order, without
+// default values
+func plain(params []core.Identifier) bool {
+	for i, p := range params {
+		if p.Rest || p.Pattern != nil || p.Default != nil || p.Binding == nil || p.Binding.Slot != i {
+			return false
+		}
+	}
+	return true
+}
+
+// function compiles the body of the function created by node, a *core.FunctionDeclaration or a
+// *core.FunctionExpression. A function created in a flat scope is compiled once the scope has an execution
+// context, the scope is compiled again then.
+func (c *compiler) function(node interface{}, params []core.Identifier, body core.BlockStatement) {
+	if c.flat() {
+		return
+	}
+	if _, ok := c.functions[node]; !ok {
+		c.functions[node] = compileFunction(params, body.Statements, c.functions)
+	}
+}
+
+func (c *compiler) emit(op opcode, arg int, node int) int {
+	c.code.instructions = append(c.code.instructions, instruction{op: op, arg: arg, node: node})
+	return len(c.code.instructions) - 1
+}
+
+func (c *compiler) emitNode(op opcode, node interface{}) int {
+	return c.emit(op, 0, c.node(node))
+}
+
+// node adds n to the nodes of the code and returns its index
+func (c *compiler) node(n interface{}) int {
+	c.code.nodes = append(c.code.nodes, n)
+	return len(c.code.nodes) - 1
+}
+
+func (c *compiler) emitConst(v *core.LiteralValue) int {
+	c.code.constants = append(c.code.constants, v)
+	return c.emit(opConst, len(c.code.constants)-1, 0)
+}
+
+func (c *compiler) patch(at int) {
+	c.code.instructions[at].arg = len(c.code.instructions)
+}
+
+func (c *compiler) statements(stmts []core.Statement) {
+	for _, s := range stmts {
+		c.statement(s)
+	}
+}
+
+func (c *compiler) statement(stmt core.Statement) {
+	switch s := stmt.(type) {
+	case core.VariableDeclaration:
+		inits := []core.Expression{}
+		for _, d := range s.Declarations {
+			if d.Init != nil {
+				c.expression(d.Init)
+				inits = append(inits, d.Init)
+			}
+		}
+		if locals, ok := c.locals(s); ok {
+			c.emitNode(opDeclareLocals, &declaration{stmt: s, inits: inits, locals: locals})
+		} else {
+			c.emitNode(opDeclareAll, s)
+		}
+		c.code.instructions[len(c.code.instructions)-1].arg = len(inits)
+	case *core.VariableDeclaration:
+		c.statement(*s)
+	case core.FunctionDeclaration:
+		c.needContext()
+		c.function(&s, s.Params, s.Body)
+		c.emitNode(opFunction, &s)
+	case core.ReturnStatement:
+		if s.Argument == nil {
+			c.emitConst(&core.LiteralValue{Type: core.LiteralTypeUndefined})
+		} else {
+			c.expression(s.Argument)
+		}
+		c.leave(0)
+		c.emit(opReturn, 0, 0)
+	case core.BreakStatement:
+		if len(c.loops) == 0 {
+			c.emitNode(opError, core.BreakError{
+				Message: fmt.Sprintf("break is not in a loop. [%d,%d]", s.Line, s.CharAt),
+			})
+			return
+		}
+		l := c.loops[len(c.loops)-1]
+		c.jumpOut(l)
+		l.breaks = append(l.breaks, c.emit(opJump, 0, 0))
+	case core.ContinueStatement:
+		if len(c.loops) == 0 {
+			c.emitNode(opError, core.ContinueError{
+				Message: fmt.Sprintf("continue is not in a loop. [%d,%d]", s.Line, s.CharAt),
+			})
+			return
+		}
+		l := c.loops[len(c.loops)-1]
+		c.jumpOut(l)
+		l.continues = append(l.continues, c.emit(opJump, 0, 0))
+	case core.BlockStatement:
+		c.statements(s.Statements)
+	case core.ExpressionStatement:
+		c.expression(s.Expression)
+		c.emit(opPop, 0, 0)
+	case core.AssignmentStatement:
+		c.assignment(s)
+	case *core.AssignmentStatement:
+		c.assignment(*s)
+	case core.IfStatement:
+		c.ifStatement(s)
+	case core.ForStatement:
+		c.forStatement(s)
+	case core.ForInStatement:
+		c.forInStatement(s)
+	case core.MatchStatement:
+		c.matchStatement(s)
+	case core.TryStatement:
+		c.tryStatement(s)
+	case core.ThrowStatement:
+		c.expression(s.Argument)
+		c.emitNode(opThrow, s)
+	case core.ExportStatement:
+		c.statement(s.Declaration)
+		c.emitNode(opExport, s)
+	default:
+		c.needContext()
+		fb := &fallback{stmt: stmt}
+		if len(c.loops) > 0 {
+			l := c.loops[len(c.loops)-1]
+			fb.loop = true
+			fb.pops = c.depth - l.depth
+			l.fallbacks = append(l.fallbacks, fb)
+		}
+		c.emitNode(opExec, fb)
+	}
+}
+
+// jumpOut emits what runs before a break or a continue of the loop l: the try statements inside l are left
+// and the execution contexts of the scopes inside l are removed
+func (c *compiler) jumpOut(l *loop) {
+	first := len(c.tries)
+	for first > 0 && c.tries[first-1].loops >= len(c.loops) {
+		first--
+	}
+	depth := c.leave(first)
+	for i := l.depth; i < depth; i++ {
+		c.emit(opPopScope, 0, 0)
+	}
+}
+
+// leave emits what runs before a jump out of the try statements being compiled, from the innermost one to
+// c.tries[first]: their handlers are removed and their finalizers run in the scope around them. It returns
+// the number of execution contexts left around the jump.
+func (c *compiler) leave(first int) int {
+	depth := c.depth
+	for i := len(c.tries) - 1; i >= first; i-- {
+		t := c.tries[i]
+		for ; depth > t.depth; depth-- {
+			c.emit(opPopScope, 0, 0)
+		}
+		for h := 0; h < t.handlers; h++ {
+			c.emit(opEndTry, 0, 0)
+		}
+		if t.stmt.Finalizer == nil {
+			continue
+		}
+		scopes, loops, tries, d := c.scopes, c.loops, c.tries, c.depth
+		c.scopes = append([]scope{}, c.scopes[:t.scopes]...)
+		c.loops = append([]*loop{}, c.loops[:t.loops]...)
+		c.tries = append([]*try{}, c.tries[:i]...)
+		c.depth = t.depth
+		c.block(*t.stmt.Finalizer)
+		c.scopes, c.loops, c.tries, c.depth = scopes, loops, tries, d
+	}
+	return depth
+}
+
+// block compiles the statements of a block in their own scope
+func (c *compiler) block(b core.BlockStatement) {
+	c.scope(func() {
+		c.statements(b.Statements)
+	})
+}
+
+func (c *compiler) assignment(s core.AssignmentStatement) {
+	c.expression(s.Right)
+	switch left := s.Left.(type) {
+	case *core.VariableExpression:
+		if left.Binding == nil {
+			c.emitNode(opAssign, s)
+			return
+		}
+		local, depth := c.variable(left.Binding)
+		if local >= 0 {
+			c.emit(opStoreLocal, local, c.node(s))
+		} else {
+			c.emit(opStoreSlot, depth, c.node(s))
+		}
+	case *core.Pattern, *core.TupleExpression:
+		c.needContext()
+		c.emitNode(opAssign, s)
+	case *core.MemberAccessExpression:
+		c.expression(left.Object)
+		if left.Compute {
+			c.expression(left.PropertyExpression)
+		}
+		c.emitNode(opSetMember, s)
+	default:
+		c.emitNode(opError, core.RuntimeErrorf(s.Left.GetLine(), s.Left.GetCharAt(), "cannot identify variable"))
+	}
+}
+
+func (c *compiler) ifStatement(stmt core.IfStatement) {
+	c.scope(func() {
+		ends := []int{}
+		for s := &stmt; s != nil; s = s.Alternate {
+			if s.Init != nil {
+				c.statement(s.Init)
+			}
+			if s.Test == nil {
+				c.statements(s.Consequent.Statements)
+				break
+			}
+			next := c.emit(opJumpIfFalse, 0, c.condition(s.Test))
+			c.statements(s.Consequent.Statements)
+			ends = append(ends, c.emit(opJump, 0, 0))
+			c.patch(next)
+		}
+		for _, end := range ends {
+			c.patch(end)
+		}
+	})
+}
+
+func (c *compiler) forStatement(stmt core.ForStatement) {
+	c.scope(func() {
+		if stmt.Init != nil {
+			c.statement(stmt.Init)
+		}
+		l := c.pushLoop()
+		exit := -1
+		if stmt.Test != nil {
+			exit = c.emit(opJumpIfFalse, 0, c.condition(stmt.Test))
+		}
+		body := len(c.code.instructions)
+		c.statements(stmt.Body.Statements)
+		cont := len(c.code.instructions)
+		if stmt.Update != nil {
+			c.statement(*stmt.Update)
+		}
+		if stmt.Test != nil {
+			c.emit(opJumpIfTrue, body, c.condition(stmt.Test))
+		} else {
+			c.emit(opJump, body, 0)
+		}
+		if exit >= 0 {
+			c.patch(exit)
+		}
+		c.popLoop(l, len(c.code.instructions), cont)
+	})
+}
+
+func (c *compiler) forInStatement(stmt core.ForInStatement) {
+	c.expression(stmt.Right)
+	c.emitNode(opIterInit, stmt)
+	l := c.pushLoop()
+	head := c.emit(opIterNext, 0, 0)
+	c.scope(func() {
+		if stmt.Value != nil {
+			c.declare(*stmt.Value)
+		} else {
+			c.emit(opPop, 0, 0)
+		}
+		c.declare(stmt.Key)
+		c.statements(stmt.Body.Statements)
+	})
+	c.emit(opJump, head, 0)
+	c.patch(head)
+	c.popLoop(l, len(c.code.instructions), head)
+	c.emit(opIterPop, 0, 0)
+}
+
+// matchStatement compiles the cases of stmt one after the other, each in its own scope. The matched value is
+// kept in a local of the frame.
+func (c *compiler) matchStatement(stmt core.MatchStatement) {
+	c.expression(stmt.Discriminant)
+	local := c.code.locals
+	c.code.locals++
+	c.emit(opMatchValue, local, c.node(stmt))
+	ends := []int{}
+	for _, mc := range stmt.Cases {
+		mc := mc
+		end := 0
+		c.scope(func() {
+			matched, fails := []int{}, []int{}
+			for i, p := range mc.Patterns {
+				c.emit(opMatch, local, c.node(c.casePattern(p)))
+				if i < len(mc.Patterns)-1 {
+					matched = append(matched, c.emit(opJumpIfTrue, 0, 0))
+				} else {
+					fails = append(fails, c.emit(opJumpIfFalse, 0, 0))
+				}
+			}
+			for _, j := range matched {
+				c.patch(j)
+			}
+			if mc.Guard != nil {
+				fails = append(fails, c.emit(opJumpIfFalse, 0, c.condition(mc.Guard)))
+			}
+			c.statements(mc.Body.Statements)
+			if !c.flat() {
+				c.emit(opPopScope, 0, 0)
+			}
+			end = c.emit(opJump, 0, 0)
+			for _, j := range fails {
+				c.patch(j)
+			}
+		})
+		ends = append(ends, end)
+	}
+	if stmt.Default != nil {
+		c.block(*stmt.Default)
+	}
+	for _, end := range ends {
+		c.patch(end)
+	}
+}
+
+// casePattern returns p with the locals of the names it declares when the current scope is flat
+func (c *compiler) casePattern(p core.CasePattern) *casePattern {
+	ids := []*core.Identifier{}
+	var names func(p core.CasePattern)
+	names = func(p core.CasePattern) {
+		if p.ID != nil {
+			ids = append(ids, p.ID)
+		}
+		for _, e := range p.Elements {
+			names(e)
+		}
+	}
+	names(p)
+	if !c.flat() {
+		return &casePattern{pattern: p}
+	}
+	locals := map[int]int{}
+	for _, id := range ids {
+		if id.Binding == nil {
+			c.needContext()
+			return &casePattern{pattern: p}
+		}
+		locals[id.Binding.Slot], _ = c.variable(id.Binding)
+	}
+	return &casePattern{pattern: p, locals: locals}
+}
+
+// tryStatement compiles stmt. Errors raised by its block go to its handler, errors raised by its block or
+// its handler go to a copy of its finalizer which raises them again when it completes.
+func (c *compiler) tryStatement(stmt core.TryStatement) {
+	t := &try{stmt: stmt, depth: c.depth, scopes: len(c.scopes), loops: len(c.loops)}
+	finally, pending := 0, c.code.pending
+	if stmt.Finalizer != nil {
+		c.code.pending++
+		finally = c.emit(opFinally, 0, c.node(pending))
+		t.handlers++
+	}
+	c.tries = append(c.tries, t)
+	if stmt.Handler == nil {
+		c.block(stmt.Block)
+	} else {
+		catch := c.emit(opCatch, 0, 0)
+		t.handlers++
+		c.block(stmt.Block)
+		c.emit(opEndTry, 0, 0)
+		t.handlers--
+		end := c.emit(opJump, 0, 0)
+		c.patch(catch)
+		c.scope(func() {
+			if stmt.Param != nil {
+				c.declare(*stmt.Param)
+			} else {
+				c.emit(opPop, 0, 0)
+			}
+			c.statements(stmt.Handler.Statements)
+		})
+		c.patch(end)
+	}
+	c.tries = c.tries[:len(c.tries)-1]
+	if stmt.Finalizer == nil {
+		return
+	}
+	c.emit(opEndTry, 0, 0)
+	c.block(*stmt.Finalizer)
+	end := c.emit(opJump, 0, 0)
+	c.patch(finally)
+	c.block(*stmt.Finalizer)
+	c.emit(opRethrow, pending, 0)
+	c.patch(end)
+}
+
+// scope compiles the body of a scope. The scope is flat unless it needs an execution context, like when a
+// closure captures it or when a statement of its body is executed by the tree-walker. Then every scope
+// around it in the function needs one too, their bodies are compiled again from the outermost flat scope.
+func (c *compiler) scope(body func()) {
+	outer := c.flat()
+	m := c.mark()
+	c.scopes = append(c.scopes, scope{flat: true, locals: map[int]int{}})
+	body()
+	c.scopes = c.scopes[:len(c.scopes)-1]
+	if !c.dynamic || outer {
+		return
+	}
+	c.reset(m)
+	c.dynamic = false
+	c.emit(opPushScope, 0, 0)
+	c.depth++
+	c.scopes = append(c.scopes, scope{})
+	body()
+	c.scopes = c.scopes[:len(c.scopes)-1]
+	c.depth--
+	c.emit(opPopScope, 0, 0)
+}
+
+// flat reports whether the current scope is flat
+func (c *compiler) flat() bool {
+	return c.scopes[len(c.scopes)-1].flat
+}
+
+// needContext records that the current scope needs an execution context
+func (c *compiler) needContext() {
+	if c.flat() {
+		c.dynamic = true
+	}
+}
+
+func (c *compiler) mark() mark {
+	m := mark{
+		instructions: len(c.code.instructions),
+		nodes:        len(c.code.nodes),
+		constants:    len(c.code.constants),
+		locals:       c.code.locals,
+		pending:      c.code.pending,
+	}
+	if len(c.loops) > 0 {
+		l := c.loops[len(c.loops)-1]
+		m.breaks, m.continues, m.fallbacks = len(l.breaks), len(l.continues), len(l.fallbacks)
+	}
+	return m
+}
+
+// reset drops what was compiled since m
+func (c *compiler) reset(m mark) {
+	c.code.instructions = c.code.instructions[:m.instructions]
+	c.code.nodes = c.code.nodes[:m.nodes]
+	c.code.constants = c.code.constants[:m.constants]
+	c.code.locals = m.locals
+	c.code.pending = m.pending
+	if len(c.loops) > 0 {
+		l := c.loops[len(c.loops)-1]
+		l.breaks, l.continues, l.fallbacks = l.breaks[:m.breaks], l.continues[:m.continues], l.fallbacks[:m.fallbacks]
+	}
+}
+
+// variable returns where the variable bound to b is: its local when its scope is flat, otherwise -1 and the
+// number of execution contexts between the current one and the one holding it
+func (c *compiler) variable(b *core.Binding) (int, int) {
+	depth := 0
+	i := len(c.scopes) - 1
+	for d := 0; d < b.Depth; d++ {
+		if i < 0 || !c.scopes[i].flat {
+			depth++
+		}
+		i--
+	}
+	if i < 0 || !c.scopes[i].flat {
+		return -1, depth
+	}
+	local, ok := c.scopes[i].locals[b.Slot]
+	if !ok {
+		local = c.code.locals
+		c.code.locals++
+		c.scopes[i].locals[b.Slot] = local
+	}
+	return local, 0
+}
+
+// locals returns the locals of the variables declared by s when the current scope is flat and they are all
+// plain identifiers bound by the resolver
+func (c *compiler) locals(s core.VariableDeclaration) ([]int, bool) {
+	if !c.flat() {
+		return nil, false
+	}
+	locals := []int{}
+	for _, d := range s.Declarations {
+		if d.ID.Pattern != nil || d.ID.Binding == nil {
+			c.needContext()
+			return nil, false
+		}
+		local, _ := c.variable(d.ID.Binding)
+		locals = append(locals, local)
+	}
+	return locals, true
+}
+
+// declare emits the declaration of id with the value on top of the stack
+func (c *compiler) declare(id core.Identifier) {
+	if !c.flat() || id.Pattern != nil || id.Binding == nil {
+		c.needContext()
+		c.emitNode(opDeclare, id)
+		return
+	}
+	local, _ := c.variable(id.Binding)
+	c.emit(opDeclareLocal, local, 0)
+}
+
+func (c *compiler) pushLoop() *loop {
+	l := &loop{depth: c.depth}
+	c.loops = append(c.loops, l)
+	return l
+}
+
+func (c *compiler) popLoop(l *loop, breakIP int, contIP int) {
+	for _, at := range l.breaks {
+		c.code.instructions[at].arg = breakIP
+	}
+	for _, at := range l.continues {
+		c.code.instructions[at].arg = contIP
+	}
+	for _, fb := range l.fallbacks {
+		fb.breakIP = breakIP
+		fb.contIP = contIP
+	}
+	c.loops = c.loops[:len(c.loops)-1]
+}
+
+func (c *compiler) expression(exp core.Expression) {
+	switch e := exp.(type) {
+	case *core.LiteralExpression:
+		c.emitConst(&core.LiteralValue{
+			Type:  e.Type,
+			Value: e.Value,
+		})
+	case *core.VariableExpression:
+		if e.Binding == nil {
+			c.emitNode(opLoad, e)
+			return
+		}
+		local, depth := c.variable(e.Binding)
+		if local >= 0 {
+			c.emit(opLoadLocal, local, c.node(e))
+		} else {
+			c.emit(opLoadSlot, depth, c.node(e))
+		}
+	case *core.BinaryExpression:
+		c.binary(e)
+	case *core.UnaryExpression:
+		if e.Operator == "!" {
+			c.emit(opNot, 0, c.condition(e.Expression))
+		} else {
+			c.expression(e.Expression)
+			c.emitNode(opUnary, e)
+		}
+	case *core.TemplateExpression:
+		for _, exp := range e.Expressions {
+			c.expression(exp)
+		}
+		c.emitNode(opTemplate, e)
+	case *core.ConditionalExpression:
+		j := c.emit(opJumpIfFalse, 0, c.condition(e.Test))
+		c.expression(e.Consequent)
+		end := c.emit(opJump, 0, 0)
+		c.patch(j)
+		c.expression(e.Alternate)
+		c.patch(end)
+	case *core.SpreadElement:
+		c.expression(e.Expression)
+	case *core.NamedArgument:
+		c.expression(e.Value)
+	case *core.ChainExpression:
+		c.chains = append(c.chains, nil)
+		c.expression(e.Expression)
+		for _, j := range c.chains[len(c.chains)-1] {
+			c.patch(j)
+		}
+		c.chains = c.chains[:len(c.chains)-1]
+	case *core.CallExpression:
+		c.expression(e.Callee)
+		if e.Optional {
+			c.optional()
+		}
+		c.emitNode(opCheckFunction, e)
+		for _, arg := range e.Arguments {
+			c.expression(arg)
+		}
+		c.emitNode(opCall, e)
+		c.code.instructions[len(c.code.instructions)-1].arg = len(e.Arguments)
+	case *core.FunctionExpression:
+		c.needContext()
+		c.function(e, e.Params, e.Body)
+		c.emitNode(opClosure, e)
+	case *core.MemberAccessExpression:
+		c.expression(e.Object)
+		if e.Optional {
+			c.optional()
+		}
+		if e.Compute {
+			c.expression(e.PropertyExpression)
+		}
+		c.emitNode(opMember, e)
+	case *core.ArrayExpression:
+		for _, elem := range e.Elements {
+			c.expression(elem)
+		}
+		c.emitNode(opArray, e)
+	case *core.TupleExpression:
+		for _, elem := range e.Elements {
+			c.expression(elem)
+		}
+		c.emit(opTuple, len(e.Elements), c.node(e))
+	case *core.ObjectExpression:
+		for _, p := range e.Properties {
+			if p.Computed {
+				c.expression(p.KeyExpression)
+			}
+			c.expression(p.Value)
+		}
+		c.emitNode(opObject, e)
+	default:
+		c.needContext()
+		c.emitNode(opEval, exp)
+	}
+}
+
+// condition compiles exp, whose value is tested, and returns its node for the instruction testing it to
+// report a tuple
+func (c *compiler) condition(exp core.Expression) int {
+	c.operand(exp)
+	return c.node(exp)
+}
+
+// operand compiles exp whose value is only read, a literal is not copied
+func (c *compiler) operand(exp core.Expression) {
+	e, ok := exp.(*core.LiteralExpression)
+	if !ok {
+		c.expression(exp)
+		return
+	}
+	c.code.constants = append(c.code.constants, &core.LiteralValue{
+		Type:  e.Type,
+		Value: e.Value,
+	})
+	c.emit(opConstOperand, len(c.code.constants)-1, 0)
+}
+
+// optional emits the jump of an optional link to the end of its chain
+func (c *compiler) optional() {
+	chain := &c.chains[len(c.chains)-1]
+	*chain = append(*chain, c.emit(opOptional, 0, 0))
+}
+
+func (c *compiler) binary(e *core.BinaryExpression) {
+	switch e.Operator.Symbol {
+	case "??":
+		c.expression(e.Left)
+		j := c.emit(opCoalesce, 0, 0)
+		c.expression(e.Right)
+		c.patch(j)
+	case "&&", "||":
+		// the result is always a boolean, like the tree-walker
+		short, jump := "#f", opJumpIfFalse
+		if e.Operator.Symbol == "||" {
+			short, jump = "#t", opJumpIfTrue
+		}
+		j := c.emit(jump, 0, c.condition(e.Left))
+		c.emit(opToBool, 0, c.condition(e.Right))
+		end := c.emit(opJump, 0, 0)
+		c.patch(j)
+		c.emitConst(&core.LiteralValue{
+			Type:  core.LiteralTypeBoolean,
+			Value: short,
+		})
+		c.patch(end)
+	default:
+		c.operand(e.Left)
+		c.operand(e.Right)
+		c.emitNode(opBinary, e)
+	}
+}
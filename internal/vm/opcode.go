@@ -0,0 +1,104 @@
+package vm
+
+import "github.com/dhl1402/covidscript/internal/core"
+
+type opcode byte
+
+const (
+	opConst           opcode = iota // push a copy of constants[arg]
+	opConstOperand                  // push constants[arg] itself, for an operand which is only read
+	opPop                           // discard the top of the stack
+	opLoad                          // push the global variable nodes[node]
+	opLoadLocal                     // push the local arg of the frame, the variable nodes[node]
+	opLoadSlot                      // push the variable nodes[node] from the execution context arg levels up
+	opDeclare                       // pop and declare the identifier or pattern nodes[node] in the current scope
+	opDeclareLocal                  // pop into the local arg of the frame
+	opDeclareAll                    // pop arg values and declare the variables of the declaration nodes[node]
+	opDeclareLocals                 // pop arg values into the locals of the declaration nodes[node]
+	opAssign                        // pop the right side and assign the global, pattern or tuple of the assignment nodes[node]
+	opStoreLocal                    // pop the right side of the assignment nodes[node] into the local arg of the frame
+	opStoreSlot                     // pop the right side of the assignment nodes[node] into the execution context arg levels up
+	opFunction                      // declare the function declaration nodes[node] in the current scope
+	opClosure                       // push a new function from the function expression nodes[node]
+	opArray                         // pop arg elements and push the array nodes[node]
+	opTuple                         // pop arg elements and push the tuple of the tuple expression nodes[node]
+	opObject                        // pop properties and push the object nodes[node]
+	opMember                        // pop property and object then push the member access nodes[node]
+	opSetMember                     // pop property, object and right side then assign the member of the assignment nodes[node]
+	opBinary                        // pop right and left then push the binary expression nodes[node]
+	opTemplate                      // pop the values interpolated by the template expression nodes[node] and push the string
+	opUnary                         // pop and push the unary expression nodes[node]
+	opNot                           // pop and push the negation of its truthiness
+	opToBool                        // pop and push its truthiness
+	opJump                          // jump to arg
+	opJumpIfFalse                   // pop and jump to arg if it is falsy
+	opJumpIfTrue                    // pop and jump to arg if it is truthy
+	opOptional                      // replace the top of the stack by undefined and jump to arg if it is null or undefined
+	opCoalesce                      // jump to arg if the top of the stack is neither null nor undefined, pop it otherwise
+	opCheckFunction                 // fail if the top of the stack is not callable by the call nodes[node]
+	opCall                          // pop arg arguments and the callee then call it
+	opReturn                        // pop and return from the current function
+	opReturnUndefined               // return undefined from the current function
+	opPushScope                     // enter a new block execution context
+	opPopScope                      // leave the current block execution context
+	opIterInit                      // pop and start iterating it for the for-in nodes[node]
+	opIterNext                      // push the next key and value, jump to arg when iteration is over
+	opIterPop                       // stop the current iteration
+	opMatchValue                    // pop into the local arg the value matched by the match statement nodes[node]
+	opMatch                         // push whether the local arg matches the case pattern nodes[node], declaring its names
+	opCatch                         // go to arg when an error is raised, until opEndTry
+	opFinally                       // go to arg when an error is raised, until opEndTry, keeping it in the pending error nodes[node]
+	opEndTry                        // remove the last handler added by opCatch or opFinally
+	opRethrow                       // raise the pending error arg if there is one
+	opThrow                         // pop and throw the value of the throw statement nodes[node]
+	opExport                        // export the names declared by the export statement nodes[node]
+	opExec                          // execute the statement nodes[node] with the tree-walker
+	opEval                          // evaluate the expression nodes[node] with the tree-walker
+	opError                         // fail with the error nodes[node]
+)
+
+type instruction struct {
+	op   opcode
+	arg  int
+	node int
+}
+
+// code is the compiled form of a function body or of a whole script
+type code struct {
+	instructions []instruction
+	constants    []*core.LiteralValue
+	nodes        []interface{}
+	locals       int  // the variables of the flat scopes, at the bottom of the stack of a frame
+	pending      int  // the errors kept while the finalizers of try statements run
+	flat         bool // the call has no execution context, the arguments are the first locals
+}
+
+// declaration is a variable declaration of a flat scope, its variables are the locals of the frame
+type declaration struct {
+	stmt   core.VariableDeclaration
+	inits  []core.Expression
+	locals []int
+}
+
+// casePattern is a pattern of a match case. When the scope of the case is flat, locals gives the local of the
+// slot of each name it declares.
+type casePattern struct {
+	pattern core.CasePattern
+	locals  map[int]int
+}
+
+// Program is the compiled form of a script, it is never modified by Run
+type Program struct {
+	main      *code
+	functions map[interface{}]*code // function bodies, keyed by the declaration or expression of their function
+}
+
+// fallback is a statement the compiler does not handle, it is executed by the tree-walker.
+// When it is inside a loop, break and continue escaping from it are redirected to the loop.
+type fallback struct {
+	stmt    core.Statement
+	loop    bool
+	pops    int
+	breakIP int
+	contIP  int
+}
@@ -0,0 +1,627 @@
+package vm
+
+import (
+	"github.com/dhl1402/covidscript/internal/core"
+	"github.com/dhl1402/covidscript/internal/utils"
+)
+
+// frame is a running call. Its locals, the variables of its flat scopes, are the bottom of its part of the
+// stack.
+type frame struct {
+	code  *code
+	ip    int
+	ec    *core.ExecutionContext
+	base  int // stack size when the frame was entered, where its locals start
+	iters []*iterator
+	call  *core.CallExpression // the call running the frame, nil for the script
+
+	handlers []handler
+	pending  []error // the errors raised before the finalizers being run
+}
+
+// handler is where a frame goes on when an error is raised: the handler or the finalizer of a try statement.
+// The stack, the execution context and the iterations are restored as they were when it was added.
+type handler struct {
+	ip      int
+	catch   bool
+	pending int // where a finalizer keeps the error
+	stack   int
+	ec      *core.ExecutionContext
+	iters   int
+}
+
+type iterator struct {
+	keys   []core.Value
+	values []core.Value
+	next   int
+}
+
+// machine runs a compiled program. Function bodies the compiler could not reach, like functions created by
+// statements executed by the tree-walker, are compiled the first time they are called.
+type machine struct {
+	program   *Program
+	functions map[interface{}]*code
+	stack     []core.Value
+	frames    []frame
+}
+
+// Run executes the compiled program p in the global execution context gec
+func Run(gec *core.ExecutionContext, p *Program) error {
+	m := &machine{
+		program:   p,
+		functions: map[interface{}]*code{},
+	}
+	return m.run(p.main, gec)
+}
+
+// function returns the compiled body of fv, it is keyed by the node creating fv
+func (m *machine) function(fv *core.FunctionValue) *code {
+	if c, ok := m.program.functions[fv.Node]; ok {
+		return c
+	}
+	if fv.Node == nil {
+		return compileFunction(fv.Params, fv.Body.Statements, m.functions)
+	}
+	c, ok := m.functions[fv.Node]
+	if !ok {
+		c = compileFunction(fv.Params, fv.Body.Statements, m.functions)
+		m.functions[fv.Node] = c
+	}
+	return c
+}
+
+// run runs entry in gec. An error raised by an instruction goes to the last handler of the frames, the frames
+// without one are left.
+func (m *machine) run(entry *code, gec *core.ExecutionContext) error {
+	m.stack = make([]core.Value, entry.locals)
+	m.frames = []frame{{code: entry, ec: gec, pending: make([]error, entry.pending)}}
+	for {
+		err := m.exec()
+		if err == nil {
+			return nil
+		}
+		if err = m.raise(err); err != nil {
+			return err
+		}
+	}
+}
+
+// raise gives err to the last handler of the frames, it returns err when there is none. A handler skips
+// break and continue, a frame left by them reports them at its call.
+func (m *machine) raise(err error) error {
+	for {
+		f := &m.frames[len(m.frames)-1]
+		for len(f.handlers) > 0 {
+			h := f.handlers[len(f.handlers)-1]
+			f.handlers = f.handlers[:len(f.handlers)-1]
+			if h.catch && core.IsControlFlowError(err) {
+				continue
+			}
+			m.stack = m.stack[:h.stack]
+			f.ip, f.ec, f.iters = h.ip, h.ec, f.iters[:h.iters]
+			if h.catch {
+				m.stack = append(m.stack, core.ErrorValue(err))
+			} else {
+				f.pending[h.pending] = err
+			}
+			return nil
+		}
+		if f.call == nil {
+			return err
+		}
+		err = escape(f, err)
+		m.stack = m.stack[:f.base]
+		m.frames = m.frames[:len(m.frames)-1]
+	}
+}
+
+// exec runs the instructions of the frames until the script is finished or an error is raised
+func (m *machine) exec() error {
+	stack, frames := m.stack, m.frames
+	f := &frames[len(frames)-1]
+	defer func() {
+		m.stack, m.frames = stack, frames
+	}()
+	pop := func() core.Value {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+	// ret leaves the current frame and reports whether the script is finished
+	ret := func(v core.Value) bool {
+		stack = stack[:f.base]
+		frames = frames[:len(frames)-1]
+		if len(frames) == 0 {
+			return true
+		}
+		f = &frames[len(frames)-1]
+		stack = append(stack, v)
+		return false
+	}
+	for {
+		ins := f.code.instructions[f.ip]
+		f.ip++
+		switch ins.op {
+		case opConst:
+			v := *f.code.constants[ins.arg]
+			stack = append(stack, &v)
+		case opConstOperand:
+			stack = append(stack, f.code.constants[ins.arg])
+		case opPop:
+			pop()
+		case opLoad:
+			vexp := f.code.nodes[ins.node].(*core.VariableExpression)
+			v, ok := f.ec.Lookup(vexp.Name, vexp.Binding)
+			if !ok {
+				return core.RuntimeErrorf(vexp.Line, vexp.CharAt, "%s is not defined", vexp.Name)
+			}
+			stack = append(stack, v)
+		case opLoadLocal:
+			v := stack[f.base+ins.arg]
+			if v == nil {
+				vexp := f.code.nodes[ins.node].(*core.VariableExpression)
+				return core.RuntimeErrorf(vexp.Line, vexp.CharAt, "%s is not defined", vexp.Name)
+			}
+			stack = append(stack, v)
+		case opLoadSlot:
+			vexp := f.code.nodes[ins.node].(*core.VariableExpression)
+			v := slot(f.ec, ins.arg, vexp.Binding.Slot)
+			if v == nil {
+				return core.RuntimeErrorf(vexp.Line, vexp.CharAt, "%s is not defined", vexp.Name)
+			}
+			stack = append(stack, v)
+		case opDeclareLocal:
+			stack[f.base+ins.arg] = pop()
+		case opDeclare:
+			if err := f.ec.Bind(f.code.nodes[ins.node].(core.Identifier), pop()); err != nil {
+				return err
+			}
+		case opDeclareAll:
+			values := make([]core.Value, ins.arg)
+			copy(values, stack[len(stack)-ins.arg:])
+			stack = stack[:len(stack)-ins.arg]
+			if err := f.code.nodes[ins.node].(core.VariableDeclaration).Declare(f.ec, values); err != nil {
+				return err
+			}
+		case opDeclareLocals:
+			d := f.code.nodes[ins.node].(*declaration)
+			values, err := core.Unpack(len(d.locals), d.inits, stack[len(stack)-ins.arg:], d.stmt.Line, d.stmt.CharAt)
+			if err != nil {
+				return err
+			}
+			for i, local := range d.locals {
+				var v core.Value = &core.LiteralValue{Type: core.LiteralTypeUndefined}
+				if i < len(values) {
+					v = values[i]
+				}
+				stack[f.base+local] = v
+			}
+			stack = stack[:len(stack)-ins.arg]
+		case opStoreLocal:
+			s := f.code.nodes[ins.node].(core.AssignmentStatement)
+			v, err := s.Assigned(s.Left.(*core.VariableExpression), stack[f.base+ins.arg], pop())
+			if err != nil {
+				return err
+			}
+			stack[f.base+ins.arg] = v
+		case opStoreSlot:
+			s := f.code.nodes[ins.node].(core.AssignmentStatement)
+			left := s.Left.(*core.VariableExpression)
+			ec := up(f.ec, ins.arg)
+			v, err := s.Assigned(left, slot(ec, 0, left.Binding.Slot), pop())
+			if err != nil {
+				return err
+			}
+			ec.Slots[left.Binding.Slot] = v
+		case opAssign:
+			s := f.code.nodes[ins.node].(core.AssignmentStatement)
+			var err error
+			switch left := s.Left.(type) {
+			case *core.Pattern:
+				err = left.Assign(f.ec, pop())
+			case *core.TupleExpression:
+				err = s.AssignTuple(f.ec, left, pop())
+			default:
+				err = s.AssignVariable(f.ec, left.(*core.VariableExpression), pop())
+			}
+			if err != nil {
+				return err
+			}
+		case opFunction:
+			fd := f.code.nodes[ins.node].(*core.FunctionDeclaration)
+			f.ec.Declare(fd.ID, &core.FunctionValue{
+				Params: fd.Params,
+				Body:   fd.Body,
+				EC:     f.ec,
+				Node:   fd,
+			})
+		case opClosure:
+			fexp := f.code.nodes[ins.node].(*core.FunctionExpression)
+			stack = append(stack, &core.FunctionValue{
+				Params: fexp.Params,
+				Body:   fexp.Body,
+				EC:     f.ec,
+				Node:   fexp,
+			})
+		case opArray:
+			aexp := f.code.nodes[ins.node].(*core.ArrayExpression)
+			elems := make([]core.Value, len(aexp.Elements))
+			copy(elems, stack[len(stack)-len(elems):])
+			stack = stack[:len(stack)-len(elems)]
+			elems, err := core.Spread(aexp.Elements, elems)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, &core.ArrayValue{Elements: elems})
+		case opTuple:
+			texp := f.code.nodes[ins.node].(*core.TupleExpression)
+			elems := make([]core.Value, ins.arg)
+			copy(elems, stack[len(stack)-ins.arg:])
+			stack = stack[:len(stack)-ins.arg]
+			for i, v := range elems {
+				if err := core.SingleValue(v, texp.Elements[i]); err != nil {
+					return err
+				}
+			}
+			stack = append(stack, &core.TupleValue{Elements: elems})
+		case opObject:
+			oexp := f.code.nodes[ins.node].(*core.ObjectExpression)
+			keys := make([]core.Value, len(oexp.Properties))
+			values := make([]core.Value, len(oexp.Properties))
+			for i := len(oexp.Properties) - 1; i >= 0; i-- {
+				values[i] = pop()
+				if oexp.Properties[i].Computed {
+					keys[i] = pop()
+				}
+			}
+			obj, err := oexp.Build(keys, values)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, obj)
+		case opMember:
+			maexp := f.code.nodes[ins.node].(*core.MemberAccessExpression)
+			var prop core.Value
+			if maexp.Compute {
+				prop = pop()
+			}
+			v, err := maexp.Access(pop(), prop)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, v)
+		case opSetMember:
+			s := f.code.nodes[ins.node].(core.AssignmentStatement)
+			maexp := s.Left.(*core.MemberAccessExpression)
+			var prop core.Value
+			if maexp.Compute {
+				prop = pop()
+			}
+			obj := pop()
+			if err := s.AssignMember(maexp, obj, prop, pop()); err != nil {
+				return err
+			}
+		case opBinary:
+			bexp := f.code.nodes[ins.node].(*core.BinaryExpression)
+			right := pop()
+			v, err := bexp.Apply(pop(), right)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, v)
+		case opTemplate:
+			texp := f.code.nodes[ins.node].(*core.TemplateExpression)
+			n := len(texp.Expressions)
+			v := texp.Apply(stack[len(stack)-n:])
+			stack = append(stack[:len(stack)-n], v)
+		case opUnary:
+			uexp := f.code.nodes[ins.node].(*core.UnaryExpression)
+			v := pop()
+			if err := core.SingleValue(v, uexp.Expression); err != nil {
+				return err
+			}
+			v, err := uexp.Apply(v)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, v)
+		case opNot:
+			v := pop()
+			if err := condition(f, ins, v); err != nil {
+				return err
+			}
+			stack = append(stack, &core.LiteralValue{
+				Type:  core.LiteralTypeBoolean,
+				Value: utils.ToBoolStr(!v.IsTruthy()),
+			})
+		case opToBool:
+			v := pop()
+			if err := condition(f, ins, v); err != nil {
+				return err
+			}
+			stack = append(stack, &core.LiteralValue{
+				Type:  core.LiteralTypeBoolean,
+				Value: utils.ToBoolStr(v.IsTruthy()),
+			})
+		case opJump:
+			f.ip = ins.arg
+		case opJumpIfFalse:
+			v := pop()
+			if err := condition(f, ins, v); err != nil {
+				return err
+			}
+			if !v.IsTruthy() {
+				f.ip = ins.arg
+			}
+		case opJumpIfTrue:
+			v := pop()
+			if err := condition(f, ins, v); err != nil {
+				return err
+			}
+			if v.IsTruthy() {
+				f.ip = ins.arg
+			}
+		case opOptional:
+			if core.IsNullish(stack[len(stack)-1]) {
+				stack[len(stack)-1] = &core.LiteralValue{Type: core.LiteralTypeUndefined}
+				f.ip = ins.arg
+			}
+		case opCoalesce:
+			if !core.IsNullish(stack[len(stack)-1]) {
+				f.ip = ins.arg
+			} else {
+				pop()
+			}
+		case opCheckFunction:
+			if _, ok := stack[len(stack)-1].(*core.FunctionValue); !ok {
+				cexp := f.code.nodes[ins.node].(*core.CallExpression)
+				return core.RuntimeErrorf(cexp.Line, cexp.CharAt, "%s is not a function", cexp.Callee.ToString())
+			}
+		case opCall:
+			cexp := f.code.nodes[ins.node].(*core.CallExpression)
+			at := len(stack) - ins.arg
+			fv := stack[at-1].(*core.FunctionValue)
+			args, err := arguments(fv, cexp, stack[at:])
+			if err != nil {
+				return err
+			}
+			var c *code
+			if fv.NativeFunction == nil {
+				c = m.function(fv)
+			}
+			if c != nil && c.flat {
+				// the arguments are moved down in place of the callee, they are the first locals of the frame
+				stack = stack[:at-1]
+				for i := range fv.Params {
+					var v core.Value = &core.LiteralValue{Type: core.LiteralTypeUndefined}
+					if i < len(args) && args[i] != nil {
+						v = args[i]
+					}
+					stack = append(stack, v)
+				}
+				frames = append(frames, frame{
+					code: c,
+					ec:   fv.EC,
+					base: at - 1,
+					call: cexp,
+				})
+			} else {
+				// the context keeps the arguments, they must not be on the stack
+				args = append([]core.Value{}, args...)
+				stack = stack[:at-1]
+				fEC, err := context(fv, args)
+				if err != nil {
+					return err
+				}
+				if c == nil {
+					rv, err := fv.NativeFunction(fEC)
+					if err != nil {
+						return core.Locate(err, cexp.Line, cexp.CharAt)
+					}
+					stack = append(stack, rv)
+					continue
+				}
+				frames = append(frames, frame{
+					code: c,
+					ec:   fEC,
+					base: len(stack),
+					call: cexp,
+				})
+			}
+			f = &frames[len(frames)-1]
+			if c.pending > 0 {
+				f.pending = make([]error, c.pending)
+			}
+			for len(stack) < f.base+c.locals {
+				stack = append(stack, nil)
+			}
+		case opReturn:
+			if ret(pop()) {
+				return nil
+			}
+		case opReturnUndefined:
+			if ret(&core.LiteralValue{Type: core.LiteralTypeUndefined}) {
+				return nil
+			}
+		case opPushScope:
+			f.ec = &core.ExecutionContext{
+				Type:  core.TypeBlockEC,
+				Outer: f.ec,
+			}
+		case opPopScope:
+			f.ec = f.ec.Outer
+		case opIterInit:
+			stmt := f.code.nodes[ins.node].(core.ForInStatement)
+			keys, values, err := stmt.Entries(pop())
+			if err != nil {
+				return err
+			}
+			f.iters = append(f.iters, &iterator{keys: keys, values: values})
+		case opIterNext:
+			it := f.iters[len(f.iters)-1]
+			if it.next >= len(it.keys) {
+				f.ip = ins.arg
+				continue
+			}
+			stack = append(stack, it.keys[it.next], it.values[it.next])
+			it.next++
+		case opIterPop:
+			f.iters = f.iters[:len(f.iters)-1]
+		case opMatchValue:
+			stmt := f.code.nodes[ins.node].(core.MatchStatement)
+			v := pop()
+			if err := core.SingleValue(v, stmt.Discriminant); err != nil {
+				return err
+			}
+			stack[f.base+ins.arg] = v
+		case opMatch:
+			cp := f.code.nodes[ins.node].(*casePattern)
+			bind := f.ec.Declare
+			if cp.locals != nil {
+				base := f.base
+				bind = func(id core.Identifier, v core.Value) {
+					stack[base+cp.locals[id.Binding.Slot]] = v
+				}
+			}
+			ok, err := cp.pattern.MatchWith(f.ec, stack[f.base+ins.arg], bind)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, &core.LiteralValue{
+				Type:  core.LiteralTypeBoolean,
+				Value: utils.ToBoolStr(ok),
+			})
+		case opCatch, opFinally:
+			h := handler{ip: ins.arg, catch: ins.op == opCatch, stack: len(stack), ec: f.ec, iters: len(f.iters)}
+			if !h.catch {
+				h.pending = f.code.nodes[ins.node].(int)
+				f.pending[h.pending] = nil
+			}
+			f.handlers = append(f.handlers, h)
+		case opEndTry:
+			f.handlers = f.handlers[:len(f.handlers)-1]
+		case opRethrow:
+			if err := f.pending[ins.arg]; err != nil {
+				f.pending[ins.arg] = nil
+				return err
+			}
+		case opThrow:
+			return f.code.nodes[ins.node].(core.ThrowStatement).Throw(pop())
+		case opExport:
+			s := f.code.nodes[ins.node].(core.ExportStatement)
+			if f.ec.Type != core.TypeGlobalEC {
+				return core.RuntimeErrorf(s.Line, s.CharAt, "export is only allowed at the top level of a module")
+			}
+			s.Export(f.ec)
+		case opExec:
+			fb := f.code.nodes[ins.node].(*fallback)
+			rv, err := fb.stmt.Execute(f.ec)
+			if fb.loop {
+				_, isBreak := err.(core.BreakError)
+				_, isContinue := err.(core.ContinueError)
+				if isBreak || isContinue {
+					for i := 0; i < fb.pops; i++ {
+						f.ec = f.ec.Outer
+					}
+					f.ip = fb.contIP
+					if isBreak {
+						f.ip = fb.breakIP
+					}
+					continue
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if rv != nil && ret(rv) {
+				return nil
+			}
+		case opEval:
+			v, err := f.code.nodes[ins.node].(core.Expression).Evaluate(f.ec)
+			if err != nil {
+				return err
+			}
+			stack = append(stack, v)
+		case opError:
+			return f.code.nodes[ins.node].(error)
+		}
+	}
+}
+
+// condition returns an error when v, the value tested by ins, is a tuple
+func condition(f *frame, ins instruction, v core.Value) error {
+	if _, ok := v.(*core.TupleValue); !ok {
+		return nil
+	}
+	return core.SingleValue(v, f.code.nodes[ins.node].(core.Expression))
+}
+
+// up returns the execution context depth levels above ec
+func up(ec *core.ExecutionContext, depth int) *core.ExecutionContext {
+	for i := 0; i < depth; i++ {
+		ec = ec.Outer
+	}
+	return ec
+}
+
+// slot returns the variable in the slot i of the execution context depth levels above ec, nil when it is
+// not declared
+func slot(ec *core.ExecutionContext, depth int, i int) core.Value {
+	ec = up(ec, depth)
+	if i < len(ec.Slots) {
+		return ec.Slots[i]
+	}
+	return nil
+}
+
+// arguments returns the arguments of the call cexp from the evaluated values, like
+// core.FunctionValue.Arguments. values are the arguments when the call has neither spread nor named ones.
+func arguments(fv *core.FunctionValue, cexp *core.CallExpression, values []core.Value) ([]core.Value, error) {
+	for i, exp := range cexp.Arguments {
+		switch exp.(type) {
+		case *core.SpreadElement, *core.NamedArgument:
+			return fv.Arguments(cexp.Arguments, values)
+		}
+		if err := core.SingleValue(values[i], exp); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// context creates the execution context of a call to fv with args, like core.FunctionValue.CallContext.
+// When every parameter is a plain identifier, args are the slots of the context.
+func context(fv *core.FunctionValue, args []core.Value) (*core.ExecutionContext, error) {
+	if fv.NativeFunction != nil {
+		return fv.CallContext(args)
+	}
+	for i, p := range fv.Params {
+		if p.Rest || p.Pattern != nil || p.Default != nil || p.Binding == nil || p.Binding.Slot != i {
+			return fv.CallContext(args)
+		}
+	}
+	slots := args
+	if len(args) != len(fv.Params) {
+		slots = make([]core.Value, len(fv.Params))
+		copy(slots, args)
+	}
+	for i, v := range slots {
+		if v == nil {
+			slots[i] = &core.LiteralValue{Type: core.LiteralTypeUndefined}
+		}
+	}
+	return &core.ExecutionContext{
+		Type:  core.TypeFunctionEC,
+		Outer: fv.EC,
+		Slots: slots,
+	}, nil
+}
+
+// escape returns err leaving the frame f, break and continue cannot leave a loop of the caller like with
+// core.FunctionValue.Call
+func escape(f *frame, err error) error {
+	if f.call == nil || !core.IsControlFlowError(err) {
+		return err
+	}
+	return core.Locate(core.Escaped(err), f.call.Line, f.call.CharAt)
+}
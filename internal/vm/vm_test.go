@@ -0,0 +1,232 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dhl1402/covidscript/internal/builtin"
+	"github.com/dhl1402/covidscript/internal/config"
+	"github.com/dhl1402/covidscript/internal/core"
+	"github.com/dhl1402/covidscript/internal/lexer"
+	"github.com/dhl1402/covidscript/internal/parser"
+	"github.com/dhl1402/covidscript/internal/resolver"
+	"github.com/stretchr/testify/require"
+)
+
+func compileScript(t *testing.T, in string) *Program {
+	tokens, err := lexer.Lex(in)
+	require.Equal(t, nil, err)
+	stmts, err := parser.ToAST(tokens)
+	require.Equal(t, nil, err)
+	_, err = resolver.Resolve(stmts, []string{"echo"}, []string{"echo"})
+	require.Equal(t, nil, err)
+	return Compile(stmts)
+}
+
+func globalEC(out *bytes.Buffer) *core.ExecutionContext {
+	return &core.ExecutionContext{
+		Type:      core.TypeGlobalEC,
+		Variables: map[string]core.Value{"echo": builtin.Echo(config.Config{Writer: out})},
+		Constants: map[string]bool{"echo": true},
+	}
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		err  error
+	}{
+		{
+			name: "run recursive function",
+			in: `
+func fib(n) {
+  if n < 2 { return n }
+  return fib(n-1) + fib(n-2)
+}
+echo(fib(10))`,
+			out: "55 \n",
+		},
+		{
+			name: "run closure",
+			in: `
+func counter() {
+  var n = 0
+  return func() { n = n + 1; return n }
+}
+var c = counter()
+c()
+echo(c())`,
+			out: "2 \n",
+		},
+		{
+			name: "run function with empty body",
+			in: `
+f := func() {}
+g := func() {}
+echo(f(), g())`,
+			out: "undefined undefined \n",
+		},
+		{
+			name: "run try catch",
+			in: `
+try {
+  throw "a"
+  echo("b")
+} catch (e) {
+  echo(e.message)
+}`,
+			out: "a \n",
+		},
+		{
+			name: "run finally after error in called function",
+			in: `
+func f() { throw "a" }
+try {
+  try { f() } finally { echo("finally") }
+} catch (e) {
+  echo(e.message)
+}`,
+			out: "finally \na \n",
+		},
+		{
+			name: "run finally on return",
+			in: `
+func f() {
+  try { return 1 } finally { echo("finally") }
+}
+echo(f())`,
+			out: "finally \n1 \n",
+		},
+		{
+			name: "run return in finally",
+			in: `
+func f() {
+  try { throw "a" } finally { return 2 }
+}
+echo(f())`,
+			out: "2 \n",
+		},
+		{
+			name: "run break in try",
+			in: `
+for i := 0; i < 3; i++ {
+  try {
+    if i == 1 { break }
+    echo(i)
+  } finally {
+    echo("finally", i)
+  }
+}`,
+			out: "0 \nfinally 0 \nfinally 1 \n",
+		},
+		{
+			name: "run continue in catch",
+			in: `
+for i := 0; i < 2; i++ {
+  try { throw i } catch (e) { continue }
+  echo("never")
+}
+echo("done")`,
+			out: "done \n",
+		},
+		{
+			name: "run match",
+			in: `
+func f(v) {
+  match v {
+    case 0 { return "zero" }
+    case [a, b] { return a + b }
+    case {x} if x > 1 { return x }
+    default { return "other" }
+  }
+}
+echo(f(0), f([1, 2]), f({x: 3}), f({x: 1}))`,
+			out: "zero 3 3 other \n",
+		},
+		{
+			name: "run tuple",
+			in: `
+a, b := 1, 2
+a, b = b, a
+echo(a, b)`,
+			out: "2 1 \n",
+		},
+		{
+			name: "run uncaught throw",
+			in: `
+func f() {
+  throw "a"
+}
+f()`,
+			err: fmt.Errorf("Runtime error: uncaught exception: a. [3,1]"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := Run(globalEC(out), compileScript(t, c.in))
+			if c.err != nil {
+				require.EqualError(t, err, c.err.Error())
+			} else {
+				require.Equal(t, nil, err)
+			}
+			require.Equal(t, c.out, out.String())
+		})
+	}
+}
+
+func TestCompile_Functions(t *testing.T) {
+	p := compileScript(t, `
+f := func() {}
+g := func() {}
+func h(a, b) { return a + b }
+func k(a = 1) { return a }
+func l(a) { return func() { return a } }
+echo(f(), g(), h(1, 2), k(), l(1)())`)
+	require.Equal(t, 6, len(p.functions))
+	for node, c := range p.functions {
+		switch n := node.(type) {
+		case *core.FunctionDeclaration:
+			require.Equal(t, n.ID.Name == "h", c.flat, n.ID.Name)
+		case *core.FunctionExpression:
+			require.Equal(t, true, c.flat)
+		}
+	}
+
+	m := &machine{program: p, functions: map[interface{}]*code{}}
+	err := m.run(p.main, globalEC(&bytes.Buffer{}))
+	require.Equal(t, nil, err)
+	require.Equal(t, 0, len(m.functions))
+}
+
+func TestCompile_Native(t *testing.T) {
+	p := compileScript(t, `
+func f(v) {
+  try {
+    match v {
+      case [a, b] { throw a + b }
+      default { return v }
+    }
+  } catch (e) {
+    return e
+  } finally {
+    echo("finally")
+  }
+}
+for i := 0; i < 2; i++ {
+  echo(f([i, i]))
+}`)
+	codes := []*code{p.main}
+	for _, c := range p.functions {
+		codes = append(codes, c)
+	}
+	for _, c := range codes {
+		for _, in := range c.instructions {
+			require.NotEqual(t, opExec, in.op)
+			require.NotEqual(t, opEval, in.op)
+		}
+	}
+}